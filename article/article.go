@@ -0,0 +1,541 @@
+// Package article extracts readable text and metadata from news article
+// pages, removing navigation, ads and other boilerplate.
+//
+// Extraction prefers the publisher's structured data (JSON-LD NewsArticle)
+// for metadata and the article body container for text, falling back to
+// Open Graph tags and the whole page respectively.
+package article
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+)
+
+// Defaults for fetching and caching articles.
+const (
+	DefaultMaxChars = 8000
+	CacheTTL        = 30 * time.Minute
+	cacheSize       = 64
+)
+
+// Article is the readable content of a news page.
+type Article struct {
+	URL       string
+	Title     string
+	Publisher string
+	Author    string
+	Published time.Time
+	// Text holds the body as paragraphs separated by blank lines.
+	Text string
+	// Paywalled is set when the page marks its content as subscriber-only;
+	// Text then holds only the free preview, if any.
+	Paywalled bool
+	// Truncated is set by Limit when Text was shortened.
+	Truncated bool
+}
+
+// Limit returns a copy of a with Text cut to at most maxChars characters at
+// a paragraph, or failing that a word, boundary.
+func (a Article) Limit(maxChars int) Article {
+	if maxChars <= 0 || utf8.RuneCountInString(a.Text) <= maxChars {
+		return a
+	}
+	cut := a.Text
+	for i := range a.Text {
+		if maxChars == 0 {
+			cut = a.Text[:i]
+			break
+		}
+		maxChars--
+	}
+	if p := strings.LastIndex(cut, "\n\n"); p > len(cut)/2 {
+		cut = cut[:p]
+	} else if w := strings.LastIndexByte(cut, ' '); w > 0 {
+		cut = cut[:w]
+	}
+	a.Text = strings.TrimSpace(cut)
+	a.Truncated = true
+	return a
+}
+
+// contentContainers identify an article's body by class name prefix or
+// data-testid, most specific first. Yahoo uses caas-body on syndicated
+// stories and atoms-wrapper or article-body on newer pages.
+var (
+	contentClasses = []string{"caas-body", "atoms-wrapper", "article-body", "article__body", "story-body", "articleBody"}
+	contentTestIDs = []string{"article-body", "article-content"}
+)
+
+// skippedElements never contain article text.
+var skippedElements = map[string]bool{
+	"script": true, "style": true, "noscript": true, "template": true, "svg": true, "iframe": true,
+	"nav": true, "aside": true, "header": true, "footer": true, "form": true, "button": true,
+	"figure": true, "figcaption": true, "select": true,
+}
+
+// skippedClasses mark ads, share bars and related-story modules inside the
+// body container.
+var skippedClasses = []string{"caas-da", "caas-figure", "caas-readmore", "ad-", "ads-", "advert", "related", "newsletter", "social", "share", "recirc", "read-more"}
+
+// blockElements delimit paragraphs.
+var blockElements = map[string]bool{
+	"p": true, "h1": true, "h2": true, "h3": true, "h4": true, "li": true, "blockquote": true, "pre": true,
+}
+
+// boilerplate phrases mark short paragraphs that are site chrome rather than
+// article text.
+var boilerplate = []string{
+	"story continues", "advertisement", "sign in to", "read more", "click here", "download the app",
+	"follow us", "recommended stories", "sponsored", "most read", "view comments", "all rights reserved",
+}
+
+// paywallPhrases in a short paragraph mark a subscriber-only article.
+var paywallPhrases = []string{
+	"subscribe to continue", "to continue reading", "for subscribers only", "already a subscriber",
+	"subscribe to read", "this article is reserved for", "unlock this article",
+}
+
+// maxChromeLength is the longest paragraph tested against boilerplate and
+// paywall phrases; longer ones are taken to be article text.
+const maxChromeLength = 160
+
+// Extract parses an article page. pageURL is recorded on the result.
+func Extract(doc []byte, pageURL string) (*Article, error) {
+	tokens := tokenize(string(doc))
+	a := &Article{URL: pageURL}
+
+	meta := readMeta(tokens)
+	ld := readLinkedData(tokens)
+
+	a.Title = firstNonEmpty(ld.headline, meta["og:title"], meta["twitter:title"], meta["<title>"])
+	a.Publisher = firstNonEmpty(ld.publisher, meta["og:site_name"], meta["application-name"])
+	a.Author = firstNonEmpty(ld.author, nonURL(meta["author"]), nonURL(meta["article:author"]), meta["byl"])
+	for _, v := range []string{ld.published, meta["article:published_time"], meta["og:article:published_time"], meta["<time>"]} {
+		if t, ok := parseTime(v); ok {
+			a.Published = t
+			break
+		}
+	}
+
+	paragraphs, paywall := readBody(tokens)
+	a.Paywalled = paywall || ld.locked || isLockedTier(meta["article:content_tier"])
+
+	text := strings.Join(paragraphs, "\n\n")
+	if body := strings.TrimSpace(ld.body); len(body) > len(text) {
+		text = normalizeParagraphs(body)
+	}
+	a.Text = text
+
+	if a.Text == "" && !a.Paywalled {
+		return nil, fmt.Errorf("no article text found")
+	}
+	return a, nil
+}
+
+// readMeta collects <meta> values by property or name, plus the document
+// <title> and first <time datetime> under the keys "<title>" and "<time>".
+func readMeta(tokens []token) map[string]string {
+	meta := make(map[string]string)
+	for i, t := range tokens {
+		if t.kind != startTag {
+			continue
+		}
+		switch t.name {
+		case "meta":
+			key := strings.ToLower(firstNonEmpty(t.attr("property"), t.attr("name"), t.attr("itemprop")))
+			if content := strings.TrimSpace(t.attr("content")); key != "" && content != "" && meta[key] == "" {
+				meta[key] = content
+			}
+		case "title":
+			if meta["<title>"] == "" && i+1 < len(tokens) && tokens[i+1].kind == textToken {
+				meta["<title>"] = collapseSpace(tokens[i+1].text)
+			}
+		case "time":
+			if meta["<time>"] == "" && t.attr("datetime") != "" {
+				meta["<time>"] = t.attr("datetime")
+			}
+		}
+	}
+	return meta
+}
+
+// linkedData is the article metadata found in JSON-LD.
+type linkedData struct {
+	headline, author, publisher, published, body string
+	locked                                       bool
+}
+
+var articleTypes = map[string]bool{
+	"NewsArticle": true, "Article": true, "ReportageNewsArticle": true, "AnalysisNewsArticle": true,
+	"BlogPosting": true, "OpinionNewsArticle": true,
+}
+
+// readLinkedData returns metadata from the first JSON-LD article object.
+func readLinkedData(tokens []token) linkedData {
+	for i, t := range tokens {
+		if t.kind != startTag || t.name != "script" || !strings.Contains(t.attr("type"), "ld+json") {
+			continue
+		}
+		if i+1 >= len(tokens) || tokens[i+1].kind != textToken {
+			continue
+		}
+		var v any
+		if err := json.Unmarshal([]byte(tokens[i+1].text), &v); err != nil {
+			continue
+		}
+		if obj := findArticle(v); obj != nil {
+			return linkedData{
+				headline:  collapseSpace(str(obj["headline"])),
+				author:    names(obj["author"]),
+				publisher: names(obj["publisher"]),
+				published: str(obj["datePublished"]),
+				body:      str(obj["articleBody"]),
+				locked:    isFalse(obj["isAccessibleForFree"]),
+			}
+		}
+	}
+	return linkedData{}
+}
+
+// findArticle searches a decoded JSON-LD value, including @graph arrays, for
+// an object whose @type is an article type.
+func findArticle(v any) map[string]any {
+	switch v := v.(type) {
+	case []any:
+		for _, e := range v {
+			if obj := findArticle(e); obj != nil {
+				return obj
+			}
+		}
+	case map[string]any:
+		switch t := v["@type"].(type) {
+		case string:
+			if articleTypes[t] {
+				return v
+			}
+		case []any:
+			for _, e := range t {
+				if s, ok := e.(string); ok && articleTypes[s] {
+					return v
+				}
+			}
+		}
+		if g, ok := v["@graph"]; ok {
+			return findArticle(g)
+		}
+	}
+	return nil
+}
+
+// names joins the name of a JSON-LD person or organization, or a list of them.
+func names(v any) string {
+	switch v := v.(type) {
+	case string:
+		return nonURL(v)
+	case map[string]any:
+		return collapseSpace(str(v["name"]))
+	case []any:
+		var out []string
+		for _, e := range v {
+			if n := names(e); n != "" && !containsString(out, n) {
+				out = append(out, n)
+			}
+		}
+		return strings.Join(out, ", ")
+	}
+	return ""
+}
+
+// readBody extracts paragraphs from the article body container, or from the
+// whole page when no container is recognized, and reports paywall markers.
+func readBody(tokens []token) ([]string, bool) {
+	root := findContainer(tokens)
+	paywall := false
+
+	var paragraphs []string
+	seen := make(map[string]bool)
+	var buf strings.Builder
+	inBlock := false
+	flush := func() {
+		p := collapseSpace(buf.String())
+		buf.Reset()
+		inBlock = false
+		if p == "" || seen[p] {
+			return
+		}
+		if len(p) <= maxChromeLength {
+			lower := strings.ToLower(p)
+			if containsAny(lower, paywallPhrases) {
+				paywall = true
+				return
+			}
+			if containsAny(lower, boilerplate) {
+				return
+			}
+		}
+		seen[p] = true
+		paragraphs = append(paragraphs, p)
+	}
+
+	start, rootName := 0, ""
+	if root >= 0 {
+		start, rootName = root+1, tokens[root].name
+	}
+	depth := 1
+	skipName, skipDepth := "", 0
+
+	for _, t := range tokens[start:] {
+		if t.kind == startTag && (t.hasClass("paywall") || strings.Contains(t.attr("data-testid"), "paywall")) {
+			paywall = true
+		}
+
+		// Track nesting of the container's own tag to find where it ends.
+		if rootName != "" && t.name == rootName && !t.selfClosing {
+			if t.kind == startTag {
+				depth++
+			} else if t.kind == endTag {
+				if depth--; depth == 0 {
+					break
+				}
+			}
+		}
+
+		if skipName != "" {
+			if t.name == skipName && !t.selfClosing {
+				if t.kind == startTag {
+					skipDepth++
+				} else if t.kind == endTag {
+					if skipDepth--; skipDepth == 0 {
+						skipName = ""
+					}
+				}
+			}
+			continue
+		}
+
+		switch t.kind {
+		case startTag:
+			if !t.selfClosing && (skippedElements[t.name] || hasAnyClass(t, skippedClasses)) {
+				if inBlock {
+					flush()
+				}
+				skipName, skipDepth = t.name, 1
+				continue
+			}
+			if blockElements[t.name] {
+				if inBlock {
+					flush()
+				}
+				inBlock = true
+			} else if t.name == "br" && inBlock {
+				buf.WriteByte(' ')
+			}
+		case endTag:
+			if blockElements[t.name] && inBlock {
+				flush()
+			}
+		case textToken:
+			if inBlock {
+				buf.WriteString(t.text)
+			}
+		}
+	}
+	if inBlock {
+		flush()
+	}
+	return paragraphs, paywall
+}
+
+// findContainer returns the index of the article body's start tag, or -1.
+func findContainer(tokens []token) int {
+	for _, prefix := range contentClasses {
+		for i, t := range tokens {
+			if t.kind == startTag && t.hasClass(prefix) {
+				return i
+			}
+		}
+	}
+	for _, id := range contentTestIDs {
+		for i, t := range tokens {
+			if t.kind == startTag && strings.Contains(t.attr("data-testid"), id) {
+				return i
+			}
+		}
+	}
+	for i, t := range tokens {
+		if t.kind == startTag && (t.name == "article" || t.attr("itemprop") == "articleBody") {
+			return i
+		}
+	}
+	return -1
+}
+
+func hasAnyClass(t token, prefixes []string) bool {
+	for _, p := range prefixes {
+		if t.hasClass(p) {
+			return true
+		}
+	}
+	return false
+}
+
+func isLockedTier(tier string) bool {
+	tier = strings.ToLower(tier)
+	return tier == "locked" || tier == "metered"
+}
+
+func isFalse(v any) bool {
+	switch v := v.(type) {
+	case bool:
+		return !v
+	case string:
+		return strings.EqualFold(v, "false")
+	}
+	return false
+}
+
+var timeLayouts = []string{
+	time.RFC3339,
+	"2006-01-02T15:04:05.000Z07:00",
+	"2006-01-02T15:04:05Z0700",
+	"2006-01-02T15:04:05",
+	"2006-01-02 15:04:05",
+	"2006-01-02",
+}
+
+func parseTime(s string) (time.Time, bool) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return time.Time{}, false
+	}
+	for _, layout := range timeLayouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t.UTC(), true
+		}
+	}
+	return time.Time{}, false
+}
+
+// normalizeParagraphs splits plain text on line breaks into paragraphs.
+func normalizeParagraphs(s string) string {
+	var out []string
+	for _, line := range strings.Split(s, "\n") {
+		if line = collapseSpace(line); line != "" {
+			out = append(out, line)
+		}
+	}
+	return strings.Join(out, "\n\n")
+}
+
+func collapseSpace(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
+
+func str(v any) string {
+	s, _ := v.(string)
+	return strings.TrimSpace(s)
+}
+
+// nonURL drops values that are profile links rather than names.
+func nonURL(s string) string {
+	if strings.HasPrefix(s, "http://") || strings.HasPrefix(s, "https://") {
+		return ""
+	}
+	return strings.TrimSpace(s)
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
+
+func containsAny(s string, phrases []string) bool {
+	for _, p := range phrases {
+		if strings.Contains(s, p) {
+			return true
+		}
+	}
+	return false
+}
+
+func containsString(values []string, v string) bool {
+	for _, s := range values {
+		if s == v {
+			return true
+		}
+	}
+	return false
+}
+
+// Getter fetches a web page, returning its body and final URL after
+// redirects. *yahoo.Client implements it.
+type Getter interface {
+	FetchPage(pageURL string) ([]byte, string, error)
+}
+
+// Fetcher retrieves and extracts articles, caching results by URL.
+type Fetcher struct {
+	src Getter
+	now func() time.Time
+
+	mu    sync.Mutex
+	cache map[string]cacheEntry
+	order []string // cached URLs, oldest first
+}
+
+type cacheEntry struct {
+	article *Article
+	fetched time.Time
+}
+
+// NewFetcher creates a Fetcher backed by src.
+func NewFetcher(src Getter) *Fetcher {
+	return &Fetcher{src: src, now: time.Now, cache: make(map[string]cacheEntry)}
+}
+
+// Fetch returns the article at pageURL, from cache when fetched within
+// CacheTTL.
+func (f *Fetcher) Fetch(pageURL string) (*Article, error) {
+	f.mu.Lock()
+	if e, ok := f.cache[pageURL]; ok && f.now().Sub(e.fetched) < CacheTTL {
+		f.mu.Unlock()
+		return e.article, nil
+	}
+	f.mu.Unlock()
+
+	body, final, err := f.src.FetchPage(pageURL)
+	if err != nil {
+		return nil, err
+	}
+	// Yahoo redirects EU visitors to a cookie consent page instead of the
+	// article; there is nothing to extract from it.
+	if u, err := url.Parse(final); err == nil && (strings.HasPrefix(u.Host, "consent.") || strings.HasPrefix(u.Host, "guce.")) {
+		return nil, fmt.Errorf("redirected to a consent page (%s)", u.Host)
+	}
+
+	a, err := Extract(body, final)
+	if err != nil {
+		return nil, err
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if _, ok := f.cache[pageURL]; !ok {
+		f.order = append(f.order, pageURL)
+		if len(f.order) > cacheSize {
+			delete(f.cache, f.order[0])
+			f.order = f.order[1:]
+		}
+	}
+	f.cache[pageURL] = cacheEntry{article: a, fetched: f.now()}
+	return a, nil
+}
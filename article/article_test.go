@@ -0,0 +1,191 @@
+package article
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func readFixture(t *testing.T, name string) []byte {
+	t.Helper()
+	b, err := os.ReadFile(filepath.Join("testdata", name))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return b
+}
+
+func TestExtract_Yahoo(t *testing.T) {
+	a, err := Extract(readFixture(t, "yahoo.html"), "https://finance.yahoo.com/news/apple-beats.html")
+	if err != nil {
+		t.Fatalf("Extract() error: %v", err)
+	}
+
+	if a.Title != "Apple beats estimates as iPhone sales climb" {
+		t.Errorf("Title = %q", a.Title)
+	}
+	if a.Publisher != "Reuters" {
+		t.Errorf("Publisher = %q, want Reuters from JSON-LD", a.Publisher)
+	}
+	if a.Author != "Jane Doe, John Roe" {
+		t.Errorf("Author = %q", a.Author)
+	}
+	if want := time.Date(2026, 10, 15, 20, 31, 0, 0, time.UTC); !a.Published.Equal(want) {
+		t.Errorf("Published = %v, want %v", a.Published, want)
+	}
+	if a.Paywalled {
+		t.Error("Paywalled = true, want false")
+	}
+
+	want := strings.Join([]string{
+		"(Reuters) - Apple reported quarterly revenue of $94.9 billion on Thursday, ahead of Wall Street estimates, as demand for the iPhone 17 lineup held up in China.",
+		"Shares rose 3% in extended trading. “We’re seeing strong upgrades,” the chief financial officer said on a call with analysts.",
+		"Services growth",
+		"Services revenue, which includes the App Store and iCloud, grew 14% to a record $27.4 billion.",
+		"Mac revenue: $8.7 billion",
+		"iPad revenue: $7.0 billion",
+	}, "\n\n")
+	if a.Text != want {
+		t.Errorf("Text =\n%s\n\nwant\n%s", a.Text, want)
+	}
+}
+
+func TestExtract_Paywall(t *testing.T) {
+	a, err := Extract(readFixture(t, "paywall.html"), "https://www.barrons.com/articles/fed")
+	if err != nil {
+		t.Fatalf("Extract() error: %v", err)
+	}
+	if !a.Paywalled {
+		t.Error("Paywalled = false, want true")
+	}
+	if a.Title != "The Fed's next move, explained" || a.Publisher != "Barron's" || a.Author != "Alex Smith" {
+		t.Errorf("metadata = %q / %q / %q", a.Title, a.Publisher, a.Author)
+	}
+	if want := time.Date(2026, 10, 14, 15, 0, 0, 0, time.UTC); !a.Published.Equal(want) {
+		t.Errorf("Published = %v, want %v", a.Published, want)
+	}
+	if a.Text != "Investors are betting on two more cuts this year. The minutes tell a different story." {
+		t.Errorf("Text = %q, want only the free preview", a.Text)
+	}
+}
+
+func TestExtract_GenericArticle(t *testing.T) {
+	a, err := Extract(readFixture(t, "generic.html"), "https://example.com/oil")
+	if err != nil {
+		t.Fatalf("Extract() error: %v", err)
+	}
+	if a.Title != "Oil slips on demand worries | Example News" {
+		t.Errorf("Title = %q, want <title> fallback", a.Title)
+	}
+	if want := time.Date(2026, 10, 16, 0, 0, 0, 0, time.UTC); !a.Published.Equal(want) {
+		t.Errorf("Published = %v, want %v from <time>", a.Published, want)
+	}
+	want := "Oil slips on demand worries\n\n" +
+		"By October 16, 2026\n\n" +
+		"Brent crude fell 1.2% to $71.40 a barrel as weaker factory data from Asia weighed on the demand outlook.\n\n" +
+		"Traders also watched U.S. inventories, which rose for a third week."
+	if a.Text != want {
+		t.Errorf("Text =\n%s\n\nwant\n%s", a.Text, want)
+	}
+}
+
+func TestExtract_NoText(t *testing.T) {
+	if _, err := Extract([]byte(`<html><body><nav><p>Home</p></nav></body></html>`), ""); err == nil {
+		t.Fatal("expected error for a page without article text")
+	}
+}
+
+func TestExtract_ArticleBodyFallback(t *testing.T) {
+	doc := `<script type="application/ld+json">{"@type":["NewsArticle"],"headline":"Short",` +
+		`"articleBody":"First paragraph of the story.\nSecond paragraph with more detail.","isAccessibleForFree":"False"}</script>` +
+		`<article><p>Teaser only.</p></article>`
+	a, err := Extract([]byte(doc), "")
+	if err != nil {
+		t.Fatalf("Extract() error: %v", err)
+	}
+	if a.Text != "First paragraph of the story.\n\nSecond paragraph with more detail." {
+		t.Errorf("Text = %q, want articleBody", a.Text)
+	}
+	if !a.Paywalled {
+		t.Error("Paywalled = false, want true from isAccessibleForFree")
+	}
+}
+
+func TestLimit(t *testing.T) {
+	a := Article{Text: "One two three.\n\nFour five six seven."}
+
+	if got := a.Limit(100); got.Text != a.Text || got.Truncated {
+		t.Errorf("Limit(100) = %+v, want unchanged", got)
+	}
+	if got := a.Limit(25); got.Text != "One two three." || !got.Truncated {
+		t.Errorf("Limit(25) = %q, %v; want first paragraph", got.Text, got.Truncated)
+	}
+	if got := a.Limit(10); got.Text != "One two" || !got.Truncated {
+		t.Errorf("Limit(10) = %q, %v; want word boundary", got.Text, got.Truncated)
+	}
+	if a.Truncated {
+		t.Error("Limit modified the receiver")
+	}
+}
+
+type fakeGetter struct {
+	pages map[string]string
+	final string
+	calls int
+}
+
+func (g *fakeGetter) FetchPage(pageURL string) ([]byte, string, error) {
+	g.calls++
+	body, ok := g.pages[pageURL]
+	if !ok {
+		return nil, "", errors.New("not found")
+	}
+	if g.final != "" {
+		return []byte(body), g.final, nil
+	}
+	return []byte(body), pageURL, nil
+}
+
+func TestFetcher_Caches(t *testing.T) {
+	const u = "https://finance.yahoo.com/news/a.html"
+	g := &fakeGetter{pages: map[string]string{u: `<article><p>Body text.</p></article>`}}
+	f := NewFetcher(g)
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	f.now = func() time.Time { return now }
+
+	for range 2 {
+		a, err := f.Fetch(u)
+		if err != nil {
+			t.Fatalf("Fetch() error: %v", err)
+		}
+		if a.Text != "Body text." || a.URL != u {
+			t.Errorf("article = %+v", a)
+		}
+	}
+	if g.calls != 1 {
+		t.Errorf("calls = %d, want 1 (cached)", g.calls)
+	}
+
+	now = now.Add(CacheTTL)
+	if _, err := f.Fetch(u); err != nil {
+		t.Fatalf("Fetch() error: %v", err)
+	}
+	if g.calls != 2 {
+		t.Errorf("calls = %d, want 2 after expiry", g.calls)
+	}
+}
+
+func TestFetcher_ConsentRedirect(t *testing.T) {
+	const u = "https://finance.yahoo.com/news/a.html"
+	g := &fakeGetter{
+		pages: map[string]string{u: `<form><p>We value your privacy</p></form>`},
+		final: "https://consent.yahoo.com/v2/collectConsent?sessionId=1",
+	}
+	_, err := NewFetcher(g).Fetch(u)
+	if err == nil || !strings.Contains(err.Error(), "consent") {
+		t.Errorf("expected consent error, got %v", err)
+	}
+}
@@ -0,0 +1,212 @@
+package article
+
+import (
+	"html"
+	"strings"
+)
+
+// tokenKind identifies an HTML token.
+type tokenKind int
+
+const (
+	textToken tokenKind = iota
+	startTag
+	endTag
+)
+
+// token is a lexed piece of HTML. Tag names and attribute keys are lower
+// case; text and attribute values have entities decoded.
+type token struct {
+	kind  tokenKind
+	name  string
+	attrs map[string]string
+	text  string
+	// selfClosing is set for void elements and tags written as <x/>.
+	selfClosing bool
+}
+
+func (t token) attr(key string) string {
+	return t.attrs[key]
+}
+
+// hasClass reports whether the token's class attribute contains a class
+// starting with prefix, which matches hashed class names such as "body-yf3".
+func (t token) hasClass(prefix string) bool {
+	for _, c := range strings.Fields(t.attrs["class"]) {
+		if strings.HasPrefix(c, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+var voidElements = map[string]bool{
+	"area": true, "base": true, "br": true, "col": true, "embed": true, "hr": true,
+	"img": true, "input": true, "link": true, "meta": true, "source": true, "track": true, "wbr": true,
+}
+
+// rawTextElements hold content that is not markup and must not be lexed.
+var rawTextElements = map[string]bool{"script": true, "style": true, "textarea": true, "title": true}
+
+// tokenize lexes an HTML document. It is lenient rather than conforming:
+// comments, doctypes and processing instructions are dropped, malformed
+// tags are treated as text, and no tree is built.
+func tokenize(doc string) []token {
+	var tokens []token
+	i := 0
+	for i < len(doc) {
+		lt := strings.IndexByte(doc[i:], '<')
+		if lt < 0 {
+			tokens = appendText(tokens, doc[i:])
+			break
+		}
+		if lt > 0 {
+			tokens = appendText(tokens, doc[i:i+lt])
+		}
+		i += lt
+
+		switch {
+		case strings.HasPrefix(doc[i:], "<!--"):
+			end := strings.Index(doc[i+4:], "-->")
+			if end < 0 {
+				return tokens
+			}
+			i += 4 + end + 3
+			continue
+		case strings.HasPrefix(doc[i:], "<!") || strings.HasPrefix(doc[i:], "<?"):
+			end := strings.IndexByte(doc[i:], '>')
+			if end < 0 {
+				return tokens
+			}
+			i += end + 1
+			continue
+		}
+
+		tok, n, ok := lexTag(doc[i:])
+		if !ok {
+			tokens = appendText(tokens, "<")
+			i++
+			continue
+		}
+		i += n
+		tokens = append(tokens, tok)
+
+		if tok.kind == startTag && !tok.selfClosing && rawTextElements[tok.name] {
+			closing := "</" + tok.name
+			end := indexFold(doc[i:], closing)
+			if end < 0 {
+				end = len(doc) - i
+			}
+			if raw := doc[i : i+end]; raw != "" {
+				// Raw text is kept verbatim (JSON-LD needs it); only
+				// <title> and <textarea> content is decoded.
+				if tok.name == "title" || tok.name == "textarea" {
+					raw = html.UnescapeString(raw)
+				}
+				tokens = append(tokens, token{kind: textToken, text: raw})
+			}
+			i += end
+		}
+	}
+	return tokens
+}
+
+func appendText(tokens []token, s string) []token {
+	return append(tokens, token{kind: textToken, text: html.UnescapeString(s)})
+}
+
+// lexTag lexes a start or end tag at the beginning of s, returning the token
+// and the number of bytes consumed.
+func lexTag(s string) (token, int, bool) {
+	i := 1
+	tok := token{kind: startTag}
+	if i < len(s) && s[i] == '/' {
+		tok.kind = endTag
+		i++
+	}
+	start := i
+	for i < len(s) && isNameByte(s[i]) {
+		i++
+	}
+	if i == start {
+		return token{}, 0, false
+	}
+	tok.name = strings.ToLower(s[start:i])
+
+	for i < len(s) {
+		for i < len(s) && isSpace(s[i]) {
+			i++
+		}
+		if i >= len(s) {
+			return token{}, 0, false
+		}
+		switch s[i] {
+		case '>':
+			tok.selfClosing = tok.selfClosing || voidElements[tok.name]
+			return tok, i + 1, true
+		case '/':
+			tok.selfClosing = true
+			i++
+			continue
+		}
+
+		keyStart := i
+		for i < len(s) && !isSpace(s[i]) && s[i] != '=' && s[i] != '>' && s[i] != '/' {
+			i++
+		}
+		key := strings.ToLower(s[keyStart:i])
+		for i < len(s) && isSpace(s[i]) {
+			i++
+		}
+		value := ""
+		if i < len(s) && s[i] == '=' {
+			i++
+			for i < len(s) && isSpace(s[i]) {
+				i++
+			}
+			if i < len(s) && (s[i] == '"' || s[i] == '\'') {
+				q := s[i]
+				end := strings.IndexByte(s[i+1:], q)
+				if end < 0 {
+					return token{}, 0, false
+				}
+				value = s[i+1 : i+1+end]
+				i += end + 2
+			} else {
+				vs := i
+				for i < len(s) && !isSpace(s[i]) && s[i] != '>' {
+					i++
+				}
+				value = s[vs:i]
+			}
+		}
+		if key != "" && tok.kind == startTag {
+			if tok.attrs == nil {
+				tok.attrs = make(map[string]string)
+			}
+			if _, dup := tok.attrs[key]; !dup {
+				tok.attrs[key] = html.UnescapeString(value)
+			}
+		}
+	}
+	return token{}, 0, false
+}
+
+func isNameByte(b byte) bool {
+	return b >= 'a' && b <= 'z' || b >= 'A' && b <= 'Z' || b >= '0' && b <= '9' || b == '-' || b == ':'
+}
+
+func isSpace(b byte) bool {
+	return b == ' ' || b == '\t' || b == '\n' || b == '\r' || b == '\f'
+}
+
+// indexFold is strings.Index with ASCII case folding of the needle.
+func indexFold(s, substr string) int {
+	n := len(substr)
+	for i := 0; i+n <= len(s); i++ {
+		if strings.EqualFold(s[i:i+n], substr) {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,42 @@
+package article
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestTokenize(t *testing.T) {
+	doc := `<!DOCTYPE html><!-- note --><DIV Class="a b" data-x=1 hidden>Fish &amp; chips<br/>` +
+		`<script type="application/ld+json">{"a":"<p>&amp;</p>"}</script></div>3 < 4 <p`
+
+	got := tokenize(doc)
+	want := []token{
+		{kind: startTag, name: "div", attrs: map[string]string{"class": "a b", "data-x": "1", "hidden": ""}},
+		{kind: textToken, text: "Fish & chips"},
+		{kind: startTag, name: "br", selfClosing: true},
+		{kind: startTag, name: "script", attrs: map[string]string{"type": "application/ld+json"}},
+		{kind: textToken, text: `{"a":"<p>&amp;</p>"}`},
+		{kind: endTag, name: "script"},
+		{kind: endTag, name: "div"},
+		{kind: textToken, text: "3 "},
+		{kind: textToken, text: "<"},
+		{kind: textToken, text: " 4 "},
+		{kind: textToken, text: "<"},
+		{kind: textToken, text: "p"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("tokenize() =\n%+v\nwant\n%+v", got, want)
+	}
+}
+
+func TestTokenHasClass(t *testing.T) {
+	tok := token{kind: startTag, name: "div", attrs: map[string]string{"class": "body yf-3x4 caas-body"}}
+	for _, prefix := range []string{"body", "yf-", "caas-body"} {
+		if !tok.hasClass(prefix) {
+			t.Errorf("hasClass(%q) = false, want true", prefix)
+		}
+	}
+	if tok.hasClass("paywall") {
+		t.Error("hasClass(paywall) = true, want false")
+	}
+}
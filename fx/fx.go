@@ -0,0 +1,216 @@
+// Package fx converts amounts between currencies using Yahoo's currency pair
+// symbols (EURUSD=X).
+//
+// Yahoo quotes some listings in a minor unit: London in pence (GBp), Tel Aviv
+// in agorot (ILA) and Johannesburg in cents (ZAc). Those are converted to
+// their major currency before any exchange rate is applied.
+package fx
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/emmanuelay/yahoo-finance-mcp/yahoo"
+)
+
+// LatestTTL is how long a latest rate is reused before it is fetched again.
+const LatestTTL = 5 * time.Minute
+
+// Source is the subset of the Yahoo client the converter needs.
+type Source interface {
+	GetBulkQuotes(symbols []string) ([]yahoo.BulkQuoteResult, error)
+	GetChartRange(symbol string, start, end time.Time, interval string) (*yahoo.ChartResult, error)
+}
+
+// minorUnits maps minor currency units to their major currency.
+var minorUnits = map[string]string{
+	"GBp": "GBP", "GBX": "GBP",
+	"ILA": "ILS",
+	"ZAc": "ZAR", "ZAC": "ZAR",
+}
+
+// Normalize returns the major currency for code and the factor that converts
+// an amount in code into it: Normalize("GBp") is ("GBP", 0.01). Codes are
+// matched case-sensitively because Yahoo distinguishes GBP from GBp.
+func Normalize(code string) (string, float64) {
+	code = strings.TrimSpace(code)
+	if major, ok := minorUnits[code]; ok {
+		return major, 0.01
+	}
+	return strings.ToUpper(code), 1
+}
+
+// Canonical returns code as Yahoo spells it: minor units keep their case
+// (GBp, ZAc) and other codes are upper-cased, so user input such as "eur"
+// compares equal to Yahoo's "EUR".
+func Canonical(code string) string {
+	code = strings.TrimSpace(code)
+	if _, ok := minorUnits[code]; ok {
+		return code
+	}
+	return strings.ToUpper(code)
+}
+
+// PairSymbol returns the Yahoo symbol quoting to per unit of from.
+func PairSymbol(from, to string) string {
+	return from + to + "=X"
+}
+
+// Rate is the number of units of To per unit of From.
+type Rate struct {
+	From  string
+	To    string
+	Value float64
+	// Date is when the rate was quoted.
+	Date time.Time
+	// Pairs lists the Yahoo symbols the rate was derived from; empty when
+	// From and To share a major currency.
+	Pairs []string
+}
+
+// Convert returns amount in r.From expressed in r.To.
+func (r Rate) Convert(amount float64) float64 {
+	return amount * r.Value
+}
+
+// ConvertMajor returns amount, given in the major unit of r.From, expressed
+// in the major unit of r.To. Yahoo reports market capitalization in GBP even
+// for listings quoted in GBp, so such totals convert with this rather than
+// Convert.
+func (r Rate) ConvertMajor(amount float64) float64 {
+	_, from := Normalize(r.From)
+	_, to := Normalize(r.To)
+	return amount * r.Value / from * to
+}
+
+// Converter fetches and caches exchange rates.
+type Converter struct {
+	src Source
+	now func() time.Time
+
+	mu     sync.Mutex
+	latest map[string]Rate // major pair → rate
+}
+
+// NewConverter creates a Converter backed by src.
+func NewConverter(src Source) *Converter {
+	return &Converter{src: src, now: time.Now, latest: make(map[string]Rate)}
+}
+
+// Latest returns the current rate from one currency to another.
+func (c *Converter) Latest(from, to string) (Rate, error) {
+	return c.rate(from, to, func(f, t string) (Rate, error) { return c.latestMajor(f, t) })
+}
+
+// On returns the closing rate on date, or on the last trading day before it.
+func (c *Converter) On(from, to string, date time.Time) (Rate, error) {
+	return c.rate(from, to, func(f, t string) (Rate, error) { return c.historicalMajor(f, t, date) })
+}
+
+// rate applies minor unit factors around a major currency rate.
+func (c *Converter) rate(from, to string, major func(f, t string) (Rate, error)) (Rate, error) {
+	f, ff := Normalize(from)
+	t, tf := Normalize(to)
+	if f == "" || t == "" {
+		return Rate{}, fmt.Errorf("currency is required")
+	}
+
+	r := Rate{Value: 1, Date: c.now()}
+	if f != t {
+		var err error
+		if r, err = major(f, t); err != nil {
+			return Rate{}, err
+		}
+	}
+	r.From, r.To = Canonical(from), Canonical(to)
+	r.Value *= ff / tf
+	return r, nil
+}
+
+// latestMajor quotes the direct pair and, for crosses Yahoo does not list,
+// triangulates through USD.
+func (c *Converter) latestMajor(from, to string) (Rate, error) {
+	key := from + to
+	c.mu.Lock()
+	if r, ok := c.latest[key]; ok && c.now().Sub(r.Date) < LatestTTL {
+		c.mu.Unlock()
+		return r, nil
+	}
+	c.mu.Unlock()
+
+	direct := PairSymbol(from, to)
+	symbols := []string{direct}
+	if from != "USD" && to != "USD" {
+		symbols = append(symbols, PairSymbol(from, "USD"), PairSymbol("USD", to))
+	}
+	quotes, err := c.src.GetBulkQuotes(symbols)
+	if err != nil {
+		return Rate{}, fmt.Errorf("get %s rate: %w", key, err)
+	}
+	prices := make(map[string]float64, len(quotes))
+	for _, q := range quotes {
+		if q.RegularMarketPrice > 0 {
+			prices[q.Symbol] = q.RegularMarketPrice
+		}
+	}
+
+	r := Rate{Date: c.now()}
+	switch {
+	case prices[direct] > 0:
+		r.Value, r.Pairs = prices[direct], []string{direct}
+	case len(symbols) == 3 && prices[symbols[1]] > 0 && prices[symbols[2]] > 0:
+		r.Value, r.Pairs = prices[symbols[1]]*prices[symbols[2]], symbols[1:]
+	default:
+		return Rate{}, fmt.Errorf("no exchange rate found for %s/%s", from, to)
+	}
+
+	c.mu.Lock()
+	c.latest[key] = r
+	c.mu.Unlock()
+	return r, nil
+}
+
+// historicalMajor is latestMajor for a past date.
+func (c *Converter) historicalMajor(from, to string, date time.Time) (Rate, error) {
+	r, err := c.closeOn(PairSymbol(from, to), date)
+	if err == nil || from == "USD" || to == "USD" {
+		return r, err
+	}
+
+	a, errA := c.closeOn(PairSymbol(from, "USD"), date)
+	b, errB := c.closeOn(PairSymbol("USD", to), date)
+	if errA != nil || errB != nil {
+		return Rate{}, fmt.Errorf("no exchange rate found for %s/%s on %s: %w", from, to, date.Format("2006-01-02"), errors.Join(errA, errB))
+	}
+	d := a.Date
+	if b.Date.Before(d) {
+		d = b.Date
+	}
+	return Rate{Value: a.Value * b.Value, Date: d, Pairs: append(a.Pairs, b.Pairs...)}, nil
+}
+
+// closeOn returns a pair's last close on or before date, looking back up to
+// a week to cover weekends and holidays.
+func (c *Converter) closeOn(symbol string, date time.Time) (Rate, error) {
+	day := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, time.UTC)
+	end := day.AddDate(0, 0, 1)
+	chart, err := c.src.GetChartRange(symbol, day.AddDate(0, 0, -7), end, "1d")
+	if err != nil {
+		return Rate{}, err
+	}
+	if len(chart.Indicators.Quote) == 0 {
+		return Rate{}, fmt.Errorf("no prices for %s", symbol)
+	}
+	closes := chart.Indicators.Quote[0].Close
+	for i := len(chart.Timestamps) - 1; i >= 0; i-- {
+		ts := time.Unix(chart.Timestamps[i], 0).UTC()
+		if i >= len(closes) || closes[i] == nil || *closes[i] <= 0 || !ts.Before(end) {
+			continue
+		}
+		return Rate{Value: *closes[i], Date: ts, Pairs: []string{symbol}}, nil
+	}
+	return Rate{}, fmt.Errorf("no prices for %s in the week to %s", symbol, day.Format("2006-01-02"))
+}
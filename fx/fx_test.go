@@ -0,0 +1,238 @@
+package fx
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/emmanuelay/yahoo-finance-mcp/yahoo"
+)
+
+type fakeSource struct {
+	prices map[string]float64
+	// closes maps a pair symbol to closes keyed by date (2006-01-02).
+	closes map[string]map[string]float64
+	calls  int
+}
+
+func (f *fakeSource) GetBulkQuotes(symbols []string) ([]yahoo.BulkQuoteResult, error) {
+	f.calls++
+	var out []yahoo.BulkQuoteResult
+	for _, s := range symbols {
+		if p, ok := f.prices[s]; ok {
+			out = append(out, yahoo.BulkQuoteResult{Symbol: s, RegularMarketPrice: p})
+		}
+	}
+	return out, nil
+}
+
+func (f *fakeSource) GetChartRange(symbol string, start, end time.Time, interval string) (*yahoo.ChartResult, error) {
+	f.calls++
+	byDate, ok := f.closes[symbol]
+	if !ok {
+		return nil, fmt.Errorf("no chart data found for symbol %q", symbol)
+	}
+	r := &yahoo.ChartResult{Indicators: yahoo.ChartIndicators{Quote: []yahoo.ChartQuote{{}}}}
+	for d := start; d.Before(end); d = d.AddDate(0, 0, 1) {
+		if v, ok := byDate[d.Format("2006-01-02")]; ok {
+			v := v
+			r.Timestamps = append(r.Timestamps, d.Unix())
+			r.Indicators.Quote[0].Close = append(r.Indicators.Quote[0].Close, &v)
+		}
+	}
+	return r, nil
+}
+
+func near(a, b float64) bool {
+	return math.Abs(a-b) < 1e-9
+}
+
+func TestNormalize(t *testing.T) {
+	tests := []struct {
+		in     string
+		major  string
+		factor float64
+	}{
+		{"GBp", "GBP", 0.01},
+		{"GBX", "GBP", 0.01},
+		{"GBP", "GBP", 1},
+		{"ILA", "ILS", 0.01},
+		{"ZAc", "ZAR", 0.01},
+		{"eur", "EUR", 1},
+	}
+	for _, tt := range tests {
+		major, factor := Normalize(tt.in)
+		if major != tt.major || factor != tt.factor {
+			t.Errorf("Normalize(%q) = %s, %v; want %s, %v", tt.in, major, factor, tt.major, tt.factor)
+		}
+	}
+}
+
+func TestCanonical(t *testing.T) {
+	tests := map[string]string{
+		"GBp":   "GBp",
+		"GBP":   "GBP",
+		"gbp":   "GBP",
+		" eur ": "EUR",
+		"ZAc":   "ZAc",
+		"ila":   "ILA",
+	}
+	for in, want := range tests {
+		if got := Canonical(in); got != want {
+			t.Errorf("Canonical(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestLatest_DirectPair(t *testing.T) {
+	src := &fakeSource{prices: map[string]float64{"EURUSD=X": 1.08}}
+	c := NewConverter(src)
+
+	r, err := c.Latest("EUR", "USD")
+	if err != nil {
+		t.Fatalf("Latest() error: %v", err)
+	}
+	if !near(r.Value, 1.08) || r.From != "EUR" || r.To != "USD" {
+		t.Errorf("rate = %+v, want EUR→USD 1.08", r)
+	}
+	if !reflect.DeepEqual(r.Pairs, []string{"EURUSD=X"}) {
+		t.Errorf("Pairs = %v, want [EURUSD=X]", r.Pairs)
+	}
+	if got := r.Convert(100); !near(got, 108) {
+		t.Errorf("Convert(100) = %v, want 108", got)
+	}
+}
+
+func TestLatest_MinorUnits(t *testing.T) {
+	src := &fakeSource{prices: map[string]float64{"GBPUSD=X": 1.25, "USDGBP=X": 0.8}}
+	c := NewConverter(src)
+
+	r, err := c.Latest("GBp", "USD")
+	if err != nil {
+		t.Fatalf("Latest() error: %v", err)
+	}
+	if !near(r.Value, 0.0125) || r.From != "GBp" {
+		t.Errorf("rate = %+v, want GBp→USD 0.0125", r)
+	}
+
+	if got := r.ConvertMajor(1000); !near(got, 1250) {
+		t.Errorf("ConvertMajor(1000) = %v, want 1250", got)
+	}
+
+	r, err = c.Latest("usd", "GBp")
+	if err != nil {
+		t.Fatalf("Latest() error: %v", err)
+	}
+	if r.From != "USD" || r.To != "GBp" {
+		t.Errorf("rate = %+v, want USD→GBp", r)
+	}
+	// A total in USD lands in GBP, not pence.
+	if got := r.ConvertMajor(1250); !near(got, 1000) {
+		t.Errorf("ConvertMajor(1250) = %v, want 1000", got)
+	}
+
+	r, err = c.Latest("GBp", "GBP")
+	if err != nil {
+		t.Fatalf("Latest() error: %v", err)
+	}
+	if !near(r.Value, 0.01) || len(r.Pairs) != 0 {
+		t.Errorf("rate = %+v, want GBp→GBP 0.01 without pairs", r)
+	}
+}
+
+func TestLatest_TriangulatesThroughUSD(t *testing.T) {
+	src := &fakeSource{prices: map[string]float64{"SEKUSD=X": 0.095, "USDILS=X": 3.7}}
+	c := NewConverter(src)
+
+	r, err := c.Latest("SEK", "ILA")
+	if err != nil {
+		t.Fatalf("Latest() error: %v", err)
+	}
+	if want := 0.095 * 3.7 * 100; !near(r.Value, want) {
+		t.Errorf("Value = %v, want %v", r.Value, want)
+	}
+	if !reflect.DeepEqual(r.Pairs, []string{"SEKUSD=X", "USDILS=X"}) {
+		t.Errorf("Pairs = %v", r.Pairs)
+	}
+}
+
+func TestLatest_Cached(t *testing.T) {
+	src := &fakeSource{prices: map[string]float64{"EURUSD=X": 1.08}}
+	c := NewConverter(src)
+	now := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	c.now = func() time.Time { return now }
+
+	for i := 0; i < 3; i++ {
+		if _, err := c.Latest("EUR", "USD"); err != nil {
+			t.Fatalf("Latest() error: %v", err)
+		}
+	}
+	if src.calls != 1 {
+		t.Errorf("calls = %d, want 1", src.calls)
+	}
+
+	now = now.Add(LatestTTL)
+	if _, err := c.Latest("EUR", "USD"); err != nil {
+		t.Fatalf("Latest() error: %v", err)
+	}
+	if src.calls != 2 {
+		t.Errorf("calls after TTL = %d, want 2", src.calls)
+	}
+}
+
+func TestLatest_NotFound(t *testing.T) {
+	c := NewConverter(&fakeSource{})
+	if _, err := c.Latest("EUR", "XYZ"); err == nil {
+		t.Fatal("expected error for unknown pair")
+	}
+}
+
+func TestOn_UsesLastCloseBeforeDate(t *testing.T) {
+	src := &fakeSource{closes: map[string]map[string]float64{
+		"EURUSD=X": {"2024-03-01": 1.0805, "2024-03-04": 1.0850},
+	}}
+	c := NewConverter(src)
+
+	// 2024-03-03 is a Sunday; Friday's close applies.
+	r, err := c.On("EUR", "USD", time.Date(2024, 3, 3, 0, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatalf("On() error: %v", err)
+	}
+	if !near(r.Value, 1.0805) || r.Date.Format("2006-01-02") != "2024-03-01" {
+		t.Errorf("rate = %+v, want 1.0805 on 2024-03-01", r)
+	}
+}
+
+func TestOn_TriangulatesThroughUSD(t *testing.T) {
+	src := &fakeSource{closes: map[string]map[string]float64{
+		"NOKUSD=X": {"2024-03-01": 0.095},
+		"USDJPY=X": {"2024-03-01": 150},
+	}}
+	c := NewConverter(src)
+
+	r, err := c.On("NOK", "JPY", time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatalf("On() error: %v", err)
+	}
+	if !near(r.Value, 0.095*150) {
+		t.Errorf("Value = %v, want %v", r.Value, 0.095*150)
+	}
+}
+
+func TestOn_TriangulationErrorNamesFailedLeg(t *testing.T) {
+	src := &fakeSource{closes: map[string]map[string]float64{
+		"USDJPY=X": {"2024-03-01": 150},
+	}}
+	c := NewConverter(src)
+
+	_, err := c.On("NOK", "JPY", time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC))
+	if err == nil {
+		t.Fatal("expected error when a leg is missing")
+	}
+	if !strings.Contains(err.Error(), "NOKUSD=X") {
+		t.Errorf("error should name the failed leg, got: %v", err)
+	}
+}
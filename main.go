@@ -24,24 +24,51 @@ func main() {
 		"yahoo-finance",
 		fmt.Sprintf("%s (%s) %s", version, commit, date),
 		server.WithToolCapabilities(true),
+		server.WithResourceCapabilities(true, true),
+		server.WithPromptCapabilities(false),
 	)
+	handlers.RegisterResources(s)
 
 	s.AddTool(tools.GetQuoteTool(), handlers.HandleGetQuote)
+	s.AddTool(tools.StreamQuotesTool(), handlers.HandleStreamQuotes)
+	s.AddTool(tools.GetCompanySnapshotTool(), handlers.HandleGetCompanySnapshot)
+	s.AddTool(tools.GetQuoteSummaryTool(), handlers.HandleGetQuoteSummary)
 	s.AddTool(tools.GetChartTool(), handlers.HandleGetChart)
 	s.AddTool(tools.SearchTool(), handlers.HandleSearch)
+	s.AddTool(tools.ResolveSymbolTool(), handlers.HandleResolveSymbol)
 	s.AddTool(tools.GetFinancialsTool(), handlers.HandleGetFinancials)
+	s.AddTool(tools.GetFinancialRatiosTool(), handlers.HandleGetFinancialRatios)
+	s.AddTool(tools.GetQualityScoresTool(), handlers.HandleGetQualityScores)
+	s.AddTool(tools.GetDCFValuationTool(), handlers.HandleGetDCFValuation)
 	s.AddTool(tools.GetOptionsTool(), handlers.HandleGetOptions)
+	s.AddTool(tools.GetVolatilitySurfaceTool(), handlers.HandleGetVolatilitySurface)
+	s.AddTool(tools.AnalyzeOptionStrategyTool(), handlers.HandleAnalyzeOptionStrategy)
 	s.AddTool(tools.GetRecommendationsTool(), handlers.HandleGetRecommendations)
+	s.AddTool(tools.GetAnalystActionsTool(), handlers.HandleGetAnalystActions)
 	s.AddTool(tools.GetNewsTool(), handlers.HandleGetNews)
+	s.AddTool(tools.GetNewsSentimentTool(), handlers.HandleGetNewsSentiment)
+	s.AddTool(tools.FetchArticleTool(), handlers.HandleFetchArticle)
+	s.AddTool(tools.GetSimilarSymbolsTool(), handlers.HandleGetSimilarSymbols)
+	s.AddTool(tools.CompareFundamentalsTool(), handlers.HandleCompareFundamentals)
 	s.AddTool(tools.GetProfileTool(), handlers.HandleGetProfile)
+	s.AddTool(tools.GetFundProfileTool(), handlers.HandleGetFundProfile)
+	s.AddTool(tools.ScreenStocksTool(), handlers.HandleScreenStocks)
 	s.AddTool(tools.GetBulkQuotesTool(), handlers.HandleGetBulkQuotes)
 	s.AddTool(tools.GetBulkSparkTool(), handlers.HandleGetBulkSpark)
 	s.AddTool(tools.GetSectorTool(), handlers.HandleGetSector)
 	s.AddTool(tools.GetIndustryTool(), handlers.HandleGetIndustry)
+	s.AddTool(tools.GetMoversTool(), handlers.HandleGetMovers)
+	s.AddTool(tools.ConvertCurrencyTool(), handlers.HandleConvertCurrency)
 	s.AddTool(tools.GetMarketSummaryTool(), handlers.HandleGetMarketSummary)
 	s.AddTool(tools.GetMarketStatusTool(), handlers.HandleGetMarketStatus)
 
-	if err := server.ServeStdio(s); err != nil {
+	s.AddPrompt(tools.EquityResearchPrompt(), tools.HandleEquityResearchPrompt)
+	s.AddPrompt(tools.EarningsPreviewPrompt(), tools.HandleEarningsPreviewPrompt)
+	s.AddPrompt(tools.OptionsTradeIdeaPrompt(), tools.HandleOptionsTradeIdeaPrompt)
+	s.AddPrompt(tools.SectorRotationPrompt(), tools.HandleSectorRotationPrompt)
+	s.AddPrompt(tools.MorningBriefPrompt(), tools.HandleMorningBriefPrompt)
+
+	if err := handlers.ServeStdio(s); err != nil {
 		log.Fatalf("Server error: %v", err)
 	}
 }
@@ -0,0 +1,234 @@
+package options
+
+import (
+	"math"
+	"sort"
+
+	"github.com/emmanuelay/yahoo-finance-mcp/yahoo"
+)
+
+// Unusual activity thresholds: volume at least UnusualVolumeOIRatio times
+// open interest and at least UnusualMinVolume contracts.
+const (
+	UnusualVolumeOIRatio = 2.0
+	UnusualMinVolume     = 500
+	maxUnusual           = 10
+)
+
+// Filter selects contracts from a chain. Zero values disable the
+// corresponding filter.
+type Filter struct {
+	// Strikes keeps this many listed strikes below the spot and this many
+	// at or above it.
+	Strikes int
+	// MinMoneyness and MaxMoneyness bound strike/spot.
+	MinMoneyness, MaxMoneyness float64
+	MinVolume                  int
+	MinOpenInterest            int
+}
+
+// FilterChain returns the contracts of chain that pass f, in strike order.
+func FilterChain(chain yahoo.OptionsChain, spot float64, f Filter) yahoo.OptionsChain {
+	lo, hi := math.Inf(-1), math.Inf(1)
+	if f.Strikes > 0 && spot > 0 {
+		lo, hi = strikeWindow(chain, spot, f.Strikes)
+	}
+
+	keep := func(c yahoo.OptionContract) bool {
+		if c.Strike < lo || c.Strike > hi {
+			return false
+		}
+		if spot > 0 {
+			m := c.Strike / spot
+			if f.MinMoneyness > 0 && m < f.MinMoneyness {
+				return false
+			}
+			if f.MaxMoneyness > 0 && m > f.MaxMoneyness {
+				return false
+			}
+		}
+		return c.Volume >= f.MinVolume && c.OpenInterest >= f.MinOpenInterest
+	}
+
+	out := yahoo.OptionsChain{ExpirationDate: chain.ExpirationDate}
+	for _, c := range chain.Calls {
+		if keep(c) {
+			out.Calls = append(out.Calls, c)
+		}
+	}
+	for _, p := range chain.Puts {
+		if keep(p) {
+			out.Puts = append(out.Puts, p)
+		}
+	}
+	return out
+}
+
+// strikeWindow returns the lowest and highest strike within n listed
+// strikes below and n at or above spot.
+func strikeWindow(chain yahoo.OptionsChain, spot float64, n int) (float64, float64) {
+	strikes := listedStrikes(chain)
+	i := sort.SearchFloat64s(strikes, spot)
+	first := max(i-n, 0)
+	last := min(i+n, len(strikes)) - 1
+	if last < first {
+		return math.Inf(1), math.Inf(-1)
+	}
+	return strikes[first], strikes[last]
+}
+
+func listedStrikes(chain yahoo.OptionsChain) []float64 {
+	seen := make(map[float64]bool)
+	var strikes []float64
+	for _, list := range [][]yahoo.OptionContract{chain.Calls, chain.Puts} {
+		for _, c := range list {
+			if !seen[c.Strike] {
+				seen[c.Strike] = true
+				strikes = append(strikes, c.Strike)
+			}
+		}
+	}
+	sort.Float64s(strikes)
+	return strikes
+}
+
+// Analytics summarizes positioning in one expiration.
+type Analytics struct {
+	CallVolume, PutVolume             int
+	CallOpenInterest, PutOpenInterest int
+	// PutCallVolume and PutCallOI are NaN when there is no call activity.
+	PutCallVolume, PutCallOI float64
+
+	// MaxPain is the strike at which expiring options pay holders the least.
+	MaxPain    float64
+	HasMaxPain bool
+
+	// Straddle is the at-the-money call plus put price; as the market's
+	// expected move to expiration it is quoted in price and percent of spot.
+	StraddleStrike  float64
+	Straddle        float64
+	ExpectedMovePct float64
+	HasStraddle     bool
+
+	// Unusual lists contracts whose volume stands out against open
+	// interest, highest volume first.
+	Unusual []Activity
+}
+
+// Activity is a contract flagged for unusual volume.
+type Activity struct {
+	Put      bool
+	Contract yahoo.OptionContract
+	// Ratio is volume over open interest; +Inf when open interest is zero.
+	Ratio float64
+}
+
+// Analyze computes analytics over every contract in chain.
+func Analyze(chain yahoo.OptionsChain, spot float64) Analytics {
+	var a Analytics
+	for _, c := range chain.Calls {
+		a.CallVolume += c.Volume
+		a.CallOpenInterest += c.OpenInterest
+	}
+	for _, p := range chain.Puts {
+		a.PutVolume += p.Volume
+		a.PutOpenInterest += p.OpenInterest
+	}
+	a.PutCallVolume = ratio(a.PutVolume, a.CallVolume)
+	a.PutCallOI = ratio(a.PutOpenInterest, a.CallOpenInterest)
+
+	a.MaxPain, a.HasMaxPain = maxPain(chain)
+	a.StraddleStrike, a.Straddle, a.HasStraddle = straddle(chain, spot)
+	if a.HasStraddle && spot > 0 {
+		a.ExpectedMovePct = a.Straddle / spot
+	}
+	a.Unusual = unusual(chain)
+	return a
+}
+
+func ratio(num, den int) float64 {
+	if den == 0 {
+		return math.NaN()
+	}
+	return float64(num) / float64(den)
+}
+
+func maxPain(chain yahoo.OptionsChain) (float64, bool) {
+	best, found := 0.0, false
+	bestPayout := math.Inf(1)
+	for _, k := range listedStrikes(chain) {
+		payout := 0.0
+		for _, c := range chain.Calls {
+			payout += float64(c.OpenInterest) * math.Max(k-c.Strike, 0)
+		}
+		for _, p := range chain.Puts {
+			payout += float64(p.OpenInterest) * math.Max(p.Strike-k, 0)
+		}
+		if payout < bestPayout {
+			best, bestPayout, found = k, payout, true
+		}
+	}
+	return best, found
+}
+
+// straddle prices the call and put at the listed strike nearest spot using
+// bid/ask midpoints, falling back to last prices when either side is unquoted.
+func straddle(chain yahoo.OptionsChain, spot float64) (strike, price float64, ok bool) {
+	puts := make(map[float64]yahoo.OptionContract, len(chain.Puts))
+	for _, p := range chain.Puts {
+		puts[p.Strike] = p
+	}
+
+	dist := math.Inf(1)
+	for _, c := range chain.Calls {
+		p, found := puts[c.Strike]
+		if !found {
+			continue
+		}
+		cp, pp := Mid(c), Mid(p)
+		if cp <= 0 || pp <= 0 {
+			continue
+		}
+		if d := math.Abs(c.Strike - spot); d < dist {
+			dist, strike, price, ok = d, c.Strike, cp+pp, true
+		}
+	}
+	return strike, price, ok
+}
+
+// Mid returns the bid/ask midpoint, or the last price when the contract
+// lacks a two-sided quote.
+func Mid(c yahoo.OptionContract) float64 {
+	if c.Bid > 0 && c.Ask > 0 {
+		return (c.Bid + c.Ask) / 2
+	}
+	return c.LastPrice
+}
+
+func unusual(chain yahoo.OptionsChain) []Activity {
+	var out []Activity
+	check := func(c yahoo.OptionContract, put bool) {
+		if c.Volume < UnusualMinVolume {
+			return
+		}
+		r := math.Inf(1)
+		if c.OpenInterest > 0 {
+			r = float64(c.Volume) / float64(c.OpenInterest)
+		}
+		if r >= UnusualVolumeOIRatio {
+			out = append(out, Activity{Put: put, Contract: c, Ratio: r})
+		}
+	}
+	for _, c := range chain.Calls {
+		check(c, false)
+	}
+	for _, p := range chain.Puts {
+		check(p, true)
+	}
+
+	sort.SliceStable(out, func(i, j int) bool { return out[i].Contract.Volume > out[j].Contract.Volume })
+	if len(out) > maxUnusual {
+		out = out[:maxUnusual]
+	}
+	return out
+}
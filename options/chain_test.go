@@ -0,0 +1,112 @@
+package options
+
+import (
+	"math"
+	"testing"
+
+	"github.com/emmanuelay/yahoo-finance-mcp/yahoo"
+)
+
+func testChain() yahoo.OptionsChain {
+	return yahoo.OptionsChain{
+		ExpirationDate: expiry(30),
+		Calls: []yahoo.OptionContract{
+			{Strike: 90, OpenInterest: 100},
+			{Strike: 95, OpenInterest: 200},
+			{Strike: 100, OpenInterest: 500, Volume: 600, Bid: 4, Ask: 4.4},
+			{Strike: 105, OpenInterest: 800, Bid: 1.5, Ask: 1.7},
+			{Strike: 110, OpenInterest: 300, Volume: 2000},
+		},
+		Puts: []yahoo.OptionContract{
+			{Strike: 90, OpenInterest: 400, Volume: 900},
+			{Strike: 95, OpenInterest: 600},
+			{Strike: 100, OpenInterest: 500, Bid: 2, Ask: 2.2},
+			{Strike: 105, OpenInterest: 100, Volume: 50, LastPrice: 4.1},
+			{Strike: 110, OpenInterest: 50},
+		},
+	}
+}
+
+func TestFilterChain_Strikes(t *testing.T) {
+	got := FilterChain(testChain(), 102, Filter{Strikes: 1})
+	if len(got.Calls) != 2 || got.Calls[0].Strike != 100 || got.Calls[1].Strike != 105 {
+		t.Errorf("calls = %+v, want strikes 100 and 105", got.Calls)
+	}
+	if len(got.Puts) != 2 {
+		t.Errorf("puts = %d, want 2", len(got.Puts))
+	}
+	if got.ExpirationDate != expiry(30) {
+		t.Error("expiration not preserved")
+	}
+
+	// a window wider than the listing keeps everything
+	if got := FilterChain(testChain(), 102, Filter{Strikes: 10}); len(got.Calls) != 5 {
+		t.Errorf("calls = %d, want 5", len(got.Calls))
+	}
+}
+
+func TestFilterChain_MoneynessAndActivity(t *testing.T) {
+	got := FilterChain(testChain(), 100, Filter{MinMoneyness: 0.95, MaxMoneyness: 1.05})
+	if len(got.Calls) != 3 || got.Calls[0].Strike != 95 || got.Calls[2].Strike != 105 {
+		t.Errorf("calls = %+v, want strikes 95-105", got.Calls)
+	}
+
+	got = FilterChain(testChain(), 100, Filter{MinVolume: 100, MinOpenInterest: 400})
+	if len(got.Calls) != 1 || got.Calls[0].Strike != 100 {
+		t.Errorf("calls = %+v, want only strike 100", got.Calls)
+	}
+	if len(got.Puts) != 1 || got.Puts[0].Strike != 90 {
+		t.Errorf("puts = %+v, want only strike 90", got.Puts)
+	}
+}
+
+func TestAnalyze(t *testing.T) {
+	a := Analyze(testChain(), 102)
+
+	if a.CallVolume != 2600 || a.PutVolume != 950 {
+		t.Errorf("volume = %d calls / %d puts, want 2600 / 950", a.CallVolume, a.PutVolume)
+	}
+	if a.CallOpenInterest != 1900 || a.PutOpenInterest != 1650 {
+		t.Errorf("open interest = %d / %d, want 1900 / 1650", a.CallOpenInterest, a.PutOpenInterest)
+	}
+	if !near(a.PutCallVolume, 950.0/2600) || !near(a.PutCallOI, 1650.0/1900) {
+		t.Errorf("put/call = %v volume, %v OI", a.PutCallVolume, a.PutCallOI)
+	}
+
+	if !a.HasMaxPain || a.MaxPain != 100 {
+		t.Errorf("max pain = %v (%v), want 100", a.MaxPain, a.HasMaxPain)
+	}
+
+	if !a.HasStraddle || a.StraddleStrike != 100 || !near(a.Straddle, 6.3) {
+		t.Errorf("straddle = %v at %v, want 6.3 at 100", a.Straddle, a.StraddleStrike)
+	}
+	if !near(a.ExpectedMovePct, 6.3/102) {
+		t.Errorf("expected move = %v", a.ExpectedMovePct)
+	}
+
+	if len(a.Unusual) != 2 {
+		t.Fatalf("unusual = %+v, want 2 contracts", a.Unusual)
+	}
+	if a.Unusual[0].Put || a.Unusual[0].Contract.Strike != 110 {
+		t.Errorf("unusual[0] = %+v, want the 110 call", a.Unusual[0])
+	}
+	if !a.Unusual[1].Put || !near(a.Unusual[1].Ratio, 2.25) {
+		t.Errorf("unusual[1] = %+v, want the 90 put at 2.25x", a.Unusual[1])
+	}
+}
+
+func TestAnalyze_Empty(t *testing.T) {
+	a := Analyze(yahoo.OptionsChain{}, 100)
+	if a.HasMaxPain || a.HasStraddle || !math.IsNaN(a.PutCallVolume) {
+		t.Errorf("empty chain analytics = %+v", a)
+	}
+}
+
+func TestMid(t *testing.T) {
+	if got := Mid(yahoo.OptionContract{Bid: 1, Ask: 1.2, LastPrice: 5}); !near(got, 1.1) {
+		t.Errorf("Mid = %v, want 1.1", got)
+	}
+	if got := Mid(yahoo.OptionContract{Ask: 1.2, LastPrice: 5}); got != 5 {
+		t.Errorf("Mid without bid = %v, want last price 5", got)
+	}
+}
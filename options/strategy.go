@@ -0,0 +1,368 @@
+package options
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/emmanuelay/yahoo-finance-mcp/pricing"
+	"github.com/emmanuelay/yahoo-finance-mcp/yahoo"
+)
+
+// Multiplier is the number of shares one equity option contract covers.
+const Multiplier = 100
+
+// LegType is the instrument of a strategy leg.
+type LegType string
+
+const (
+	LegCall  LegType = "call"
+	LegPut   LegType = "put"
+	LegStock LegType = "stock"
+)
+
+// Leg is one position in a strategy. Quantity is positive for long and
+// negative for short positions, in contracts for options and shares for
+// stock.
+type Leg struct {
+	Type     LegType
+	Strike   float64
+	Quantity int
+	// Price is the entry price per share: the option mid or the spot.
+	Price float64
+	IV    float64
+}
+
+// units returns the signed number of shares the leg controls.
+func (l Leg) units() float64 {
+	if l.Type == LegStock {
+		return float64(l.Quantity)
+	}
+	return float64(l.Quantity * Multiplier)
+}
+
+// value returns the leg's per-share value at expiration with the underlying at s.
+func (l Leg) value(s float64) float64 {
+	switch l.Type {
+	case LegCall:
+		return math.Max(s-l.Strike, 0)
+	case LegPut:
+		return math.Max(l.Strike-s, 0)
+	}
+	return s
+}
+
+// ParseLegs parses a comma or semicolon separated list of legs such as
+// "buy 1 call 190, sell 1 call 200" or "long 100 shares; short call 210".
+// Each leg is an action (buy, long, sell, short or write), an optional
+// quantity (default 1), an instrument (call, put or shares) and, for
+// options, a strike.
+func ParseLegs(s string) ([]Leg, error) {
+	var legs []Leg
+	for _, raw := range strings.FieldsFunc(s, func(r rune) bool { return r == ',' || r == ';' || r == '\n' }) {
+		raw = strings.TrimSpace(raw)
+		if raw == "" {
+			continue
+		}
+		leg, err := parseLeg(raw)
+		if err != nil {
+			return nil, fmt.Errorf("leg %q: %w", raw, err)
+		}
+		legs = append(legs, leg)
+	}
+	if len(legs) == 0 {
+		return nil, fmt.Errorf("at least one leg is required")
+	}
+	return legs, nil
+}
+
+func parseLeg(s string) (Leg, error) {
+	fields := strings.Fields(strings.ToLower(s))
+
+	var sign int
+	switch fields[0] {
+	case "buy", "long", "+":
+		sign = 1
+	case "sell", "short", "write", "-":
+		sign = -1
+	default:
+		return Leg{}, fmt.Errorf("must start with buy or sell")
+	}
+	fields = fields[1:]
+
+	qty := 1
+	if len(fields) > 0 {
+		if n, err := strconv.Atoi(strings.Trim(fields[0], "x")); err == nil {
+			if n <= 0 {
+				return Leg{}, fmt.Errorf("quantity must be positive")
+			}
+			qty, fields = n, fields[1:]
+		}
+	}
+	if len(fields) == 0 {
+		return Leg{}, fmt.Errorf("missing instrument (call, put or shares)")
+	}
+
+	var leg Leg
+	switch fields[0] {
+	case "call", "calls", "c":
+		leg.Type = LegCall
+	case "put", "puts", "p":
+		leg.Type = LegPut
+	case "share", "shares", "stock":
+		leg.Type = LegStock
+	default:
+		return Leg{}, fmt.Errorf("unknown instrument %q (use call, put or shares)", fields[0])
+	}
+	fields = fields[1:]
+	leg.Quantity = sign * qty
+
+	if leg.Type == LegStock {
+		if len(fields) > 0 {
+			return Leg{}, fmt.Errorf("unexpected %q after shares", strings.Join(fields, " "))
+		}
+		return leg, nil
+	}
+
+	if len(fields) != 1 {
+		return Leg{}, fmt.Errorf("options need exactly one strike")
+	}
+	k, err := strconv.ParseFloat(strings.TrimLeft(fields[0], "@$"), 64)
+	if err != nil || k <= 0 {
+		return Leg{}, fmt.Errorf("invalid strike %q", fields[0])
+	}
+	leg.Strike = k
+	return leg, nil
+}
+
+// PriceLegs fills each leg's entry price and implied volatility from the
+// chain: option mids (see Mid) and the spot for stock.
+func PriceLegs(legs []Leg, chain yahoo.OptionsChain, spot float64) ([]Leg, error) {
+	out := make([]Leg, len(legs))
+	for i, l := range legs {
+		if l.Type == LegStock {
+			l.Price = spot
+			out[i] = l
+			continue
+		}
+		list := chain.Calls
+		if l.Type == LegPut {
+			list = chain.Puts
+		}
+		found := false
+		for _, c := range list {
+			if c.Strike == l.Strike {
+				l.Price, l.IV, found = Mid(c), c.ImpliedVolatility, true
+				break
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("no %s listed at strike %.2f", l.Type, l.Strike)
+		}
+		if l.Price <= 0 {
+			return nil, fmt.Errorf("no price for the %.2f %s", l.Strike, l.Type)
+		}
+		out[i] = l
+	}
+	return out, nil
+}
+
+// Market holds the inputs for strategy probabilities and Greeks.
+type Market struct {
+	Spot     float64
+	Years    float64
+	Rate     float64
+	Dividend float64
+	// Vol is used for the probability of profit and for legs without a
+	// usable implied volatility.
+	Vol float64
+}
+
+// PayoffPoint is the profit or loss at expiration for an underlying price.
+type PayoffPoint struct {
+	Price float64
+	PnL   float64
+}
+
+// Strategy is the analysis of a multi-leg position held to expiration.
+type Strategy struct {
+	Legs []Leg
+	// NetCost is the cash paid to open the position; negative for a credit.
+	NetCost float64
+	// MaxProfit and MaxLoss are over all prices at expiration; MaxLoss is
+	// negative. Unlimited flags mark sides that grow without bound.
+	MaxProfit, MaxLoss             float64
+	UnlimitedProfit, UnlimitedLoss bool
+	Breakevens                     []float64
+	// ProbProfit is the risk-neutral lognormal probability of a profit at
+	// expiration using Market.Vol.
+	ProbProfit float64
+	// Greeks are position totals in dollars; Delta is in shares.
+	Greeks pricing.Greeks
+	Payoff []PayoffPoint
+}
+
+// AnalyzeStrategy computes the expiration profile, probability of profit
+// and aggregate Greeks of priced legs, with the payoff sampled on grid.
+func AnalyzeStrategy(legs []Leg, m Market, grid []float64) Strategy {
+	st := Strategy{Legs: legs}
+	for _, l := range legs {
+		st.NetCost += l.units() * l.Price
+	}
+
+	pnl := func(s float64) float64 {
+		total := 0.0
+		for _, l := range legs {
+			total += l.units() * (l.value(s) - l.Price)
+		}
+		return total
+	}
+
+	// P&L is piecewise linear with kinks at the strikes.
+	points := []float64{0}
+	slope := 0.0
+	for _, l := range legs {
+		if l.Type != LegStock {
+			points = append(points, l.Strike)
+		}
+		if l.Type != LegPut {
+			slope += l.units()
+		}
+	}
+	sort.Float64s(points)
+	points = dedupe(points)
+
+	st.MaxProfit, st.MaxLoss = math.Inf(-1), math.Inf(1)
+	for _, x := range points {
+		v := pnl(x)
+		st.MaxProfit = math.Max(st.MaxProfit, v)
+		st.MaxLoss = math.Min(st.MaxLoss, v)
+	}
+	st.UnlimitedProfit = slope > 0
+	st.UnlimitedLoss = slope < 0
+
+	addBreakeven := func(x float64) {
+		if n := len(st.Breakevens); n == 0 || st.Breakevens[n-1] != x {
+			st.Breakevens = append(st.Breakevens, x)
+		}
+	}
+	for i := 0; i+1 < len(points); i++ {
+		a, b := points[i], points[i+1]
+		fa, fb := pnl(a), pnl(b)
+		if fa == 0 {
+			addBreakeven(a)
+		} else if fa*fb < 0 {
+			addBreakeven(a + (b-a)*fa/(fa-fb))
+		}
+	}
+	last := points[len(points)-1]
+	if fl := pnl(last); fl == 0 {
+		addBreakeven(last)
+	} else if fl*slope < 0 {
+		addBreakeven(last - fl/slope)
+	}
+
+	st.ProbProfit = probProfit(pnl, st.Breakevens, m)
+
+	for _, l := range legs {
+		if l.Type == LegStock {
+			st.Greeks.Delta += l.units()
+			continue
+		}
+		vol := l.IV
+		if vol < pricing.MinVolatility {
+			vol = m.Vol
+		}
+		kind := pricing.Call
+		if l.Type == LegPut {
+			kind = pricing.Put
+		}
+		g := pricing.Price(pricing.BlackScholes, pricing.Params{
+			Kind: kind, Spot: m.Spot, Strike: l.Strike, Years: m.Years,
+			Rate: m.Rate, Dividend: m.Dividend, Vol: vol,
+		})
+		u := l.units()
+		st.Greeks.Value += u * g.Value
+		st.Greeks.Delta += u * g.Delta
+		st.Greeks.Gamma += u * g.Gamma
+		st.Greeks.Theta += u * g.Theta
+		st.Greeks.Vega += u * g.Vega
+		st.Greeks.Rho += u * g.Rho
+	}
+
+	for _, x := range grid {
+		st.Payoff = append(st.Payoff, PayoffPoint{Price: x, PnL: pnl(x)})
+	}
+	return st
+}
+
+func dedupe(sorted []float64) []float64 {
+	out := sorted[:0]
+	for i, v := range sorted {
+		if i == 0 || v != sorted[i-1] {
+			out = append(out, v)
+		}
+	}
+	return out
+}
+
+// probProfit sums the lognormal probability of each interval between
+// breakevens on which the position is profitable.
+func probProfit(pnl func(float64) float64, breakevens []float64, m Market) float64 {
+	if m.Spot <= 0 || m.Years <= 0 || m.Vol <= 0 {
+		if pnl(m.Spot) > 0 {
+			return 1
+		}
+		return 0
+	}
+
+	sd := m.Vol * math.Sqrt(m.Years)
+	mu := math.Log(m.Spot) + (m.Rate-m.Dividend-m.Vol*m.Vol/2)*m.Years
+	cdf := func(x float64) float64 {
+		if x <= 0 {
+			return 0
+		}
+		return 0.5 * math.Erfc(-(math.Log(x)-mu)/(sd*math.Sqrt2))
+	}
+
+	bounds := append([]float64{0}, breakevens...)
+	prob := 0.0
+	for i, lo := range bounds {
+		hi, probe := math.Inf(1), lo*2+1
+		if i+1 < len(bounds) {
+			hi = bounds[i+1]
+			probe = (lo + hi) / 2
+		}
+		if pnl(probe) <= 0 {
+			continue
+		}
+		upper := 1.0
+		if !math.IsInf(hi, 1) {
+			upper = cdf(hi)
+		}
+		prob += upper - cdf(lo)
+	}
+	return prob
+}
+
+// PayoffGrid returns n evenly spaced prices spanning the strikes and spot
+// with a margin on each side.
+func PayoffGrid(legs []Leg, spot float64, n int) []float64 {
+	lo, hi := spot, spot
+	for _, l := range legs {
+		if l.Type != LegStock {
+			lo, hi = math.Min(lo, l.Strike), math.Max(hi, l.Strike)
+		}
+	}
+	lo, hi = lo*0.85, hi*1.15
+	if n < 2 {
+		return []float64{spot}
+	}
+	grid := make([]float64, n)
+	for i := range grid {
+		grid[i] = lo + (hi-lo)*float64(i)/float64(n-1)
+	}
+	return grid
+}
@@ -0,0 +1,161 @@
+package options
+
+import (
+	"math"
+	"testing"
+
+	"github.com/emmanuelay/yahoo-finance-mcp/pricing"
+	"github.com/emmanuelay/yahoo-finance-mcp/yahoo"
+)
+
+func TestParseLegs(t *testing.T) {
+	legs, err := ParseLegs("buy 1 call 190, sell call $200; long 100 shares; sell 2x put @180")
+	if err != nil {
+		t.Fatalf("ParseLegs() error: %v", err)
+	}
+	want := []Leg{
+		{Type: LegCall, Strike: 190, Quantity: 1},
+		{Type: LegCall, Strike: 200, Quantity: -1},
+		{Type: LegStock, Quantity: 100},
+		{Type: LegPut, Strike: 180, Quantity: -2},
+	}
+	if len(legs) != len(want) {
+		t.Fatalf("legs = %+v, want %d legs", legs, len(want))
+	}
+	for i := range want {
+		if legs[i] != want[i] {
+			t.Errorf("leg %d = %+v, want %+v", i, legs[i], want[i])
+		}
+	}
+}
+
+func TestParseLegs_Invalid(t *testing.T) {
+	for _, s := range []string{"", "hold 1 call 100", "buy 1 call", "buy 0 put 100", "buy 1 future 100", "buy 100 shares 50", "sell call abc"} {
+		if _, err := ParseLegs(s); err == nil {
+			t.Errorf("ParseLegs(%q) expected error", s)
+		}
+	}
+}
+
+func TestPriceLegs(t *testing.T) {
+	chain := yahoo.OptionsChain{
+		Calls: []yahoo.OptionContract{{Strike: 100, Bid: 4, Ask: 4.4, ImpliedVolatility: 0.3}},
+		Puts:  []yahoo.OptionContract{{Strike: 100, LastPrice: 3}},
+	}
+	legs, err := PriceLegs([]Leg{{Type: LegCall, Strike: 100, Quantity: 1}, {Type: LegPut, Strike: 100, Quantity: 1}, {Type: LegStock, Quantity: 10}}, chain, 101)
+	if err != nil {
+		t.Fatalf("PriceLegs() error: %v", err)
+	}
+	if !near(legs[0].Price, 4.2) || legs[0].IV != 0.3 || legs[1].Price != 3 || legs[2].Price != 101 {
+		t.Errorf("priced legs = %+v", legs)
+	}
+
+	if _, err := PriceLegs([]Leg{{Type: LegPut, Strike: 95, Quantity: 1}}, chain, 101); err == nil {
+		t.Error("expected error for an unlisted strike")
+	}
+}
+
+func flat(spot float64) Market {
+	return Market{Spot: spot, Years: 1, Vol: 0.2}
+}
+
+func TestAnalyzeStrategy_BullCallSpread(t *testing.T) {
+	legs := []Leg{
+		{Type: LegCall, Strike: 100, Quantity: 1, Price: 5, IV: 0.2},
+		{Type: LegCall, Strike: 110, Quantity: -1, Price: 2, IV: 0.2},
+	}
+	st := AnalyzeStrategy(legs, flat(100), []float64{90, 105, 120})
+
+	if !near(st.NetCost, 300) {
+		t.Errorf("NetCost = %v, want 300 debit", st.NetCost)
+	}
+	if !near(st.MaxProfit, 700) || !near(st.MaxLoss, -300) || st.UnlimitedProfit || st.UnlimitedLoss {
+		t.Errorf("profit/loss = %v / %v (unlimited %v/%v), want 700 / -300", st.MaxProfit, st.MaxLoss, st.UnlimitedProfit, st.UnlimitedLoss)
+	}
+	if len(st.Breakevens) != 1 || !near(st.Breakevens[0], 103) {
+		t.Errorf("Breakevens = %v, want [103]", st.Breakevens)
+	}
+	wantPayoff := []float64{-300, 200, 700}
+	for i, p := range st.Payoff {
+		if !near(p.PnL, wantPayoff[i]) {
+			t.Errorf("payoff at %v = %v, want %v", p.Price, p.PnL, wantPayoff[i])
+		}
+	}
+	if st.ProbProfit <= 0 || st.ProbProfit >= 0.5 {
+		t.Errorf("ProbProfit = %v, want between 0 and 0.5", st.ProbProfit)
+	}
+}
+
+func TestAnalyzeStrategy_ShortStraddle(t *testing.T) {
+	legs := []Leg{
+		{Type: LegCall, Strike: 100, Quantity: -1, Price: 4},
+		{Type: LegPut, Strike: 100, Quantity: -1, Price: 3},
+	}
+	st := AnalyzeStrategy(legs, flat(100), nil)
+
+	if !near(st.NetCost, -700) {
+		t.Errorf("NetCost = %v, want -700 credit", st.NetCost)
+	}
+	if !near(st.MaxProfit, 700) || !st.UnlimitedLoss || st.UnlimitedProfit {
+		t.Errorf("max profit = %v, unlimited loss %v", st.MaxProfit, st.UnlimitedLoss)
+	}
+	if len(st.Breakevens) != 2 || !near(st.Breakevens[0], 93) || !near(st.Breakevens[1], 107) {
+		t.Errorf("Breakevens = %v, want [93 107]", st.Breakevens)
+	}
+
+	// profit between the breakevens under a lognormal with zero drift
+	m := flat(100)
+	sd := m.Vol
+	cdf := func(x float64) float64 { return 0.5 * math.Erfc(-(math.Log(x/100)+sd*sd/2)/(sd*math.Sqrt2)) }
+	if want := cdf(107) - cdf(93); !near(st.ProbProfit, want) {
+		t.Errorf("ProbProfit = %v, want %v", st.ProbProfit, want)
+	}
+}
+
+func TestAnalyzeStrategy_CoveredCall(t *testing.T) {
+	legs := []Leg{
+		{Type: LegStock, Quantity: 100, Price: 100},
+		{Type: LegCall, Strike: 110, Quantity: -1, Price: 2, IV: 0.25},
+	}
+	m := flat(100)
+	st := AnalyzeStrategy(legs, m, nil)
+
+	if !near(st.NetCost, 9800) {
+		t.Errorf("NetCost = %v, want 9800", st.NetCost)
+	}
+	if !near(st.MaxProfit, 1200) || !near(st.MaxLoss, -9800) || st.UnlimitedProfit || st.UnlimitedLoss {
+		t.Errorf("profit/loss = %v / %v", st.MaxProfit, st.MaxLoss)
+	}
+	if len(st.Breakevens) != 1 || !near(st.Breakevens[0], 98) {
+		t.Errorf("Breakevens = %v, want [98]", st.Breakevens)
+	}
+
+	call := pricing.Price(pricing.BlackScholes, pricing.Params{Kind: pricing.Call, Spot: 100, Strike: 110, Years: 1, Vol: 0.25})
+	if !near(st.Greeks.Delta, 100-100*call.Delta) {
+		t.Errorf("Delta = %v, want %v", st.Greeks.Delta, 100-100*call.Delta)
+	}
+	if !near(st.Greeks.Vega, -100*call.Vega) {
+		t.Errorf("Vega = %v, want %v", st.Greeks.Vega, -100*call.Vega)
+	}
+}
+
+func TestAnalyzeStrategy_LongStock(t *testing.T) {
+	st := AnalyzeStrategy([]Leg{{Type: LegStock, Quantity: 1, Price: 100}}, flat(100), nil)
+	if len(st.Breakevens) != 1 || st.Breakevens[0] != 100 {
+		t.Errorf("Breakevens = %v, want [100]", st.Breakevens)
+	}
+	if !st.UnlimitedProfit {
+		t.Error("long stock should have unlimited profit")
+	}
+	// P(S_T > S) with zero drift is N(-sigma/2)
+	if want := 0.5 * math.Erfc(0.1/math.Sqrt2); !near(st.ProbProfit, want) {
+		t.Errorf("ProbProfit = %v, want %v", st.ProbProfit, want)
+	}
+}
+
+func TestPayoffGrid(t *testing.T) {
+	grid := PayoffGrid([]Leg{{Type: LegPut, Strike: 80}, {Type: LegCall, Strike: 120}}, 100, 5)
+	if len(grid) != 5 || !near(grid[0], 68) || !near(grid[4], 138) {
+		t.Errorf("grid = %v, want 68 to 138", grid)
+	}
+}
@@ -0,0 +1,148 @@
+// Package options analyzes option chains: implied volatility surfaces,
+// term structure and skew.
+package options
+
+import (
+	"math"
+	"sort"
+	"time"
+
+	"github.com/emmanuelay/yahoo-finance-mcp/pricing"
+	"github.com/emmanuelay/yahoo-finance-mcp/yahoo"
+)
+
+// DefaultMoneyness is the strike/spot grid a surface is sampled on.
+var DefaultMoneyness = []float64{0.80, 0.85, 0.90, 0.95, 1.00, 1.05, 1.10, 1.15, 1.20}
+
+// Skew wings, as strike/spot ratios.
+const (
+	SkewPutWing  = 0.90
+	SkewCallWing = 1.10
+)
+
+// Surface is implied volatility sampled by moneyness and expiration.
+type Surface struct {
+	Spot      float64
+	Moneyness []float64
+	Slices    []Slice // nearest expiration first
+}
+
+// Slice is the volatility smile for one expiration.
+type Slice struct {
+	Expiration int64
+	Days       float64
+	// IVs[i] is implied volatility at Moneyness[i], or NaN when the strike
+	// range does not cover it.
+	IVs []float64
+	// ATM is implied volatility at the money.
+	ATM float64
+	// Skew is put wing minus call wing volatility; positive when downside
+	// protection is bid. NaN when either wing is missing.
+	Skew float64
+	// Quotes is the number of contracts with a usable implied volatility.
+	Quotes int
+}
+
+// BuildSurface samples each chain's out-of-the-money smile (puts below
+// spot, calls at or above) on the moneyness grid by linear interpolation
+// between listed strikes. Values outside the listed strikes are not
+// extrapolated.
+func BuildSurface(result *yahoo.OptionsResult, moneyness []float64, now time.Time) Surface {
+	spot := result.Quote.RegularMarketPrice
+	s := Surface{Spot: spot, Moneyness: moneyness}
+	if spot <= 0 {
+		return s
+	}
+
+	for _, chain := range result.Options {
+		pts := smile(chain, spot)
+		sl := Slice{
+			Expiration: chain.ExpirationDate,
+			Days:       math.Max(pricing.YearsToExpiry(chain.ExpirationDate, now), 0) * 365,
+			IVs:        make([]float64, len(moneyness)),
+			Quotes:     len(pts),
+		}
+		for i, m := range moneyness {
+			sl.IVs[i] = interpolate(pts, m)
+		}
+		sl.ATM = interpolate(pts, 1)
+		sl.Skew = interpolate(pts, SkewPutWing) - interpolate(pts, SkewCallWing)
+		s.Slices = append(s.Slices, sl)
+	}
+	return s
+}
+
+// ATMVol returns the chain's at-the-money implied volatility interpolated
+// from out-of-the-money contracts, or NaN when strikes do not straddle spot.
+func ATMVol(chain yahoo.OptionsChain, spot float64) float64 {
+	if spot <= 0 {
+		return math.NaN()
+	}
+	return interpolate(smile(chain, spot), 1)
+}
+
+// point is an implied volatility at a moneyness.
+type point struct {
+	m, iv float64
+}
+
+// smile returns out-of-the-money implied volatilities sorted by moneyness.
+func smile(chain yahoo.OptionsChain, spot float64) []point {
+	var pts []point
+	add := func(c yahoo.OptionContract) {
+		if c.ImpliedVolatility >= pricing.MinVolatility && c.Strike > 0 {
+			pts = append(pts, point{m: c.Strike / spot, iv: c.ImpliedVolatility})
+		}
+	}
+	for _, p := range chain.Puts {
+		if p.Strike < spot {
+			add(p)
+		}
+	}
+	for _, c := range chain.Calls {
+		if c.Strike >= spot {
+			add(c)
+		}
+	}
+	sort.Slice(pts, func(i, j int) bool { return pts[i].m < pts[j].m })
+	return pts
+}
+
+func interpolate(pts []point, m float64) float64 {
+	if len(pts) == 0 || m < pts[0].m || m > pts[len(pts)-1].m {
+		return math.NaN()
+	}
+	i := sort.Search(len(pts), func(i int) bool { return pts[i].m >= m })
+	if pts[i].m == m || i == 0 {
+		return pts[i].iv
+	}
+	lo, hi := pts[i-1], pts[i]
+	w := (m - lo.m) / (hi.m - lo.m)
+	return lo.iv + w*(hi.iv-lo.iv)
+}
+
+// TermStructure summarizes at-the-money volatility across expirations.
+type TermStructure struct {
+	Front, Back         float64 // ATM IV of the nearest and farthest slices with data
+	FrontDays, BackDays float64
+	// Slope is back minus front ATM IV: positive is contango (normal),
+	// negative is backwardation (near-term stress).
+	Slope float64
+	OK    bool
+}
+
+// Term returns the ATM term structure of s.
+func (s Surface) Term() TermStructure {
+	var t TermStructure
+	for _, sl := range s.Slices {
+		if math.IsNaN(sl.ATM) {
+			continue
+		}
+		if !t.OK {
+			t.Front, t.FrontDays, t.OK = sl.ATM, sl.Days, true
+		}
+		t.Back, t.BackDays = sl.ATM, sl.Days
+	}
+	t.Slope = t.Back - t.Front
+	return t
+}
@@ -0,0 +1,105 @@
+package options
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/emmanuelay/yahoo-finance-mcp/yahoo"
+)
+
+func near(a, b float64) bool {
+	return math.Abs(a-b) < 1e-9
+}
+
+var now = time.Date(2024, 1, 2, 15, 0, 0, 0, time.UTC)
+
+func expiry(days int) int64 {
+	return time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC).AddDate(0, 0, days).Unix()
+}
+
+func contracts(ivs map[float64]float64) []yahoo.OptionContract {
+	var out []yahoo.OptionContract
+	for k, iv := range ivs {
+		out = append(out, yahoo.OptionContract{Strike: k, ImpliedVolatility: iv})
+	}
+	return out
+}
+
+func testChains() *yahoo.OptionsResult {
+	return &yahoo.OptionsResult{
+		Quote: yahoo.OptionsQuote{RegularMarketPrice: 100},
+		Options: []yahoo.OptionsChain{
+			{
+				ExpirationDate: expiry(30),
+				// ITM calls and puts are ignored in favor of the OTM side
+				Calls: contracts(map[float64]float64{90: 0.99, 100: 0.20, 110: 0.18}),
+				Puts:  contracts(map[float64]float64{90: 0.30, 95: 0.25, 110: 0.99}),
+			},
+			{
+				ExpirationDate: expiry(90),
+				Calls:          contracts(map[float64]float64{100: 0.24, 120: 0.22}),
+				Puts:           contracts(map[float64]float64{80: 0.30, 90: 0.27, 95: 0.00001}),
+			},
+		},
+	}
+}
+
+func TestBuildSurface(t *testing.T) {
+	s := BuildSurface(testChains(), DefaultMoneyness, now)
+
+	if len(s.Slices) != 2 {
+		t.Fatalf("slices = %d, want 2", len(s.Slices))
+	}
+
+	front := s.Slices[0]
+	if !near(front.ATM, 0.20) {
+		t.Errorf("front ATM = %v, want 0.20", front.ATM)
+	}
+	if !near(front.Skew, 0.30-0.18) {
+		t.Errorf("front skew = %v, want 0.12", front.Skew)
+	}
+	if !math.IsNaN(front.IVs[0]) {
+		t.Errorf("front IV at 0.80 = %v, want NaN (no extrapolation)", front.IVs[0])
+	}
+	if !near(front.IVs[5], 0.19) {
+		t.Errorf("front IV at 1.05 = %v, want 0.19 (interpolated)", front.IVs[5])
+	}
+	if front.Quotes != 4 {
+		t.Errorf("front quotes = %d, want 4", front.Quotes)
+	}
+	if math.Abs(front.Days-(30-15.0/24+20.0/24)) > 1e-6 {
+		t.Errorf("front days = %v", front.Days)
+	}
+
+	back := s.Slices[1]
+	// the near-zero IV at 95 is discarded, so 0.95 interpolates 90 -> 100
+	if !near(back.IVs[3], 0.255) {
+		t.Errorf("back IV at 0.95 = %v, want 0.255", back.IVs[3])
+	}
+	if !near(back.Skew, 0.27-0.23) {
+		t.Errorf("back skew = %v, want 0.04", back.Skew)
+	}
+}
+
+func TestTerm(t *testing.T) {
+	term := BuildSurface(testChains(), DefaultMoneyness, now).Term()
+	if !term.OK {
+		t.Fatal("expected term structure")
+	}
+	if !near(term.Front, 0.20) || !near(term.Back, 0.24) || !near(term.Slope, 0.04) {
+		t.Errorf("term = %+v, want front 0.20, back 0.24, slope 0.04", term)
+	}
+
+	if (Surface{}).Term().OK {
+		t.Error("empty surface should have no term structure")
+	}
+}
+
+func TestBuildSurface_NoSpot(t *testing.T) {
+	r := testChains()
+	r.Quote.RegularMarketPrice = 0
+	if s := BuildSurface(r, DefaultMoneyness, now); len(s.Slices) != 0 {
+		t.Errorf("slices = %d, want none without a spot price", len(s.Slices))
+	}
+}
@@ -0,0 +1,174 @@
+// Package peers compares fundamentals and valuation across a group of
+// companies, placing each company relative to the group median.
+package peers
+
+import (
+	"math"
+	"sort"
+
+	"github.com/emmanuelay/yahoo-finance-mcp/yahoo"
+)
+
+// Column is a compared metric.
+type Column struct {
+	Name    string
+	Percent bool // a decimal fraction shown as a percentage
+	Money   bool // an absolute amount in the company's currency
+}
+
+// Column names, in presentation order.
+const (
+	MarketCap       = "Mkt Cap"
+	TrailingPE      = "P/E"
+	ForwardPE       = "Fwd P/E"
+	EVToEBITDA      = "EV/EBITDA"
+	GrossMargin     = "Gross Mgn"
+	OperatingMargin = "Op Mgn"
+	NetMargin       = "Net Mgn"
+	RevenueGrowth   = "Rev Gr"
+	DividendYield   = "Div Yld"
+	OneYearReturn   = "1Y Ret"
+)
+
+// Columns lists every compared metric in presentation order.
+var Columns = []Column{
+	{Name: MarketCap, Money: true},
+	{Name: TrailingPE},
+	{Name: ForwardPE},
+	{Name: EVToEBITDA},
+	{Name: GrossMargin, Percent: true},
+	{Name: OperatingMargin, Percent: true},
+	{Name: NetMargin, Percent: true},
+	{Name: RevenueGrowth, Percent: true},
+	{Name: DividendYield, Percent: true},
+	{Name: OneYearReturn, Percent: true},
+}
+
+// Company is one row of a comparison. A metric is absent from Values when
+// Yahoo does not report it.
+type Company struct {
+	Symbol   string
+	Name     string
+	Currency string
+	Values   map[string]float64
+}
+
+// FromSummary builds a comparison row from a quoteSummary result with the
+// yahoo.FundamentalsModules modules.
+func FromSummary(symbol string, r *yahoo.QuoteSummaryResult) Company {
+	c := Company{Symbol: symbol, Values: make(map[string]float64)}
+	set := func(name string, v yahoo.YahooValue) {
+		if v.Raw != 0 || v.Fmt != "" {
+			c.Values[name] = v.Raw
+		}
+	}
+	// Multiples are only meaningful when positive; Yahoo reports a
+	// negative P/E or EV/EBITDA for loss-making companies.
+	setMultiple := func(name string, v yahoo.YahooValue) {
+		if v.Raw > 0 {
+			c.Values[name] = v.Raw
+		}
+	}
+
+	if p := r.Price; p != nil {
+		c.Name = p.LongName
+		if c.Name == "" {
+			c.Name = p.ShortName
+		}
+		c.Currency = p.Currency
+		if p.MarketCap.Raw > 0 {
+			c.Values[MarketCap] = float64(p.MarketCap.Raw)
+		}
+	}
+	if d := r.SummaryDetail; d != nil {
+		setMultiple(TrailingPE, d.TrailingPE)
+		setMultiple(ForwardPE, d.ForwardPE)
+		set(DividendYield, d.DividendYield)
+	}
+	if f := r.FinancialData; f != nil {
+		set(GrossMargin, f.GrossMargins)
+		set(OperatingMargin, f.OperatingMargins)
+		set(NetMargin, f.ProfitMargins)
+		set(RevenueGrowth, f.RevenueGrowth)
+	}
+	if k := r.DefaultKeyStatistics; k != nil {
+		setMultiple(EVToEBITDA, k.EnterpriseToEbitda)
+		set(OneYearReturn, k.FiftyTwoWeekChange)
+	}
+	return c
+}
+
+// Comparison is a peer group with per-column statistics.
+type Comparison struct {
+	Companies []Company
+	// Medians holds the median of each column across the companies that
+	// report it; a column no company reports is absent.
+	Medians map[string]float64
+	// Ranks[i] holds the percentile rank (0-100) of Companies[i] within each
+	// column it reports. Higher ranks mean higher values, which is better for
+	// margins and growth but more expensive for multiples.
+	Ranks []map[string]float64
+}
+
+// Compare computes medians and percentile ranks for each column.
+func Compare(companies []Company) Comparison {
+	cmp := Comparison{
+		Companies: companies,
+		Medians:   make(map[string]float64),
+		Ranks:     make([]map[string]float64, len(companies)),
+	}
+	for i := range cmp.Ranks {
+		cmp.Ranks[i] = make(map[string]float64)
+	}
+
+	for _, col := range Columns {
+		var values []float64
+		for _, c := range companies {
+			if v, ok := c.Values[col.Name]; ok {
+				values = append(values, v)
+			}
+		}
+		if len(values) == 0 {
+			continue
+		}
+		cmp.Medians[col.Name] = Median(values)
+		for i, c := range companies {
+			if v, ok := c.Values[col.Name]; ok {
+				cmp.Ranks[i][col.Name] = PercentileRank(values, v)
+			}
+		}
+	}
+	return cmp
+}
+
+// Median returns the median of values, or NaN when values is empty.
+func Median(values []float64) float64 {
+	if len(values) == 0 {
+		return math.NaN()
+	}
+	s := append([]float64(nil), values...)
+	sort.Float64s(s)
+	n := len(s)
+	if n%2 == 1 {
+		return s[n/2]
+	}
+	return (s[n/2-1] + s[n/2]) / 2
+}
+
+// PercentileRank returns the percentage of values below v, counting values
+// equal to v as half below. A group of one ranks 50.
+func PercentileRank(values []float64, v float64) float64 {
+	if len(values) == 0 {
+		return math.NaN()
+	}
+	var below, equal float64
+	for _, x := range values {
+		switch {
+		case x < v:
+			below++
+		case x == v:
+			equal++
+		}
+	}
+	return (below + equal/2) / float64(len(values)) * 100
+}
@@ -0,0 +1,138 @@
+package peers
+
+import (
+	"math"
+	"testing"
+
+	"github.com/emmanuelay/yahoo-finance-mcp/yahoo"
+)
+
+func near(a, b float64) bool {
+	return math.Abs(a-b) < 1e-9
+}
+
+func TestMedian(t *testing.T) {
+	tests := []struct {
+		values []float64
+		want   float64
+	}{
+		{[]float64{3, 1, 2}, 2},
+		{[]float64{4, 1, 3, 2}, 2.5},
+		{[]float64{7}, 7},
+	}
+	for _, tt := range tests {
+		if got := Median(tt.values); !near(got, tt.want) {
+			t.Errorf("Median(%v) = %v, want %v", tt.values, got, tt.want)
+		}
+	}
+	if !math.IsNaN(Median(nil)) {
+		t.Error("Median(nil) should be NaN")
+	}
+}
+
+func TestMedian_DoesNotReorderInput(t *testing.T) {
+	values := []float64{3, 1, 2}
+	Median(values)
+	if values[0] != 3 || values[1] != 1 || values[2] != 2 {
+		t.Errorf("Median reordered its input: %v", values)
+	}
+}
+
+func TestPercentileRank(t *testing.T) {
+	values := []float64{10, 20, 30, 40}
+	tests := []struct {
+		v    float64
+		want float64
+	}{
+		{10, 12.5},
+		{20, 37.5},
+		{40, 87.5},
+		{25, 50},
+	}
+	for _, tt := range tests {
+		if got := PercentileRank(values, tt.v); !near(got, tt.want) {
+			t.Errorf("PercentileRank(%v) = %v, want %v", tt.v, got, tt.want)
+		}
+	}
+	if got := PercentileRank([]float64{5}, 5); !near(got, 50) {
+		t.Errorf("single value rank = %v, want 50", got)
+	}
+	if got := PercentileRank([]float64{5, 5, 5}, 5); !near(got, 50) {
+		t.Errorf("all-equal rank = %v, want 50", got)
+	}
+}
+
+func TestFromSummary(t *testing.T) {
+	r := &yahoo.QuoteSummaryResult{
+		Price: &yahoo.PriceData{
+			ShortName: "Acme",
+			Currency:  "USD",
+			MarketCap: yahoo.YahooLongValue{Raw: 5e9},
+		},
+		SummaryDetail: &yahoo.SummaryDetailData{
+			TrailingPE: yahoo.YahooValue{Raw: -12, Fmt: "-12.00"},
+			ForwardPE:  yahoo.YahooValue{Raw: 18, Fmt: "18.00"},
+		},
+		FinancialData: &yahoo.FinancialDataModule{
+			GrossMargins:     yahoo.YahooValue{Raw: 0.4, Fmt: "40.00%"},
+			OperatingMargins: yahoo.YahooValue{Raw: 0, Fmt: "0.00%"},
+		},
+		DefaultKeyStatistics: &yahoo.DefaultKeyStatisticsData{
+			EnterpriseToEbitda: yahoo.YahooValue{Raw: 11, Fmt: "11.00"},
+			FiftyTwoWeekChange: yahoo.YahooValue{Raw: -0.1, Fmt: "-10.00%"},
+		},
+	}
+
+	c := FromSummary("ACME", r)
+	if c.Name != "Acme" || c.Currency != "USD" {
+		t.Errorf("Name/Currency = %q/%q, want Acme/USD", c.Name, c.Currency)
+	}
+	want := map[string]float64{
+		MarketCap:       5e9,
+		ForwardPE:       18,
+		EVToEBITDA:      11,
+		GrossMargin:     0.4,
+		OperatingMargin: 0,
+		OneYearReturn:   -0.1,
+	}
+	for name, v := range want {
+		got, ok := c.Values[name]
+		if !ok || !near(got, v) {
+			t.Errorf("%s = %v (present %v), want %v", name, got, ok, v)
+		}
+	}
+	for _, name := range []string{TrailingPE, NetMargin, RevenueGrowth, DividendYield} {
+		if v, ok := c.Values[name]; ok {
+			t.Errorf("%s should be absent, got %v", name, v)
+		}
+	}
+}
+
+func TestCompare(t *testing.T) {
+	companies := []Company{
+		{Symbol: "A", Values: map[string]float64{TrailingPE: 10, GrossMargin: 0.3}},
+		{Symbol: "B", Values: map[string]float64{TrailingPE: 20}},
+		{Symbol: "C", Values: map[string]float64{TrailingPE: 30, GrossMargin: 0.5}},
+	}
+
+	cmp := Compare(companies)
+	if got := cmp.Medians[TrailingPE]; !near(got, 20) {
+		t.Errorf("P/E median = %v, want 20", got)
+	}
+	if got := cmp.Medians[GrossMargin]; !near(got, 0.4) {
+		t.Errorf("gross margin median = %v, want 0.4", got)
+	}
+	if _, ok := cmp.Medians[DividendYield]; ok {
+		t.Error("dividend yield median should be absent when no company reports it")
+	}
+
+	if got := cmp.Ranks[2][TrailingPE]; !near(got, 100*2.5/3) {
+		t.Errorf("C P/E rank = %v, want %v", got, 100*2.5/3)
+	}
+	if got := cmp.Ranks[0][GrossMargin]; !near(got, 25) {
+		t.Errorf("A gross margin rank = %v, want 25", got)
+	}
+	if _, ok := cmp.Ranks[1][GrossMargin]; ok {
+		t.Error("B should have no gross margin rank")
+	}
+}
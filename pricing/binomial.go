@@ -0,0 +1,72 @@
+package pricing
+
+import "math"
+
+// binomial prices an American option on a Cox-Ross-Rubinstein tree.
+// Delta, gamma and theta are read from the first nodes of the tree; vega
+// and rho are central differences from repricing.
+func binomial(p Params, steps int) Greeks {
+	g := tree(p, steps)
+
+	const dv, dr = 0.01, 0.0001
+	up, down := p, p
+	up.Vol, down.Vol = p.Vol+dv, math.Max(p.Vol-dv, 1e-6)
+	g.Vega = (tree(up, steps).Value - tree(down, steps).Value) / ((up.Vol - down.Vol) * 100)
+
+	up, down = p, p
+	up.Rate, down.Rate = p.Rate+dr, p.Rate-dr
+	g.Rho = (tree(up, steps).Value - tree(down, steps).Value) / (2 * dr * 100)
+
+	return g
+}
+
+// tree returns value, delta, gamma and theta from a single tree.
+func tree(p Params, steps int) Greeks {
+	dt := p.Years / float64(steps)
+	u := math.Exp(p.Vol * math.Sqrt(dt))
+	d := 1 / u
+	growth := math.Exp((p.Rate - p.Dividend) * dt)
+	q := (growth - d) / (u - d)
+	disc := math.Exp(-p.Rate * dt)
+
+	payoff := func(spot float64) float64 {
+		if p.Kind == Call {
+			return math.Max(spot-p.Strike, 0)
+		}
+		return math.Max(p.Strike-spot, 0)
+	}
+
+	// values[j] is the option value after j up moves at the current step
+	values := make([]float64, steps+1)
+	for j := 0; j <= steps; j++ {
+		values[j] = payoff(p.Spot * math.Pow(u, float64(2*j-steps)))
+	}
+
+	var step1, step2 [3]float64
+	for i := steps - 1; i >= 0; i-- {
+		for j := 0; j <= i; j++ {
+			hold := disc * (q*values[j+1] + (1-q)*values[j])
+			values[j] = math.Max(hold, payoff(p.Spot*math.Pow(u, float64(2*j-i))))
+		}
+		switch i {
+		case 2:
+			copy(step2[:], values[:3])
+		case 1:
+			copy(step1[:], values[:2])
+		}
+	}
+
+	var g Greeks
+	g.Value = values[0]
+	if steps < 2 {
+		return g
+	}
+	su, sd := p.Spot*u, p.Spot*d
+	g.Delta = (step1[1] - step1[0]) / (su - sd)
+	suu, sdd := p.Spot*u*u, p.Spot*d*d
+	deltaUp := (step2[2] - step2[1]) / (suu - p.Spot)
+	deltaDown := (step2[1] - step2[0]) / (p.Spot - sdd)
+	g.Gamma = (deltaUp - deltaDown) / ((suu - sdd) / 2)
+	g.Theta = (step2[1] - g.Value) / (2 * dt) / 365
+	return g
+}
@@ -0,0 +1,147 @@
+// Package pricing values European and American equity options and computes
+// their sensitivities (Greeks).
+//
+// Black-Scholes-Merton gives closed-form European prices with a continuous
+// dividend yield; a Cox-Ross-Rubinstein binomial tree handles early exercise.
+// Rates, yields and volatilities are decimals (0.05 for 5%).
+package pricing
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"time"
+)
+
+// Kind is the option type.
+type Kind int
+
+const (
+	Call Kind = iota
+	Put
+)
+
+// Model selects the pricing model.
+type Model string
+
+const (
+	BlackScholes Model = "black_scholes"
+	Binomial     Model = "binomial"
+)
+
+// BinomialSteps is the tree depth used by the binomial model.
+const BinomialSteps = 200
+
+// Defaults for pricing inputs a caller does not supply.
+const (
+	// DefaultRatesSymbol quotes the 13-week Treasury bill yield in percent.
+	DefaultRatesSymbol = "^IRX"
+	// DefaultRate is used when the rates symbol cannot be quoted.
+	DefaultRate = 0.04
+	// MinVolatility is the lowest implied volatility treated as usable.
+	MinVolatility = 0.005
+)
+
+// ParseModel parses a model name, accepting "bs" and "crr" as shorthands.
+func ParseModel(s string) (Model, error) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "", "black_scholes", "black-scholes", "bs":
+		return BlackScholes, nil
+	case "binomial", "crr", "american":
+		return Binomial, nil
+	}
+	return "", fmt.Errorf("unknown pricing model %q (use black_scholes or binomial)", s)
+}
+
+// Params describes one option to price.
+type Params struct {
+	Kind     Kind
+	Spot     float64
+	Strike   float64
+	Years    float64 // time to expiration
+	Rate     float64 // continuously compounded risk-free rate
+	Dividend float64 // continuous dividend yield
+	Vol      float64 // annualized volatility
+}
+
+// Greeks is a theoretical value with its sensitivities.
+type Greeks struct {
+	Value float64
+	Delta float64
+	Gamma float64
+	Theta float64 // per calendar day
+	Vega  float64 // per 1 percentage point of volatility
+	Rho   float64 // per 1 percentage point of rate
+}
+
+// YearsToExpiry returns the time from now until an option expiring on the
+// given date stops trading, approximating the 4pm New York close as 20:00
+// UTC. Yahoo reports expirations as midnight UTC of the expiration date.
+func YearsToExpiry(expiration int64, now time.Time) float64 {
+	end := time.Unix(expiration, 0).UTC().Add(20 * time.Hour)
+	return end.Sub(now).Hours() / (24 * 365)
+}
+
+// Price values p with the chosen model.
+func Price(m Model, p Params) Greeks {
+	if p.Years <= 0 || p.Vol <= 0 || p.Spot <= 0 || p.Strike <= 0 {
+		return expired(p)
+	}
+	if m == Binomial {
+		return binomial(p, BinomialSteps)
+	}
+	return blackScholes(p)
+}
+
+// expired returns intrinsic value with delta only, for options at or past
+// expiration or without a usable volatility.
+func expired(p Params) Greeks {
+	var g Greeks
+	switch p.Kind {
+	case Call:
+		if p.Spot > p.Strike {
+			g.Value, g.Delta = p.Spot-p.Strike, 1
+		}
+	case Put:
+		if p.Spot < p.Strike {
+			g.Value, g.Delta = p.Strike-p.Spot, -1
+		}
+	}
+	return g
+}
+
+func blackScholes(p Params) Greeks {
+	sqrtT := math.Sqrt(p.Years)
+	d1 := (math.Log(p.Spot/p.Strike) + (p.Rate-p.Dividend+p.Vol*p.Vol/2)*p.Years) / (p.Vol * sqrtT)
+	d2 := d1 - p.Vol*sqrtT
+	qf := math.Exp(-p.Dividend * p.Years)
+	rf := math.Exp(-p.Rate * p.Years)
+	pdf := normPDF(d1)
+
+	var g Greeks
+	g.Gamma = qf * pdf / (p.Spot * p.Vol * sqrtT)
+	g.Vega = p.Spot * qf * pdf * sqrtT / 100
+	decay := -p.Spot * qf * pdf * p.Vol / (2 * sqrtT)
+
+	switch p.Kind {
+	case Call:
+		g.Value = p.Spot*qf*normCDF(d1) - p.Strike*rf*normCDF(d2)
+		g.Delta = qf * normCDF(d1)
+		g.Theta = (decay - p.Rate*p.Strike*rf*normCDF(d2) + p.Dividend*p.Spot*qf*normCDF(d1)) / 365
+		g.Rho = p.Strike * p.Years * rf * normCDF(d2) / 100
+	case Put:
+		g.Value = p.Strike*rf*normCDF(-d2) - p.Spot*qf*normCDF(-d1)
+		g.Delta = -qf * normCDF(-d1)
+		g.Theta = (decay + p.Rate*p.Strike*rf*normCDF(-d2) - p.Dividend*p.Spot*qf*normCDF(-d1)) / 365
+		g.Rho = -p.Strike * p.Years * rf * normCDF(-d2) / 100
+	}
+	return g
+}
+
+func normCDF(x float64) float64 {
+	return 0.5 * math.Erfc(-x/math.Sqrt2)
+}
+
+func normPDF(x float64) float64 {
+	return math.Exp(-x*x/2) / math.Sqrt(2*math.Pi)
+}
@@ -0,0 +1,123 @@
+package pricing
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func within(a, b, tol float64) bool {
+	return math.Abs(a-b) <= tol
+}
+
+func atm(kind Kind) Params {
+	return Params{Kind: kind, Spot: 100, Strike: 100, Years: 1, Rate: 0.05, Vol: 0.2}
+}
+
+func TestBlackScholes(t *testing.T) {
+	call := Price(BlackScholes, atm(Call))
+	checks := []struct {
+		name      string
+		got, want float64
+	}{
+		{"call value", call.Value, 10.4506},
+		{"call delta", call.Delta, 0.6368},
+		{"call gamma", call.Gamma, 0.01876},
+		{"call vega", call.Vega, 0.3752},
+		{"call theta", call.Theta, -6.4140 / 365},
+		{"call rho", call.Rho, 0.5323},
+	}
+	for _, c := range checks {
+		if !within(c.got, c.want, 1e-4) {
+			t.Errorf("%s = %.6f, want %.6f", c.name, c.got, c.want)
+		}
+	}
+
+	put := Price(BlackScholes, atm(Put))
+	if !within(put.Value, 5.5735, 1e-4) {
+		t.Errorf("put value = %.6f, want 5.5735", put.Value)
+	}
+	if !within(put.Delta, call.Delta-1, 1e-9) {
+		t.Errorf("put delta = %.6f, want call delta - 1", put.Delta)
+	}
+}
+
+func TestBlackScholes_PutCallParity(t *testing.T) {
+	p := Params{Spot: 120, Strike: 110, Years: 0.5, Rate: 0.04, Dividend: 0.02, Vol: 0.3}
+	p.Kind = Call
+	call := Price(BlackScholes, p)
+	p.Kind = Put
+	put := Price(BlackScholes, p)
+
+	// C - P = S e^(-qT) - K e^(-rT)
+	want := p.Spot*math.Exp(-p.Dividend*p.Years) - p.Strike*math.Exp(-p.Rate*p.Years)
+	if !within(call.Value-put.Value, want, 1e-9) {
+		t.Errorf("C - P = %.6f, want %.6f", call.Value-put.Value, want)
+	}
+}
+
+func TestBinomial(t *testing.T) {
+	bs := Price(BlackScholes, atm(Call))
+	am := Price(Binomial, atm(Call))
+
+	// without dividends an American call is never exercised early
+	if !within(am.Value, bs.Value, 0.02) {
+		t.Errorf("binomial call = %.4f, want about %.4f", am.Value, bs.Value)
+	}
+	for _, c := range []struct {
+		name      string
+		got, want float64
+	}{
+		{"delta", am.Delta, bs.Delta},
+		{"gamma", am.Gamma, bs.Gamma},
+		{"theta", am.Theta, bs.Theta},
+		{"vega", am.Vega, bs.Vega},
+		{"rho", am.Rho, bs.Rho},
+	} {
+		if !within(c.got, c.want, 0.01) {
+			t.Errorf("binomial %s = %.5f, want about %.5f", c.name, c.got, c.want)
+		}
+	}
+
+	// early exercise makes an American put worth more than a European one
+	if amPut, euPut := Price(Binomial, atm(Put)), Price(BlackScholes, atm(Put)); amPut.Value <= euPut.Value {
+		t.Errorf("American put %.4f should exceed European put %.4f", amPut.Value, euPut.Value)
+	}
+}
+
+func TestPrice_Expired(t *testing.T) {
+	p := atm(Put)
+	p.Spot, p.Years = 90, 0
+	for _, m := range []Model{BlackScholes, Binomial} {
+		g := Price(m, p)
+		if g.Value != 10 || g.Delta != -1 || g.Gamma != 0 {
+			t.Errorf("%s expired put = %+v, want intrinsic 10 and delta -1", m, g)
+		}
+	}
+
+	p = atm(Call)
+	p.Vol = 0
+	p.Spot = 90
+	if g := Price(BlackScholes, p); g.Value != 0 || g.Delta != 0 {
+		t.Errorf("out of the money call without volatility = %+v, want zero", g)
+	}
+}
+
+func TestYearsToExpiry(t *testing.T) {
+	exp := time.Date(2024, 6, 21, 0, 0, 0, 0, time.UTC).Unix()
+	now := time.Date(2024, 6, 20, 20, 0, 0, 0, time.UTC)
+	if got := YearsToExpiry(exp, now); !within(got, 1.0/365, 1e-12) {
+		t.Errorf("YearsToExpiry = %v, want one day", got)
+	}
+}
+
+func TestParseModel(t *testing.T) {
+	for in, want := range map[string]Model{"": BlackScholes, "BS": BlackScholes, "binomial": Binomial, "american": Binomial} {
+		if got, err := ParseModel(in); err != nil || got != want {
+			t.Errorf("ParseModel(%q) = %q, %v; want %q", in, got, err, want)
+		}
+	}
+	if _, err := ParseModel("monte_carlo"); err == nil {
+		t.Error("expected error for unknown model")
+	}
+}
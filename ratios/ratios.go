@@ -0,0 +1,268 @@
+// Package ratios derives profitability, liquidity, leverage and growth
+// metrics from financial statement tables.
+//
+// All metrics are computed per reporting date from values reported for that
+// same date, so a ratio never mixes figures from different periods.
+package ratios
+
+import (
+	"math"
+	"time"
+
+	"github.com/emmanuelay/yahoo-finance-mcp/yahoo"
+)
+
+// RequiredItems lists the statement line items Compute reads.
+var RequiredItems = []string{
+	// Income statement
+	"TotalRevenue", "CostOfRevenue", "GrossProfit", "OperatingIncome", "EBIT", "EBITDA",
+	"InterestExpense", "PretaxIncome", "TaxProvision", "TaxRateForCalcs", "NetIncome", "DilutedEPS",
+	// Balance sheet
+	"TotalAssets", "CurrentAssets", "CurrentLiabilities", "Inventory",
+	"StockholdersEquity", "TotalDebt", "CashAndCashEquivalents", "InvestedCapital",
+	// Cash flow
+	"OperatingCashFlow", "CapitalExpenditure", "FreeCashFlow",
+}
+
+// Metric is a derived series keyed by reporting date.
+type Metric struct {
+	Name   string
+	Values map[string]float64
+}
+
+// Metric names returned by Compute, in presentation order.
+const (
+	GrossMargin      = "Gross Margin"
+	OperatingMargin  = "Operating Margin"
+	NetMargin        = "Net Margin"
+	ReturnOnEquity   = "ROE"
+	ReturnOnCapital  = "ROIC"
+	CurrentRatio     = "Current Ratio"
+	QuickRatio       = "Quick Ratio"
+	DebtToEquity     = "Debt/Equity"
+	InterestCoverage = "Interest Coverage"
+	FCFConversion    = "FCF Conversion"
+)
+
+// Compute derives financial ratios for every date in t.
+// Balance sheet based returns use period-end balances; quarterly returns are
+// not annualized. A ratio is omitted for a date when an input is missing or
+// its denominator is zero.
+func Compute(t *yahoo.StatementTable) []Metric {
+	metrics := []Metric{
+		{Name: GrossMargin}, {Name: OperatingMargin}, {Name: NetMargin},
+		{Name: ReturnOnEquity}, {Name: ReturnOnCapital},
+		{Name: CurrentRatio}, {Name: QuickRatio},
+		{Name: DebtToEquity}, {Name: InterestCoverage}, {Name: FCFConversion},
+	}
+	for i := range metrics {
+		metrics[i].Values = make(map[string]float64)
+	}
+	// add records num/den for metric i when inputs are present and den is non-zero
+	add := func(i int, date string, num, den float64, ok bool) {
+		if ok && den != 0 {
+			metrics[i].Values[date] = num / den
+		}
+	}
+
+	for _, d := range t.Dates {
+		get := func(item string) (float64, bool) { return t.Value(item, d) }
+
+		revenue, hasRevenue := get("TotalRevenue")
+		grossProfit, hasGross := get("GrossProfit")
+		if !hasGross {
+			if cost, ok := get("CostOfRevenue"); ok && hasRevenue {
+				grossProfit, hasGross = revenue-cost, true
+			}
+		}
+		opIncome, hasOpIncome := get("OperatingIncome")
+		netIncome, hasNetIncome := get("NetIncome")
+		equity, hasEquity := get("StockholdersEquity")
+
+		add(0, d, grossProfit, revenue, hasGross && hasRevenue)
+		add(1, d, opIncome, revenue, hasOpIncome && hasRevenue)
+		add(2, d, netIncome, revenue, hasNetIncome && hasRevenue)
+		add(3, d, netIncome, equity, hasNetIncome && hasEquity)
+
+		if afterTax, ok := nopat(t, d); ok {
+			if invested, ok := investedCapital(t, d); ok {
+				add(4, d, afterTax, invested, true)
+			}
+		}
+
+		currentAssets, hasCA := get("CurrentAssets")
+		currentLiabilities, hasCL := get("CurrentLiabilities")
+		add(5, d, currentAssets, currentLiabilities, hasCA && hasCL)
+		inventory, _ := get("Inventory") // no inventory line means none held
+		add(6, d, currentAssets-inventory, currentLiabilities, hasCA && hasCL)
+
+		debt, hasDebt := get("TotalDebt")
+		add(7, d, debt, equity, hasDebt && hasEquity)
+
+		ebit, hasEBIT := get("EBIT")
+		if !hasEBIT {
+			ebit, hasEBIT = opIncome, hasOpIncome
+		}
+		interest, hasInterest := get("InterestExpense")
+		add(8, d, ebit, math.Abs(interest), hasEBIT && hasInterest)
+
+		fcf, hasFCF := freeCashFlow(t, d)
+		add(9, d, fcf, netIncome, hasFCF && hasNetIncome)
+	}
+
+	return metrics
+}
+
+// nopat returns operating income after tax, using Yahoo's computed tax rate
+// when available and otherwise the effective rate (clamped to 0-50%).
+func nopat(t *yahoo.StatementTable, date string) (float64, bool) {
+	opIncome, ok := t.Value("OperatingIncome", date)
+	if !ok {
+		return 0, false
+	}
+	rate, ok := t.Value("TaxRateForCalcs", date)
+	if !ok {
+		tax, hasTax := t.Value("TaxProvision", date)
+		pretax, hasPretax := t.Value("PretaxIncome", date)
+		if !hasTax || !hasPretax || pretax == 0 {
+			return 0, false
+		}
+		rate = tax / pretax
+	}
+	rate = math.Max(0, math.Min(rate, 0.5))
+	return opIncome * (1 - rate), true
+}
+
+// investedCapital prefers Yahoo's reported figure and otherwise uses
+// debt plus equity minus cash.
+func investedCapital(t *yahoo.StatementTable, date string) (float64, bool) {
+	if v, ok := t.Value("InvestedCapital", date); ok {
+		return v, true
+	}
+	equity, hasEquity := t.Value("StockholdersEquity", date)
+	debt, hasDebt := t.Value("TotalDebt", date)
+	if !hasEquity || !hasDebt {
+		return 0, false
+	}
+	cash, _ := t.Value("CashAndCashEquivalents", date)
+	return debt + equity - cash, true
+}
+
+// freeCashFlow prefers the reported figure and otherwise derives it from
+// operating cash flow and capital expenditure (reported as a negative).
+func freeCashFlow(t *yahoo.StatementTable, date string) (float64, bool) {
+	if v, ok := t.Value("FreeCashFlow", date); ok {
+		return v, true
+	}
+	ocf, hasOCF := t.Value("OperatingCashFlow", date)
+	capex, hasCapex := t.Value("CapitalExpenditure", date)
+	if !hasOCF || !hasCapex {
+		return 0, false
+	}
+	return ocf - math.Abs(capex), true
+}
+
+// Growth holds period-over-period growth rates for one line item, keyed by
+// the later reporting date.
+type Growth struct {
+	Item string
+	// YoY compares each date with the value reported about one year earlier.
+	YoY map[string]float64
+	// QoQ compares each date with the value reported about one quarter
+	// earlier. It is empty for annual data.
+	QoQ map[string]float64
+	// CAGR is the compound annual growth rate from the oldest to the newest
+	// value, over Years years. HasCAGR is false when either end is not
+	// positive or the span is shorter than a year.
+	CAGR    float64
+	Years   float64
+	HasCAGR bool
+}
+
+// Tolerance when matching a date with the one a year or a quarter earlier;
+// fiscal periods end on slightly different days from year to year.
+const dateTolerance = 20 * 24 * time.Hour
+
+// ComputeGrowth derives YoY, QoQ and CAGR for every line item in t.
+// Growth from a negative base is measured against its absolute value, so an
+// improvement from -10 to -5 is +50%.
+func ComputeGrowth(t *yahoo.StatementTable) []Growth {
+	dates := make([]time.Time, len(t.Dates))
+	for i, d := range t.Dates {
+		dates[i], _ = time.Parse("2006-01-02", d)
+	}
+
+	var out []Growth
+	for _, item := range t.Items {
+		g := Growth{Item: item, YoY: map[string]float64{}, QoQ: map[string]float64{}}
+
+		for i, d := range t.Dates {
+			cur, ok := t.Value(item, d)
+			if !ok {
+				continue
+			}
+			if prev, ok := valueNear(t, item, dates, dates[i].AddDate(-1, 0, 0)); ok {
+				if v, ok := growth(cur, prev); ok {
+					g.YoY[d] = v
+				}
+			}
+			if prev, ok := valueNear(t, item, dates, dates[i].AddDate(0, -3, 0)); ok {
+				if v, ok := growth(cur, prev); ok {
+					g.QoQ[d] = v
+				}
+			}
+		}
+
+		// Dates are newest first; find the reported endpoints
+		first, last := -1, -1
+		for i := range t.Dates {
+			if _, ok := t.Value(item, t.Dates[i]); ok {
+				if last == -1 {
+					last = i
+				}
+				first = i
+			}
+		}
+		if first > last {
+			start, _ := t.Value(item, t.Dates[first])
+			end, _ := t.Value(item, t.Dates[last])
+			years := dates[last].Sub(dates[first]).Hours() / 24 / 365.25
+			if start > 0 && end > 0 && years >= 0.95 {
+				g.CAGR = math.Pow(end/start, 1/years) - 1
+				g.Years = years
+				g.HasCAGR = true
+			}
+		}
+
+		out = append(out, g)
+	}
+
+	return out
+}
+
+// valueNear returns the item's value at the reported date closest to target,
+// if one lies within dateTolerance.
+func valueNear(t *yahoo.StatementTable, item string, dates []time.Time, target time.Time) (float64, bool) {
+	best := -1
+	var bestDiff time.Duration
+	for i, d := range dates {
+		diff := d.Sub(target)
+		if diff < 0 {
+			diff = -diff
+		}
+		if diff <= dateTolerance && (best == -1 || diff < bestDiff) {
+			best, bestDiff = i, diff
+		}
+	}
+	if best == -1 {
+		return 0, false
+	}
+	return t.Value(item, t.Dates[best])
+}
+
+func growth(cur, prev float64) (float64, bool) {
+	if prev == 0 {
+		return 0, false
+	}
+	return (cur - prev) / math.Abs(prev), true
+}
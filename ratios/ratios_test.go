@@ -0,0 +1,157 @@
+package ratios
+
+import (
+	"math"
+	"testing"
+
+	"github.com/emmanuelay/yahoo-finance-mcp/yahoo"
+)
+
+func table(values map[string]map[string]float64) *yahoo.StatementTable {
+	var results []yahoo.FinancialResult
+	for item, byDate := range values {
+		r := yahoo.FinancialResult{Type: "annual" + item}
+		for d, v := range byDate {
+			r.Items = append(r.Items, yahoo.FinancialItem{Date: d, ReportedValue: v, CurrencyCode: "USD"})
+		}
+		results = append(results, r)
+	}
+	return yahoo.NewStatementTable(results)
+}
+
+func near(a, b float64) bool {
+	return math.Abs(a-b) < 1e-9
+}
+
+func metric(metrics []Metric, name string) Metric {
+	for _, m := range metrics {
+		if m.Name == name {
+			return m
+		}
+	}
+	return Metric{}
+}
+
+func TestCompute(t *testing.T) {
+	const d = "2023-12-31"
+	tbl := table(map[string]map[string]float64{
+		"TotalRevenue":       {d: 1000},
+		"GrossProfit":        {d: 400},
+		"OperatingIncome":    {d: 200},
+		"NetIncome":          {d: 150},
+		"EBIT":               {d: 210},
+		"InterestExpense":    {d: 21},
+		"TaxRateForCalcs":    {d: 0.25},
+		"StockholdersEquity": {d: 750},
+		"InvestedCapital":    {d: 1000},
+		"CurrentAssets":      {d: 500},
+		"CurrentLiabilities": {d: 250},
+		"Inventory":          {d: 100},
+		"TotalDebt":          {d: 300},
+		"OperatingCashFlow":  {d: 220},
+		"CapitalExpenditure": {d: -40},
+	})
+
+	metrics := Compute(tbl)
+	for name, want := range map[string]float64{
+		GrossMargin:      0.4,
+		OperatingMargin:  0.2,
+		NetMargin:        0.15,
+		ReturnOnEquity:   0.2,
+		ReturnOnCapital:  0.15, // 200 * (1 - 0.25) / 1000
+		CurrentRatio:     2,
+		QuickRatio:       1.6,
+		DebtToEquity:     0.4,
+		InterestCoverage: 10,
+		FCFConversion:    1.2, // (220 - 40) / 150
+	} {
+		got, ok := metric(metrics, name).Values[d]
+		if !ok {
+			t.Errorf("%s missing", name)
+			continue
+		}
+		if !near(got, want) {
+			t.Errorf("%s = %v, want %v", name, got, want)
+		}
+	}
+}
+
+func TestCompute_MissingInputsOmitted(t *testing.T) {
+	tbl := table(map[string]map[string]float64{
+		"TotalRevenue": {"2023-12-31": 1000, "2022-12-31": 0},
+		"NetIncome":    {"2022-12-31": 50},
+	})
+
+	metrics := Compute(tbl)
+	if _, ok := metric(metrics, NetMargin).Values["2023-12-31"]; ok {
+		t.Error("net margin should be omitted when net income is missing")
+	}
+	if _, ok := metric(metrics, NetMargin).Values["2022-12-31"]; ok {
+		t.Error("net margin should be omitted when revenue is zero")
+	}
+}
+
+func TestComputeGrowth_Annual(t *testing.T) {
+	tbl := table(map[string]map[string]float64{
+		// Fiscal year ends drift by a few days
+		"TotalRevenue": {"2023-09-30": 121, "2022-09-24": 110, "2021-09-25": 100},
+		"NetIncome":    {"2023-09-30": -5, "2022-09-24": -10},
+	})
+
+	growth := ComputeGrowth(tbl)
+	var revenue, netIncome Growth
+	for _, g := range growth {
+		switch g.Item {
+		case "TotalRevenue":
+			revenue = g
+		case "NetIncome":
+			netIncome = g
+		}
+	}
+
+	if !near(revenue.YoY["2023-09-30"], 0.1) {
+		t.Errorf("revenue YoY 2023 = %v, want 0.1", revenue.YoY["2023-09-30"])
+	}
+	if !near(revenue.YoY["2022-09-24"], 0.1) {
+		t.Errorf("revenue YoY 2022 = %v, want 0.1", revenue.YoY["2022-09-24"])
+	}
+	if _, ok := revenue.YoY["2021-09-25"]; ok {
+		t.Error("oldest period should have no YoY")
+	}
+	if len(revenue.QoQ) != 0 {
+		t.Errorf("annual data should have no QoQ, got %v", revenue.QoQ)
+	}
+	if !revenue.HasCAGR || math.Abs(revenue.CAGR-0.1) > 0.001 {
+		t.Errorf("revenue CAGR = %v (has %v), want ~0.1", revenue.CAGR, revenue.HasCAGR)
+	}
+
+	if !near(netIncome.YoY["2023-09-30"], 0.5) {
+		t.Errorf("net income YoY from negative base = %v, want 0.5", netIncome.YoY["2023-09-30"])
+	}
+	if netIncome.HasCAGR {
+		t.Error("CAGR should be unavailable for negative values")
+	}
+}
+
+func TestComputeGrowth_Quarterly(t *testing.T) {
+	tbl := table(map[string]map[string]float64{
+		"TotalRevenue": {
+			"2024-03-31": 130,
+			"2023-12-31": 120,
+			"2023-09-30": 110,
+			"2023-06-30": 105,
+			"2023-03-31": 100,
+		},
+	})
+
+	g := ComputeGrowth(tbl)[0]
+	if !near(g.QoQ["2024-03-31"], 130.0/120-1) {
+		t.Errorf("QoQ = %v, want %v", g.QoQ["2024-03-31"], 130.0/120-1)
+	}
+	if !near(g.YoY["2024-03-31"], 0.3) {
+		t.Errorf("YoY = %v, want 0.3", g.YoY["2024-03-31"])
+	}
+	if _, ok := g.YoY["2023-12-31"]; ok {
+		t.Error("no YoY expected without a year-earlier quarter")
+	}
+}
@@ -0,0 +1,153 @@
+// Package refresh caches rendered resources and keeps recently read ones
+// current: when a watched entry expires it is loaded again in the background
+// and, if its content changed, a callback reports the update.
+//
+// An entry is watched for WatchWindow after each read, and for as long as it
+// is pinned, which callers do while a client is subscribed to it.
+package refresh
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+)
+
+// WatchWindow is how long after its last read an entry keeps refreshing.
+// Entries unread for longer are evicted.
+const WatchWindow = 30 * time.Minute
+
+// Loader renders a resource.
+type Loader func() (string, error)
+
+type entry struct {
+	load     Loader
+	ttl      time.Duration
+	text     string
+	fetched  time.Time
+	lastRead time.Time
+	loading  bool // a background refresh is in flight
+}
+
+// Cache holds rendered resources by URI.
+type Cache struct {
+	onChange func(uri string)
+	now      func() time.Time
+
+	mu      sync.Mutex
+	entries map[string]*entry
+	pinned  map[string]bool
+}
+
+// New creates a Cache. onChange, if not nil, is called with the URI of each
+// entry whose content changes on refresh.
+func New(onChange func(uri string)) *Cache {
+	return &Cache{onChange: onChange, now: time.Now, entries: make(map[string]*entry), pinned: make(map[string]bool)}
+}
+
+// Pin keeps uri watched, once it has been read, until Unpin.
+func (c *Cache) Pin(uri string) {
+	c.mu.Lock()
+	c.pinned[uri] = true
+	c.mu.Unlock()
+}
+
+// Unpin lets uri be evicted WatchWindow after its last read again.
+func (c *Cache) Unpin(uri string) {
+	c.mu.Lock()
+	delete(c.pinned, uri)
+	c.mu.Unlock()
+}
+
+// Get returns the content of uri, loading it when absent or older than ttl,
+// and marks it watched.
+func (c *Cache) Get(uri string, ttl time.Duration, load Loader) (string, error) {
+	now := c.now()
+	c.mu.Lock()
+	if e, ok := c.entries[uri]; ok && now.Sub(e.fetched) < e.ttl {
+		e.lastRead = now
+		text := e.text
+		c.mu.Unlock()
+		return text, nil
+	}
+	c.mu.Unlock()
+
+	text, err := load()
+	if err != nil {
+		return "", err
+	}
+
+	c.mu.Lock()
+	c.entries[uri] = &entry{load: load, ttl: ttl, text: text, fetched: now, lastRead: now}
+	c.mu.Unlock()
+	return text, nil
+}
+
+// Watched returns the URIs currently being kept fresh, in order.
+func (c *Cache) Watched() []string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	uris := make([]string, 0, len(c.entries))
+	for uri := range c.entries {
+		uris = append(uris, uri)
+	}
+	sort.Strings(uris)
+	return uris
+}
+
+// Refresh evicts unpinned entries unread for WatchWindow and reloads expired
+// ones, calling onChange for each whose content changed. A failed load keeps
+// the previous content and is retried on the next pass.
+func (c *Cache) Refresh() {
+	now := c.now()
+	type job struct {
+		uri  string
+		load Loader
+	}
+	var due []job
+	c.mu.Lock()
+	for uri, e := range c.entries {
+		switch {
+		case !c.pinned[uri] && now.Sub(e.lastRead) >= WatchWindow:
+			delete(c.entries, uri)
+		case !e.loading && now.Sub(e.fetched) >= e.ttl:
+			e.loading = true
+			due = append(due, job{uri, e.load})
+		}
+	}
+	c.mu.Unlock()
+
+	for _, j := range due {
+		text, err := j.load()
+
+		c.mu.Lock()
+		e, ok := c.entries[j.uri]
+		changed := false
+		if ok {
+			e.loading = false
+			if err == nil {
+				changed = text != e.text
+				e.text, e.fetched = text, now
+			}
+		}
+		c.mu.Unlock()
+
+		if changed && c.onChange != nil {
+			c.onChange(j.uri)
+		}
+	}
+}
+
+// Run calls Refresh every interval until ctx is cancelled.
+func (c *Cache) Run(ctx context.Context, interval time.Duration) {
+	t := time.NewTicker(interval)
+	defer t.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-t.C:
+			c.Refresh()
+		}
+	}
+}
@@ -0,0 +1,154 @@
+package refresh
+
+import (
+	"errors"
+	"slices"
+	"testing"
+	"time"
+)
+
+type clock struct{ t time.Time }
+
+func (c *clock) now() time.Time { return c.t }
+
+func newTestCache(changed *[]string) (*Cache, *clock) {
+	clk := &clock{t: time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)}
+	c := New(func(uri string) { *changed = append(*changed, uri) })
+	c.now = clk.now
+	return c, clk
+}
+
+func TestGet_CachesWithinTTL(t *testing.T) {
+	var changed []string
+	c, clk := newTestCache(&changed)
+	calls := 0
+	load := func() (string, error) {
+		calls++
+		return "v", nil
+	}
+
+	for range 2 {
+		if text, err := c.Get("yahoo://quote/AAPL", time.Minute, load); err != nil || text != "v" {
+			t.Fatalf("Get() = %q, %v", text, err)
+		}
+	}
+	if calls != 1 {
+		t.Errorf("calls = %d, want 1", calls)
+	}
+
+	clk.t = clk.t.Add(time.Minute)
+	c.Get("yahoo://quote/AAPL", time.Minute, load)
+	if calls != 2 {
+		t.Errorf("calls = %d, want 2 after expiry", calls)
+	}
+}
+
+func TestGet_Error(t *testing.T) {
+	c := New(nil)
+	if _, err := c.Get("yahoo://quote/NOPE", time.Minute, func() (string, error) { return "", errors.New("not found") }); err == nil {
+		t.Fatal("expected error")
+	}
+	if len(c.Watched()) != 0 {
+		t.Errorf("Watched() = %v, want failed loads not cached", c.Watched())
+	}
+}
+
+func TestRefresh_NotifiesOnChange(t *testing.T) {
+	var changed []string
+	c, clk := newTestCache(&changed)
+	price := "189.50"
+	quote := func() (string, error) { return price, nil }
+	profile := func() (string, error) { return "Apple Inc.", nil }
+	c.Get("yahoo://quote/AAPL", time.Minute, quote)
+	c.Get("yahoo://profile/AAPL", 24*time.Hour, profile)
+
+	// Nothing has expired yet.
+	c.Refresh()
+	if len(changed) != 0 {
+		t.Fatalf("changed = %v, want none", changed)
+	}
+
+	// The quote expires and its content changed; the profile is still fresh.
+	clk.t = clk.t.Add(time.Minute)
+	price = "190.10"
+	c.Refresh()
+	if !slices.Equal(changed, []string{"yahoo://quote/AAPL"}) {
+		t.Fatalf("changed = %v, want the quote", changed)
+	}
+	if text, _ := c.Get("yahoo://quote/AAPL", time.Minute, quote); text != "190.10" {
+		t.Errorf("Get() = %q, want refreshed content", text)
+	}
+
+	// Expired again but unchanged: no notification.
+	clk.t = clk.t.Add(time.Minute)
+	c.Refresh()
+	if len(changed) != 1 {
+		t.Errorf("changed = %v, want no notification for unchanged content", changed)
+	}
+}
+
+func TestRefresh_KeepsContentOnError(t *testing.T) {
+	var changed []string
+	c, clk := newTestCache(&changed)
+	fail := false
+	load := func() (string, error) {
+		if fail {
+			return "", errors.New("timeout")
+		}
+		return "ok", nil
+	}
+	c.Get("yahoo://market/US", time.Minute, load)
+
+	fail = true
+	clk.t = clk.t.Add(time.Minute)
+	c.Refresh()
+	if len(changed) != 0 {
+		t.Errorf("changed = %v, want none after a failed refresh", changed)
+	}
+	c.mu.Lock()
+	text := c.entries["yahoo://market/US"].text
+	c.mu.Unlock()
+	if text != "ok" {
+		t.Errorf("text = %q, want previous content kept", text)
+	}
+}
+
+func TestRefresh_EvictsUnwatched(t *testing.T) {
+	var changed []string
+	c, clk := newTestCache(&changed)
+	load := func() (string, error) { return clk.t.String(), nil }
+	c.Get("yahoo://sector/technology", time.Hour, load)
+	c.Get("yahoo://quote/MSFT", time.Minute, load)
+
+	clk.t = clk.t.Add(WatchWindow - time.Second)
+	c.Get("yahoo://quote/MSFT", time.Minute, load) // read again: stays watched
+	clk.t = clk.t.Add(time.Second)
+	c.Refresh()
+
+	if got := c.Watched(); !slices.Equal(got, []string{"yahoo://quote/MSFT"}) {
+		t.Errorf("Watched() = %v, want only the recently read quote", got)
+	}
+}
+
+func TestRefresh_KeepsPinned(t *testing.T) {
+	var changed []string
+	c, clk := newTestCache(&changed)
+	load := func() (string, error) { return clk.t.String(), nil }
+	c.Get("yahoo://quote/AAPL", time.Minute, load)
+	c.Pin("yahoo://quote/AAPL")
+
+	clk.t = clk.t.Add(WatchWindow)
+	c.Refresh()
+	if got := c.Watched(); !slices.Equal(got, []string{"yahoo://quote/AAPL"}) {
+		t.Errorf("Watched() = %v, want the pinned quote", got)
+	}
+	if !slices.Equal(changed, []string{"yahoo://quote/AAPL"}) {
+		t.Errorf("changed = %v, want the pinned quote refreshed", changed)
+	}
+
+	c.Unpin("yahoo://quote/AAPL")
+	c.Refresh()
+	if got := c.Watched(); len(got) != 0 {
+		t.Errorf("Watched() = %v, want unpinned quote evicted", got)
+	}
+}
@@ -0,0 +1,82 @@
+package scoring
+
+import (
+	"fmt"
+
+	"github.com/emmanuelay/yahoo-finance-mcp/yahoo"
+)
+
+// Altman Z-score zone thresholds for public companies.
+const (
+	AltmanSafe     = 2.99
+	AltmanDistress = 1.81
+)
+
+// AltmanZ computes the original Altman Z-score for public companies:
+//
+//	Z = 1.2 A + 1.4 B + 3.3 C + 0.6 D + 1.0 E
+//
+// using the latest fiscal year and marketCap as the market value of equity.
+// marketCap must be in the statements' reporting currency.
+func AltmanZ(t *yahoo.StatementTable, marketCap float64) (Score, error) {
+	if len(t.Dates) == 0 {
+		return Score{}, fmt.Errorf("no financial data")
+	}
+	p := periods{t: t, cur: t.Dates[0]}
+	if len(t.Dates) > 1 {
+		p.prior = t.Dates[1]
+	}
+	s := Score{Name: "Altman Z-Score", Date: p.cur}
+
+	assets, okAssets := p.get(p.cur, "TotalAssets")
+	term := func(name string, weight, num float64, okNum bool, numLabel, denLabel string, den float64, okDen bool) {
+		v, ok := div(num, den)
+		ok = ok && okNum && okDen
+		c := Component{
+			Name:   name,
+			Value:  v,
+			Detail: fmt.Sprintf("%s %.0f / %s %.0f (weight %.1f)", numLabel, num, denLabel, den, weight),
+			OK:     ok,
+		}
+		if ok {
+			c.Points = weight * v
+			s.Value += c.Points
+		}
+		s.Components = append(s.Components, c)
+	}
+
+	wc, okWC := p.workingCapital(p.cur)
+	term("A: working capital / assets", 1.2, wc, okWC, "working capital", "assets", assets, okAssets)
+
+	re, okRE := p.get(p.cur, "RetainedEarnings")
+	term("B: retained earnings / assets", 1.4, re, okRE, "retained earnings", "assets", assets, okAssets)
+
+	ebit, okEBIT := p.get(p.cur, "EBIT", "OperatingIncome")
+	term("C: EBIT / assets", 3.3, ebit, okEBIT, "EBIT", "assets", assets, okAssets)
+
+	liabilities, okLiab := p.get(p.cur, "TotalLiabilitiesNetMinorityInterest")
+	term("D: market cap / liabilities", 0.6, marketCap, marketCap > 0, "market cap", "liabilities", liabilities, okLiab)
+
+	revenue, okRev := p.get(p.cur, "TotalRevenue")
+	term("E: revenue / assets", 1.0, revenue, okRev, "revenue", "assets", assets, okAssets)
+
+	s = finish(s)
+	switch {
+	case !s.Complete:
+		s.Interpretation = "insufficient data"
+	case s.Value > AltmanSafe:
+		s.Interpretation = "safe zone"
+	case s.Value >= AltmanDistress:
+		s.Interpretation = "grey zone"
+	default:
+		s.Interpretation = "distress zone"
+	}
+	return s, nil
+}
+
+func (p periods) workingCapital(date string) (float64, bool) {
+	if v, ok := p.need(date, "CurrentAssets", "CurrentLiabilities"); ok {
+		return v[0] - v[1], true
+	}
+	return 0, false
+}
@@ -0,0 +1,144 @@
+package scoring
+
+import (
+	"fmt"
+
+	"github.com/emmanuelay/yahoo-finance-mcp/yahoo"
+)
+
+// BeneishThreshold is the M-score above which earnings manipulation is
+// considered likely in the eight-variable model.
+const BeneishThreshold = -1.78
+
+// Beneish computes the eight-variable Beneish M-score comparing the latest
+// fiscal year with the prior one:
+//
+//	M = -4.84 + 0.920 DSRI + 0.528 GMI + 0.404 AQI + 0.892 SGI
+//	    + 0.115 DEPI - 0.172 SGAI + 4.679 TATA - 0.327 LVGI
+func Beneish(t *yahoo.StatementTable) (Score, error) {
+	p, err := newPeriods(t)
+	if err != nil {
+		return Score{}, err
+	}
+	s := Score{Name: "Beneish M-Score", Date: p.cur, PriorDate: p.prior, Value: -4.84}
+
+	add := func(name string, weight, value float64, ok bool, detail string) {
+		c := Component{Name: name, Value: value, Detail: detail, OK: ok}
+		if ok {
+			c.Points = weight * value
+			s.Value += c.Points
+		}
+		s.Components = append(s.Components, c)
+	}
+
+	// DSRI: days sales in receivables index
+	recCur, okRC := p.receivablesToSales(p.cur)
+	recPrior, okRP := p.receivablesToSales(p.prior)
+	dsri, ok := div(recCur, recPrior)
+	add("DSRI (receivables/sales index)", 0.920, dsri, ok && okRC && okRP,
+		fmt.Sprintf("%.4f vs %.4f", recCur, recPrior))
+
+	// GMI: gross margin index (prior over current)
+	gmCur, okGC := p.grossMargin(p.cur)
+	gmPrior, okGP := p.grossMargin(p.prior)
+	gmi, ok := div(gmPrior, gmCur)
+	add("GMI (gross margin index)", 0.528, gmi, ok && okGC && okGP,
+		fmt.Sprintf("prior %.2f%% / current %.2f%%", gmPrior*100, gmCur*100))
+
+	// AQI: asset quality index
+	aqCur, okAC := p.assetQuality(p.cur)
+	aqPrior, okAP := p.assetQuality(p.prior)
+	aqi, ok := div(aqCur, aqPrior)
+	add("AQI (asset quality index)", 0.404, aqi, ok && okAC && okAP,
+		fmt.Sprintf("soft assets %.2f%% vs %.2f%% of total", aqCur*100, aqPrior*100))
+
+	// SGI: sales growth index
+	revCur, okSC := p.get(p.cur, "TotalRevenue")
+	revPrior, okSP := p.get(p.prior, "TotalRevenue")
+	sgi, ok := div(revCur, revPrior)
+	add("SGI (sales growth index)", 0.892, sgi, ok && okSC && okSP,
+		fmt.Sprintf("revenue %.0f / %.0f", revCur, revPrior))
+
+	// DEPI: depreciation index (prior rate over current rate)
+	depCur, okDC := p.depreciationRate(p.cur)
+	depPrior, okDP := p.depreciationRate(p.prior)
+	depi, ok := div(depPrior, depCur)
+	add("DEPI (depreciation index)", 0.115, depi, ok && okDC && okDP,
+		fmt.Sprintf("depreciation rate %.2f%% vs %.2f%%", depCur*100, depPrior*100))
+
+	// SGAI: SG&A expense index
+	sgaCur, okGAC := p.ratioOf(p.cur, "SellingGeneralAndAdministration", "TotalRevenue")
+	sgaPrior, okGAP := p.ratioOf(p.prior, "SellingGeneralAndAdministration", "TotalRevenue")
+	sgai, ok := div(sgaCur, sgaPrior)
+	add("SGAI (SG&A index)", -0.172, sgai, ok && okGAC && okGAP,
+		fmt.Sprintf("SG&A/sales %.2f%% vs %.2f%%", sgaCur*100, sgaPrior*100))
+
+	// TATA: total accruals to total assets
+	v, okT := p.need(p.cur, "NetIncomeContinuousOperations|NetIncome", "OperatingCashFlow", "TotalAssets")
+	var tata float64
+	if okT {
+		tata, okT = div(v[0]-v[1], v[2])
+	}
+	detail := "missing inputs"
+	if okT {
+		detail = fmt.Sprintf("(income %.0f - CFO %.0f) / assets %.0f", v[0], v[1], v[2])
+	}
+	add("TATA (accruals / assets)", 4.679, tata, okT, detail)
+
+	// LVGI: leverage index
+	lvCur, okLC := p.totalLeverage(p.cur)
+	lvPrior, okLP := p.totalLeverage(p.prior)
+	lvgi, ok := div(lvCur, lvPrior)
+	add("LVGI (leverage index)", -0.327, lvgi, ok && okLC && okLP,
+		fmt.Sprintf("%.4f vs %.4f", lvCur, lvPrior))
+
+	s = finish(s)
+	switch {
+	case !s.Complete:
+		s.Interpretation = "insufficient data"
+	case s.Value > BeneishThreshold:
+		s.Interpretation = fmt.Sprintf("likely manipulator (above %.2f)", BeneishThreshold)
+	default:
+		s.Interpretation = fmt.Sprintf("unlikely manipulator (below %.2f)", BeneishThreshold)
+	}
+	return s, nil
+}
+
+func (p periods) ratioOf(date, num, den string) (float64, bool) {
+	v, ok := p.need(date, num, den)
+	if !ok {
+		return 0, false
+	}
+	return div(v[0], v[1])
+}
+
+func (p periods) receivablesToSales(date string) (float64, bool) {
+	return p.ratioOf(date, "AccountsReceivable|Receivables", "TotalRevenue")
+}
+
+// assetQuality is the share of total assets other than current assets and PP&E.
+func (p periods) assetQuality(date string) (float64, bool) {
+	v, ok := p.need(date, "CurrentAssets", "NetPPE", "TotalAssets")
+	if !ok {
+		return 0, false
+	}
+	hard, ok := div(v[0]+v[1], v[2])
+	return 1 - hard, ok
+}
+
+func (p periods) depreciationRate(date string) (float64, bool) {
+	v, ok := p.need(date, "DepreciationAndAmortization|ReconciledDepreciation", "NetPPE")
+	if !ok {
+		return 0, false
+	}
+	return div(v[0], v[0]+v[1])
+}
+
+func (p periods) totalLeverage(date string) (float64, bool) {
+	v, ok := p.need(date, "CurrentLiabilities", "TotalAssets")
+	if !ok {
+		return 0, false
+	}
+	debt, _ := p.get(date, "LongTermDebt")
+	return div(v[0]+debt, v[1])
+}
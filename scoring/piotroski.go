@@ -0,0 +1,154 @@
+package scoring
+
+import (
+	"fmt"
+
+	"github.com/emmanuelay/yahoo-finance-mcp/yahoo"
+)
+
+// Piotroski computes the nine-point Piotroski F-score. Returns are measured
+// against beginning-of-year total assets when the earlier year is available,
+// otherwise against year-end assets. Scores of 8-9 indicate strong
+// fundamentals, 0-2 weak ones.
+func Piotroski(t *yahoo.StatementTable) (Score, error) {
+	p, err := newPeriods(t)
+	if err != nil {
+		return Score{}, err
+	}
+	s := Score{Name: "Piotroski F-Score", Date: p.cur, PriorDate: p.prior}
+
+	add := func(name string, value float64, pass bool, detail string, ok bool) {
+		c := Component{Name: name, Value: value, Detail: detail, OK: ok}
+		if ok && pass {
+			c.Points = 1
+		}
+		s.Components = append(s.Components, c)
+	}
+
+	roaCur, okCur := p.roa(p.cur, p.prior)
+	roaPrior, okPrior := p.roa(p.prior, p.before)
+
+	// Profitability
+	add("ROA > 0", roaCur, roaCur > 0, fmt.Sprintf("net income / assets = %.2f%%", roaCur*100), okCur)
+
+	cfo, okCFO := p.get(p.cur, "OperatingCashFlow")
+	add("Operating cash flow > 0", cfo, cfo > 0, fmt.Sprintf("CFO = %.0f", cfo), okCFO)
+
+	add("ROA improved", roaCur-roaPrior, roaCur > roaPrior,
+		fmt.Sprintf("%.2f%% vs %.2f%%", roaCur*100, roaPrior*100), okCur && okPrior)
+
+	assets, okAssets := p.beginAssets(p.cur, p.prior)
+	cfoROA, okCFOROA := div(cfo, assets)
+	add("Cash flow ROA > ROA (accruals)", cfoROA-roaCur, cfoROA > roaCur,
+		fmt.Sprintf("CFO / assets = %.2f%% vs ROA %.2f%%", cfoROA*100, roaCur*100), okCFO && okAssets && okCFOROA && okCur)
+
+	// Leverage, liquidity and source of funds
+	levCur, okLevCur := p.leverage(p.cur)
+	levPrior, okLevPrior := p.leverage(p.prior)
+	add("Leverage decreased", levCur-levPrior, levCur < levPrior || (levCur == 0 && levPrior == 0),
+		fmt.Sprintf("long-term debt / assets = %.3f vs %.3f", levCur, levPrior), okLevCur && okLevPrior)
+
+	crCur, okCRCur := p.currentRatio(p.cur)
+	crPrior, okCRPrior := p.currentRatio(p.prior)
+	add("Current ratio improved", crCur-crPrior, crCur > crPrior,
+		fmt.Sprintf("%.2f vs %.2f", crCur, crPrior), okCRCur && okCRPrior)
+
+	sharesCur, okSharesCur := p.get(p.cur, "OrdinarySharesNumber", "DilutedAverageShares")
+	sharesPrior, okSharesPrior := p.get(p.prior, "OrdinarySharesNumber", "DilutedAverageShares")
+	add("No new shares issued", sharesCur-sharesPrior, sharesCur <= sharesPrior,
+		fmt.Sprintf("shares %.0f vs %.0f", sharesCur, sharesPrior), okSharesCur && okSharesPrior)
+
+	// Operating efficiency
+	gmCur, okGMCur := p.grossMargin(p.cur)
+	gmPrior, okGMPrior := p.grossMargin(p.prior)
+	add("Gross margin improved", gmCur-gmPrior, gmCur > gmPrior,
+		fmt.Sprintf("%.2f%% vs %.2f%%", gmCur*100, gmPrior*100), okGMCur && okGMPrior)
+
+	atCur, okATCur := p.assetTurnover(p.cur, p.prior)
+	atPrior, okATPrior := p.assetTurnover(p.prior, p.before)
+	add("Asset turnover improved", atCur-atPrior, atCur > atPrior,
+		fmt.Sprintf("revenue / assets = %.3f vs %.3f", atCur, atPrior), okATCur && okATPrior)
+
+	for _, c := range s.Components {
+		s.Value += c.Points
+	}
+	s = finish(s)
+
+	switch {
+	case s.Value >= 8:
+		s.Interpretation = "strong"
+	case s.Value <= 2:
+		s.Interpretation = "weak"
+	default:
+		s.Interpretation = "average"
+	}
+	if !s.Complete {
+		s.Interpretation += fmt.Sprintf(" (only %d of 9 criteria could be evaluated)", 9-len(s.Missing()))
+	}
+	return s, nil
+}
+
+// beginAssets returns total assets at the start of the year ending on date,
+// falling back to year-end assets when the earlier balance is unavailable.
+func (p periods) beginAssets(date, priorDate string) (float64, bool) {
+	if v, ok := p.get(priorDate, "TotalAssets"); ok {
+		return v, true
+	}
+	return p.get(date, "TotalAssets")
+}
+
+func (p periods) roa(date, priorDate string) (float64, bool) {
+	ni, ok := p.get(date, "NetIncome")
+	if !ok {
+		return 0, false
+	}
+	assets, ok := p.beginAssets(date, priorDate)
+	if !ok {
+		return 0, false
+	}
+	return div(ni, assets)
+}
+
+func (p periods) leverage(date string) (float64, bool) {
+	assets, ok := p.get(date, "TotalAssets")
+	if !ok {
+		return 0, false
+	}
+	debt, _ := p.get(date, "LongTermDebt") // no long-term debt line means none outstanding
+	return div(debt, assets)
+}
+
+func (p periods) currentRatio(date string) (float64, bool) {
+	v, ok := p.need(date, "CurrentAssets", "CurrentLiabilities")
+	if !ok {
+		return 0, false
+	}
+	return div(v[0], v[1])
+}
+
+func (p periods) grossMargin(date string) (float64, bool) {
+	revenue, ok := p.get(date, "TotalRevenue")
+	if !ok {
+		return 0, false
+	}
+	if gp, ok := p.get(date, "GrossProfit"); ok {
+		return div(gp, revenue)
+	}
+	cost, ok := p.get(date, "CostOfRevenue")
+	if !ok {
+		return 0, false
+	}
+	return div(revenue-cost, revenue)
+}
+
+func (p periods) assetTurnover(date, priorDate string) (float64, bool) {
+	revenue, ok := p.get(date, "TotalRevenue")
+	if !ok {
+		return 0, false
+	}
+	assets, ok := p.beginAssets(date, priorDate)
+	if !ok {
+		return 0, false
+	}
+	return div(revenue, assets)
+}
@@ -0,0 +1,129 @@
+// Package scoring computes fundamental quality scores (Piotroski F-score,
+// Altman Z-score and Beneish M-score) from annual financial statements.
+//
+// Each score reports its individual components with the inputs used, so a
+// result can be audited line by line. Scores compare the two most recent
+// fiscal years in the statement table.
+package scoring
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/emmanuelay/yahoo-finance-mcp/yahoo"
+)
+
+// RequiredItems lists the annual line items the scores read.
+var RequiredItems = []string{
+	// Income statement
+	"TotalRevenue", "CostOfRevenue", "GrossProfit", "SellingGeneralAndAdministration",
+	"EBIT", "OperatingIncome", "NetIncome", "NetIncomeContinuousOperations",
+	"ReconciledDepreciation", "DilutedAverageShares",
+	// Balance sheet
+	"TotalAssets", "CurrentAssets", "CurrentLiabilities", "AccountsReceivable", "Receivables",
+	"NetPPE", "LongTermDebt", "RetainedEarnings", "TotalLiabilitiesNetMinorityInterest",
+	"OrdinarySharesNumber",
+	// Cash flow
+	"OperatingCashFlow", "DepreciationAndAmortization",
+}
+
+// Component is one term of a score.
+type Component struct {
+	Name string
+	// Value is the measured ratio or index for this term.
+	Value float64
+	// Points is the term's contribution to the score: 0 or 1 for the
+	// F-score, the weighted term for the Z- and M-scores.
+	Points float64
+	// Detail describes the inputs behind Value.
+	Detail string
+	// OK is false when an input was missing; Points is then zero.
+	OK bool
+}
+
+// Score is a computed model with its components.
+type Score struct {
+	Name       string
+	Value      float64
+	Components []Component
+	// Date and PriorDate are the fiscal year ends compared.
+	Date, PriorDate string
+	// Complete is false when any component lacked inputs.
+	Complete       bool
+	Interpretation string
+}
+
+// Missing lists the names of components that lacked inputs.
+func (s Score) Missing() []string {
+	var out []string
+	for _, c := range s.Components {
+		if !c.OK {
+			out = append(out, c.Name)
+		}
+	}
+	return out
+}
+
+// periods gives access to the two most recent fiscal years of a table.
+type periods struct {
+	t          *yahoo.StatementTable
+	cur, prior string
+	// before is the year preceding prior, used for beginning-of-year assets
+	before string
+}
+
+func newPeriods(t *yahoo.StatementTable) (periods, error) {
+	if len(t.Dates) < 2 {
+		return periods{}, fmt.Errorf("at least two fiscal years are required, got %d", len(t.Dates))
+	}
+	p := periods{t: t, cur: t.Dates[0], prior: t.Dates[1]}
+	if len(t.Dates) > 2 {
+		p.before = t.Dates[2]
+	}
+	return p, nil
+}
+
+// get returns the first reported item of the alternatives for a date.
+func (p periods) get(date string, items ...string) (float64, bool) {
+	if date == "" {
+		return 0, false
+	}
+	for _, item := range items {
+		if v, ok := p.t.Value(item, date); ok {
+			return v, true
+		}
+	}
+	return 0, false
+}
+
+// need fetches several values for a date, reporting whether all were present.
+// An item may list alternatives separated by "|", tried in order.
+func (p periods) need(date string, items ...string) ([]float64, bool) {
+	out := make([]float64, len(items))
+	for i, item := range items {
+		v, ok := p.get(date, strings.Split(item, "|")...)
+		if !ok {
+			return nil, false
+		}
+		out[i] = v
+	}
+	return out, true
+}
+
+func div(num, den float64) (float64, bool) {
+	if den == 0 || math.IsNaN(num) || math.IsNaN(den) {
+		return 0, false
+	}
+	return num / den, true
+}
+
+func finish(s Score) Score {
+	s.Complete = true
+	for _, c := range s.Components {
+		if !c.OK {
+			s.Complete = false
+		}
+	}
+	return s
+}
@@ -0,0 +1,171 @@
+package scoring
+
+import (
+	"math"
+	"testing"
+
+	"github.com/emmanuelay/yahoo-finance-mcp/yahoo"
+)
+
+const (
+	curDate   = "2023-12-31"
+	priorDate = "2022-12-31"
+)
+
+// testTable builds an annual statement table from per-year line items.
+func testTable(years map[string]map[string]float64) *yahoo.StatementTable {
+	byItem := map[string]*yahoo.FinancialResult{}
+	var order []string
+	for date, items := range years {
+		for item, v := range items {
+			r, ok := byItem[item]
+			if !ok {
+				r = &yahoo.FinancialResult{Type: "annual" + item}
+				byItem[item] = r
+				order = append(order, item)
+			}
+			r.Items = append(r.Items, yahoo.FinancialItem{Date: date, ReportedValue: v, CurrencyCode: "USD"})
+		}
+	}
+	var results []yahoo.FinancialResult
+	for _, item := range order {
+		results = append(results, *byItem[item])
+	}
+	return yahoo.NewStatementTable(results)
+}
+
+func healthyCompany() map[string]map[string]float64 {
+	return map[string]map[string]float64{
+		curDate: {
+			"TotalRevenue": 1200, "CostOfRevenue": 700, "GrossProfit": 500,
+			"SellingGeneralAndAdministration": 150, "EBIT": 260,
+			"NetIncome": 180, "NetIncomeContinuousOperations": 180,
+			"TotalAssets": 2000, "CurrentAssets": 800, "CurrentLiabilities": 400,
+			"AccountsReceivable": 200, "NetPPE": 600, "LongTermDebt": 300,
+			"RetainedEarnings": 900, "TotalLiabilitiesNetMinorityInterest": 1000,
+			"OrdinarySharesNumber": 100, "OperatingCashFlow": 250, "DepreciationAndAmortization": 60,
+		},
+		priorDate: {
+			"TotalRevenue": 1000, "CostOfRevenue": 600, "GrossProfit": 400,
+			"SellingGeneralAndAdministration": 140, "EBIT": 200,
+			"NetIncome": 120, "NetIncomeContinuousOperations": 120,
+			"TotalAssets": 1800, "CurrentAssets": 700, "CurrentLiabilities": 400,
+			"AccountsReceivable": 150, "NetPPE": 550, "LongTermDebt": 350,
+			"RetainedEarnings": 750, "TotalLiabilitiesNetMinorityInterest": 950,
+			"OrdinarySharesNumber": 102, "OperatingCashFlow": 200, "DepreciationAndAmortization": 55,
+		},
+	}
+}
+
+func TestPiotroski_Healthy(t *testing.T) {
+	s, err := Piotroski(testTable(healthyCompany()))
+	if err != nil {
+		t.Fatalf("Piotroski() error: %v", err)
+	}
+	if s.Value != 9 {
+		for _, c := range s.Components {
+			t.Logf("%s: %v (%s)", c.Name, c.Points, c.Detail)
+		}
+		t.Errorf("F-score = %v, want 9", s.Value)
+	}
+	if !s.Complete {
+		t.Errorf("expected complete score, missing %v", s.Missing())
+	}
+	if s.Date != curDate || s.PriorDate != priorDate {
+		t.Errorf("dates = %s/%s, want %s/%s", s.Date, s.PriorDate, curDate, priorDate)
+	}
+	if s.Interpretation != "strong" {
+		t.Errorf("Interpretation = %q, want strong", s.Interpretation)
+	}
+}
+
+func TestPiotroski_Deteriorating(t *testing.T) {
+	years := healthyCompany()
+	// Swap the years so every improvement becomes a deterioration
+	years[curDate], years[priorDate] = years[priorDate], years[curDate]
+
+	s, err := Piotroski(testTable(years))
+	if err != nil {
+		t.Fatalf("Piotroski() error: %v", err)
+	}
+	// Still profitable with positive cash flow and accruals below cash earnings
+	if s.Value != 3 {
+		for _, c := range s.Components {
+			t.Logf("%s: %v (%s)", c.Name, c.Points, c.Detail)
+		}
+		t.Errorf("F-score = %v, want 3", s.Value)
+	}
+}
+
+func TestPiotroski_MissingInputs(t *testing.T) {
+	years := healthyCompany()
+	delete(years[curDate], "CurrentAssets")
+	delete(years[curDate], "CurrentLiabilities")
+
+	s, err := Piotroski(testTable(years))
+	if err != nil {
+		t.Fatalf("Piotroski() error: %v", err)
+	}
+	if s.Complete {
+		t.Error("expected incomplete score")
+	}
+	if len(s.Missing()) != 1 || s.Missing()[0] != "Current ratio improved" {
+		t.Errorf("Missing() = %v", s.Missing())
+	}
+	if s.Value != 8 {
+		t.Errorf("F-score = %v, want 8", s.Value)
+	}
+}
+
+func TestPiotroski_SingleYear(t *testing.T) {
+	years := healthyCompany()
+	delete(years, priorDate)
+	if _, err := Piotroski(testTable(years)); err == nil {
+		t.Fatal("expected error with a single fiscal year")
+	}
+}
+
+func TestAltmanZ(t *testing.T) {
+	s, err := AltmanZ(testTable(healthyCompany()), 3000)
+	if err != nil {
+		t.Fatalf("AltmanZ() error: %v", err)
+	}
+	if math.Abs(s.Value-3.699) > 1e-9 {
+		t.Errorf("Z = %v, want 3.699", s.Value)
+	}
+	if s.Interpretation != "safe zone" {
+		t.Errorf("Interpretation = %q, want safe zone", s.Interpretation)
+	}
+	if len(s.Components) != 5 {
+		t.Errorf("components = %d, want 5", len(s.Components))
+	}
+}
+
+func TestAltmanZ_MissingMarketCap(t *testing.T) {
+	s, err := AltmanZ(testTable(healthyCompany()), 0)
+	if err != nil {
+		t.Fatalf("AltmanZ() error: %v", err)
+	}
+	if s.Complete || s.Interpretation != "insufficient data" {
+		t.Errorf("expected insufficient data, got complete=%v %q", s.Complete, s.Interpretation)
+	}
+}
+
+func TestBeneish(t *testing.T) {
+	s, err := Beneish(testTable(healthyCompany()))
+	if err != nil {
+		t.Fatalf("Beneish() error: %v", err)
+	}
+	if !s.Complete {
+		t.Fatalf("expected complete score, missing %v", s.Missing())
+	}
+	if math.Abs(s.Value-(-2.3208596608946612)) > 1e-9 {
+		t.Errorf("M = %v, want -2.32086", s.Value)
+	}
+	if len(s.Components) != 8 {
+		t.Errorf("components = %d, want 8", len(s.Components))
+	}
+	if s.Value > BeneishThreshold {
+		t.Errorf("expected score below threshold, got %v", s.Value)
+	}
+}
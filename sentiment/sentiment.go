@@ -0,0 +1,277 @@
+// Package sentiment scores the tone of financial news with a word list in
+// the style of the Loughran–McDonald dictionary, which classifies words by
+// their meaning in financial text rather than in general English ("liability"
+// and "tax" are neutral; "restatement" and "impairment" are negative).
+//
+// Scoring runs locally: each positive or negative term found counts as a hit,
+// and tone is the damped share of positive over negative hits.
+package sentiment
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// Term polarities.
+const (
+	Positive = 1
+	Negative = -1
+)
+
+// Scoring parameters.
+const (
+	// TitleWeight multiplies hits in a headline, which states the story's
+	// angle more directly than its body.
+	TitleWeight = 2
+	// smoothing damps the tone of texts with few hits so a single word does
+	// not score ±1.
+	smoothing = 1
+	// NeutralBand is the tone either side of zero labelled neutral.
+	NeutralBand = 0.15
+	// SharpNegativeTone is the mean article tone at or below which coverage
+	// counts as sharply negative.
+	SharpNegativeTone = -0.3
+	// negationWindow is how many preceding words are searched for a negator.
+	negationWindow = 3
+)
+
+var positiveWords = words(`
+	achieve accelerate advance advantage approve approval attractive beat benefit best
+	boost breakthrough bullish buyback climb confident exceed excellent expand expansion
+	favorable gain great growth improve improvement innovative jump momentum
+	optimistic outpace outperform positive profit profitable progress rally rebound
+	record recover recovery resilient reward robust rise rose skyrocket soar solid
+	strength strengthen strong stronger success successful surge surpass upbeat
+	upgrade upside win winner`)
+
+var negativeWords = words(`
+	accuse adverse allegation alleged antitrust bankrupt bankruptcy bearish bleak breach
+	collapse concern crash crisis decline default deficit delay delist disappoint
+	dismal downgrade downside downturn drop fail failure fall fear fell fraud gloomy
+	guilty halt headwind hack impair impairment indict indictment investigation lawsuit
+	layoff lose loss lost miss negative outage penalty pessimistic plummet plunge probe
+	recall recession resign restate restatement sank scandal selloff shortfall shrink
+	sink slash slid slide slowdown slump sour struggle subpoena sue suspend tank
+	tumble turmoil underperform violation warn warning weak weaken weakness worse worst
+	writedown`)
+
+// negators flip a following positive word ("not profitable"). As in the
+// Loughran–McDonald method, negative words are not flipped: "no loss" is rare
+// in news and "not" before a negative word is usually not a negation of it.
+var negators = words(`not no never none neither nor without cannot`)
+
+func words(s string) map[string]bool {
+	m := make(map[string]bool)
+	for _, w := range strings.Fields(s) {
+		m[w] = true
+	}
+	return m
+}
+
+// Term is a lexicon word found in a text.
+type Term struct {
+	// Term is the dictionary form of the word, prefixed "not " when negated.
+	Term     string
+	Polarity int
+	Count    int
+}
+
+// Score is the tone of a text or of several texts added together.
+type Score struct {
+	// Positive and Negative are weighted hit counts.
+	Positive float64
+	Negative float64
+	// Terms lists the words that produced the hits, most frequent first.
+	Terms []Term
+}
+
+// Analyze scores text.
+func Analyze(text string) Score {
+	tokens := tokenize(text)
+	counts := make(map[Term]int)
+	var s Score
+	for i, w := range tokens {
+		base, polarity := lookup(w)
+		if polarity == 0 {
+			continue
+		}
+		term := base
+		if polarity == Positive && negated(tokens, i) {
+			polarity, term = Negative, "not "+base
+		}
+		if polarity == Positive {
+			s.Positive++
+		} else {
+			s.Negative++
+		}
+		counts[Term{Term: term, Polarity: polarity}]++
+	}
+	for t, n := range counts {
+		t.Count = n
+		s.Terms = append(s.Terms, t)
+	}
+	sortTerms(s.Terms)
+	return s
+}
+
+// ForArticle scores a news article, weighting its headline by TitleWeight.
+// body may be empty when only the headline is available.
+func ForArticle(title, body string) Score {
+	return Analyze(title).Scale(TitleWeight).Add(Analyze(body))
+}
+
+// Scale multiplies the hit counts by w, leaving term counts unchanged.
+func (s Score) Scale(w float64) Score {
+	s.Positive *= w
+	s.Negative *= w
+	return s
+}
+
+// Add returns the combined score of s and o.
+func (s Score) Add(o Score) Score {
+	return Score{
+		Positive: s.Positive + o.Positive,
+		Negative: s.Negative + o.Negative,
+		Terms:    mergeTerms(s.Terms, o.Terms),
+	}
+}
+
+// Hits is the weighted number of positive and negative terms.
+func (s Score) Hits() float64 {
+	return s.Positive + s.Negative
+}
+
+// Tone is in (-1, 1): negative when negative hits outnumber positive ones,
+// and closer to ±1 the more one-sided and numerous the hits are.
+func (s Score) Tone() float64 {
+	return (s.Positive - s.Negative) / (s.Positive + s.Negative + smoothing)
+}
+
+// Label names a tone: "positive", "negative" or "neutral".
+func Label(tone float64) string {
+	switch {
+	case tone >= NeutralBand:
+		return "positive"
+	case tone <= -NeutralBand:
+		return "negative"
+	}
+	return "neutral"
+}
+
+// Summary aggregates the scores of several articles.
+type Summary struct {
+	Articles int
+	// Positive, Negative and Neutral count articles by Label.
+	Positive int
+	Negative int
+	Neutral  int
+	// Tone is the mean article tone, so a long article weighs no more than
+	// a short one.
+	Tone  float64
+	Terms []Term
+}
+
+// Summarize aggregates article scores.
+func Summarize(scores []Score) Summary {
+	var sum Summary
+	for _, s := range scores {
+		tone := s.Tone()
+		switch Label(tone) {
+		case "positive":
+			sum.Positive++
+		case "negative":
+			sum.Negative++
+		default:
+			sum.Neutral++
+		}
+		sum.Tone += tone
+		sum.Terms = mergeTerms(sum.Terms, s.Terms)
+	}
+	sum.Articles = len(scores)
+	if sum.Articles > 0 {
+		sum.Tone /= float64(sum.Articles)
+	}
+	return sum
+}
+
+// SharplyNegative reports coverage whose mean tone is at or below
+// SharpNegativeTone with at least half of the articles negative.
+func (s Summary) SharplyNegative() bool {
+	return s.Articles > 0 && s.Tone <= SharpNegativeTone && s.Negative*2 >= s.Articles
+}
+
+func mergeTerms(a, b []Term) []Term {
+	if len(b) == 0 {
+		return a
+	}
+	counts := make(map[Term]int, len(a)+len(b))
+	for _, ts := range [][]Term{a, b} {
+		for _, t := range ts {
+			counts[Term{Term: t.Term, Polarity: t.Polarity}] += t.Count
+		}
+	}
+	out := make([]Term, 0, len(counts))
+	for t, n := range counts {
+		t.Count = n
+		out = append(out, t)
+	}
+	sortTerms(out)
+	return out
+}
+
+func sortTerms(terms []Term) {
+	sort.Slice(terms, func(i, j int) bool {
+		if terms[i].Count != terms[j].Count {
+			return terms[i].Count > terms[j].Count
+		}
+		return terms[i].Term < terms[j].Term
+	})
+}
+
+// tokenize lower-cases text and splits it into words, keeping apostrophes
+// within words so contractions such as "isn't" survive.
+func tokenize(text string) []string {
+	text = strings.ReplaceAll(strings.ToLower(text), "’", "'")
+	return strings.FieldsFunc(text, func(r rune) bool {
+		return !unicode.IsLetter(r) && r != '\''
+	})
+}
+
+// lookup returns the dictionary form and polarity of w, trying common
+// inflections: "plunges", "declined", "surging", "dropped".
+func lookup(w string) (string, int) {
+	w = strings.Trim(w, "'")
+	candidates := []string{w}
+	for _, suffix := range []string{"s", "es", "d", "ed", "ing"} {
+		if stem, ok := strings.CutSuffix(w, suffix); ok && len(stem) >= 3 {
+			candidates = append(candidates, stem)
+			if suffix == "ing" {
+				candidates = append(candidates, stem+"e")
+			}
+			if n := len(stem); (suffix == "ed" || suffix == "ing") && stem[n-1] == stem[n-2] {
+				candidates = append(candidates, stem[:n-1])
+			}
+		}
+	}
+	for _, c := range candidates {
+		if positiveWords[c] {
+			return c, Positive
+		}
+		if negativeWords[c] {
+			return c, Negative
+		}
+	}
+	return "", 0
+}
+
+// negated reports whether a negator precedes tokens[i] within
+// negationWindow words.
+func negated(tokens []string, i int) bool {
+	for j := max(0, i-negationWindow); j < i; j++ {
+		if negators[tokens[j]] || strings.HasSuffix(tokens[j], "n't") {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,113 @@
+package sentiment
+
+import (
+	"math"
+	"reflect"
+	"testing"
+)
+
+func near(a, b float64) bool {
+	return math.Abs(a-b) < 1e-9
+}
+
+func TestLookup(t *testing.T) {
+	tests := []struct {
+		in       string
+		base     string
+		polarity int
+	}{
+		{"plunges", "plunge", Negative},
+		{"declined", "decline", Negative},
+		{"surging", "surge", Positive},
+		{"dropped", "drop", Negative},
+		{"losses", "loss", Negative},
+		{"sued", "sue", Negative},
+		{"beats", "beat", Positive},
+		{"liability", "", 0},
+		{"tax", "", 0},
+	}
+	for _, tt := range tests {
+		base, polarity := lookup(tt.in)
+		if base != tt.base || polarity != tt.polarity {
+			t.Errorf("lookup(%q) = %q, %d; want %q, %d", tt.in, base, polarity, tt.base, tt.polarity)
+		}
+	}
+}
+
+func TestAnalyze(t *testing.T) {
+	s := Analyze("Shares plunged after the company missed estimates and warned of weak demand; margins rose.")
+	if s.Positive != 1 || s.Negative != 4 {
+		t.Errorf("hits = +%v -%v, want +1 -4", s.Positive, s.Negative)
+	}
+	if !near(s.Tone(), -3.0/6) {
+		t.Errorf("Tone() = %v, want -0.5", s.Tone())
+	}
+	want := []Term{
+		{Term: "miss", Polarity: Negative, Count: 1},
+		{Term: "plunge", Polarity: Negative, Count: 1},
+		{Term: "rose", Polarity: Positive, Count: 1},
+		{Term: "warn", Polarity: Negative, Count: 1},
+		{Term: "weak", Polarity: Negative, Count: 1},
+	}
+	if !reflect.DeepEqual(s.Terms, want) {
+		t.Errorf("Terms = %+v, want %+v", s.Terms, want)
+	}
+}
+
+func TestAnalyze_Negation(t *testing.T) {
+	s := Analyze("The unit isn’t profitable and saw no growth, but there was no loss.")
+	if s.Positive != 0 || s.Negative != 3 {
+		t.Errorf("hits = +%v -%v, want +0 -3", s.Positive, s.Negative)
+	}
+	want := []Term{
+		{Term: "loss", Polarity: Negative, Count: 1},
+		{Term: "not growth", Polarity: Negative, Count: 1},
+		{Term: "not profitable", Polarity: Negative, Count: 1},
+	}
+	if !reflect.DeepEqual(s.Terms, want) {
+		t.Errorf("Terms = %+v, want %+v", s.Terms, want)
+	}
+}
+
+func TestForArticle(t *testing.T) {
+	s := ForArticle("Apple beats estimates", "Revenue growth slowed as China sales declined.")
+	if s.Positive != 3 || s.Negative != 1 {
+		t.Errorf("hits = +%v -%v, want +3 (title weighted) -1", s.Positive, s.Negative)
+	}
+	if got := Label(s.Tone()); got != "positive" {
+		t.Errorf("Label = %s, want positive", got)
+	}
+	if got := Label(ForArticle("Apple to hold annual meeting", "").Tone()); got != "neutral" {
+		t.Errorf("Label = %s, want neutral", got)
+	}
+}
+
+func TestSummarize(t *testing.T) {
+	scores := []Score{
+		ForArticle("Lender plunges as fraud probe widens", ""),
+		ForArticle("Regulators sue bank over losses", ""),
+		ForArticle("Bank schedules earnings call", ""),
+		ForArticle("Bank names new chair", ""),
+	}
+	sum := Summarize(scores)
+	if sum.Articles != 4 || sum.Negative != 2 || sum.Neutral != 2 || sum.Positive != 0 {
+		t.Errorf("counts = %d articles, +%d -%d =%d", sum.Articles, sum.Positive, sum.Negative, sum.Neutral)
+	}
+	wantTone := (-6.0/7 - 4.0/5) / 4
+	if !near(sum.Tone, wantTone) {
+		t.Errorf("Tone = %v, want %v", sum.Tone, wantTone)
+	}
+	if !sum.SharplyNegative() {
+		t.Error("SharplyNegative() = false, want true")
+	}
+	if len(sum.Terms) != 5 || sum.Terms[0].Term != "fraud" {
+		t.Errorf("Terms = %+v", sum.Terms)
+	}
+
+	if Summarize(scores[2:]).SharplyNegative() {
+		t.Error("SharplyNegative() = true for neutral coverage")
+	}
+	if got := Summarize(nil); got.Articles != 0 || got.Tone != 0 || got.SharplyNegative() {
+		t.Errorf("Summarize(nil) = %+v", got)
+	}
+}
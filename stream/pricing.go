@@ -0,0 +1,192 @@
+package stream
+
+import (
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+)
+
+// Tick is a decoded PricingData message: the latest trade and quote for a
+// symbol. Fields Yahoo omits from a message are zero.
+type Tick struct {
+	Symbol        string
+	Price         float64
+	Time          time.Time
+	Currency      string
+	Exchange      string
+	QuoteType     string
+	MarketHours   string // PRE_MARKET, REGULAR_MARKET, POST_MARKET or EXTENDED_HOURS_MARKET
+	ChangePercent float64
+	Change        float64
+	DayVolume     int64
+	DayHigh       float64
+	DayLow        float64
+	Open          float64
+	PreviousClose float64
+	ShortName     string
+	LastSize      int64
+	Bid           float64
+	BidSize       int64
+	Ask           float64
+	AskSize       int64
+	MarketCap     float64
+}
+
+// quoteTypes names the PricingData QuoteType enum.
+var quoteTypes = map[uint64]string{
+	5: "ALTSYMBOL", 7: "HEARTBEAT", 8: "EQUITY", 9: "INDEX", 11: "MUTUALFUND",
+	12: "MONEYMARKET", 13: "OPTION", 14: "CURRENCY", 15: "WARRANT", 17: "BOND",
+	18: "FUTURE", 20: "ETF", 23: "COMMODITY", 28: "ECNQUOTE", 41: "CRYPTOCURRENCY",
+	42: "INDICATOR", 1000: "INDUSTRY",
+}
+
+// marketHours names the PricingData MarketHoursType enum.
+var marketHours = []string{"PRE_MARKET", "REGULAR_MARKET", "POST_MARKET", "EXTENDED_HOURS_MARKET"}
+
+// Protobuf wire types.
+const (
+	wireVarint  = 0
+	wireFixed64 = 1
+	wireBytes   = 2
+	wireFixed32 = 5
+)
+
+// DecodeMessage decodes a streamer text message. Version 2 of the streamer
+// wraps the base64 payload in JSON ({"type":"pricing","message":"..."});
+// version 1 sends the base64 payload alone.
+func DecodeMessage(msg []byte) (Tick, error) {
+	payload := strings.TrimSpace(string(msg))
+	if strings.HasPrefix(payload, "{") {
+		var env struct {
+			Type    string `json:"type"`
+			Message string `json:"message"`
+		}
+		if err := json.Unmarshal([]byte(payload), &env); err != nil {
+			return Tick{}, fmt.Errorf("decode envelope: %w", err)
+		}
+		if env.Type != "" && env.Type != "pricing" {
+			return Tick{}, fmt.Errorf("unsupported message type %q", env.Type)
+		}
+		payload = env.Message
+	}
+
+	raw, err := base64.StdEncoding.DecodeString(payload)
+	if err != nil {
+		if raw, err = base64.RawStdEncoding.DecodeString(payload); err != nil {
+			return Tick{}, fmt.Errorf("decode base64: %w", err)
+		}
+	}
+	return DecodePricingData(raw)
+}
+
+// DecodePricingData decodes a protobuf PricingData message. Unknown fields
+// are skipped so additions to Yahoo's schema do not break decoding.
+func DecodePricingData(b []byte) (Tick, error) {
+	var t Tick
+	for len(b) > 0 {
+		key, n := binary.Uvarint(b)
+		if n <= 0 {
+			return Tick{}, errors.New("truncated field key")
+		}
+		b = b[n:]
+		field, wire := key>>3, key&7
+
+		var (
+			v   uint64 // varint and fixed values
+			buf []byte // length-delimited values
+		)
+		switch wire {
+		case wireVarint:
+			if v, n = binary.Uvarint(b); n <= 0 {
+				return Tick{}, fmt.Errorf("truncated varint in field %d", field)
+			}
+			b = b[n:]
+		case wireFixed64:
+			if len(b) < 8 {
+				return Tick{}, fmt.Errorf("truncated fixed64 in field %d", field)
+			}
+			v, b = binary.LittleEndian.Uint64(b), b[8:]
+		case wireFixed32:
+			if len(b) < 4 {
+				return Tick{}, fmt.Errorf("truncated fixed32 in field %d", field)
+			}
+			v, b = uint64(binary.LittleEndian.Uint32(b)), b[4:]
+		case wireBytes:
+			size, n := binary.Uvarint(b)
+			if n <= 0 || uint64(len(b)-n) < size {
+				return Tick{}, fmt.Errorf("truncated bytes in field %d", field)
+			}
+			buf, b = b[n:n+int(size)], b[n+int(size):]
+		default:
+			return Tick{}, fmt.Errorf("unsupported wire type %d in field %d", wire, field)
+		}
+
+		// float fields arrive as fixed32, sint64 fields as zigzag varints.
+		f32 := float64(math.Float32frombits(uint32(v)))
+		s64 := int64(v>>1) ^ -int64(v&1)
+		switch field {
+		case 1:
+			t.Symbol = string(buf)
+		case 2:
+			t.Price = f32
+		case 3:
+			t.Time = unixTime(s64)
+		case 4:
+			t.Currency = string(buf)
+		case 5:
+			t.Exchange = string(buf)
+		case 6:
+			t.QuoteType = quoteTypes[v]
+		case 7:
+			if v < uint64(len(marketHours)) {
+				t.MarketHours = marketHours[v]
+			}
+		case 8:
+			t.ChangePercent = f32
+		case 9:
+			t.DayVolume = s64
+		case 10:
+			t.DayHigh = f32
+		case 11:
+			t.DayLow = f32
+		case 12:
+			t.Change = f32
+		case 13:
+			t.ShortName = string(buf)
+		case 15:
+			t.Open = f32
+		case 16:
+			t.PreviousClose = f32
+		case 22:
+			t.LastSize = s64
+		case 23:
+			t.Bid = f32
+		case 24:
+			t.BidSize = s64
+		case 25:
+			t.Ask = f32
+		case 26:
+			t.AskSize = s64
+		case 33:
+			t.MarketCap = math.Float64frombits(v)
+		}
+	}
+	if t.Symbol == "" {
+		return Tick{}, errors.New("pricing data without a symbol")
+	}
+	return t, nil
+}
+
+// unixTime interprets a timestamp in milliseconds, as the streamer sends
+// them, or in seconds for values too small to be milliseconds.
+func unixTime(v int64) time.Time {
+	if v > 1e11 {
+		return time.UnixMilli(v).UTC()
+	}
+	return time.Unix(v, 0).UTC()
+}
@@ -0,0 +1,140 @@
+package stream
+
+import (
+	"encoding/base64"
+	"encoding/binary"
+	"math"
+	"testing"
+	"time"
+)
+
+// pb builds protobuf messages for tests.
+type pb []byte
+
+func (b pb) key(field, wire int) pb {
+	return binary.AppendUvarint(b, uint64(field<<3|wire))
+}
+
+func (b pb) str(field int, s string) pb {
+	b = binary.AppendUvarint(b.key(field, wireBytes), uint64(len(s)))
+	return append(b, s...)
+}
+
+func (b pb) float(field int, v float32) pb {
+	return binary.LittleEndian.AppendUint32(b.key(field, wireFixed32), math.Float32bits(v))
+}
+
+func (b pb) double(field int, v float64) pb {
+	return binary.LittleEndian.AppendUint64(b.key(field, wireFixed64), math.Float64bits(v))
+}
+
+func (b pb) sint(field int, v int64) pb {
+	return binary.AppendUvarint(b.key(field, wireVarint), uint64(v<<1^v>>63))
+}
+
+func (b pb) enum(field int, v uint64) pb {
+	return binary.AppendUvarint(b.key(field, wireVarint), v)
+}
+
+func applePricingData() pb {
+	return pb(nil).
+		str(1, "AAPL").
+		float(2, 189.5).
+		sint(3, 1760630400000).
+		str(4, "USD").
+		str(5, "NMS").
+		enum(6, 8).
+		enum(7, 1).
+		float(8, 1.25).
+		sint(9, 41234567).
+		float(10, 190.25).
+		float(11, 186.5).
+		float(12, 2.34).
+		str(13, "Apple Inc.").
+		str(18, "ignored"). // underlyingSymbol, not decoded
+		enum(99, 7).        // a field added after this decoder was written
+		float(23, 189.49).
+		sint(24, 300).
+		float(25, 189.51).
+		sint(26, 200).
+		double(33, 2.85e12)
+}
+
+func TestDecodePricingData(t *testing.T) {
+	tick, err := DecodePricingData(applePricingData())
+	if err != nil {
+		t.Fatalf("DecodePricingData() error: %v", err)
+	}
+	want := Tick{
+		Symbol:        "AAPL",
+		Price:         float64(float32(189.5)),
+		Time:          time.Date(2025, 10, 16, 16, 0, 0, 0, time.UTC),
+		Currency:      "USD",
+		Exchange:      "NMS",
+		QuoteType:     "EQUITY",
+		MarketHours:   "REGULAR_MARKET",
+		ChangePercent: float64(float32(1.25)),
+		Change:        float64(float32(2.34)),
+		DayVolume:     41234567,
+		DayHigh:       float64(float32(190.25)),
+		DayLow:        float64(float32(186.5)),
+		ShortName:     "Apple Inc.",
+		Bid:           float64(float32(189.49)),
+		BidSize:       300,
+		Ask:           float64(float32(189.51)),
+		AskSize:       200,
+		MarketCap:     2.85e12,
+	}
+	if tick != want {
+		t.Errorf("DecodePricingData() =\n%+v\nwant\n%+v", tick, want)
+	}
+}
+
+func TestDecodePricingData_NegativeAndSeconds(t *testing.T) {
+	tick, err := DecodePricingData(pb(nil).str(1, "^VIX").sint(3, 1760630400).float(12, -1.5).sint(22, -1))
+	if err != nil {
+		t.Fatalf("DecodePricingData() error: %v", err)
+	}
+	if !tick.Time.Equal(time.Unix(1760630400, 0)) || tick.Change != -1.5 || tick.LastSize != -1 {
+		t.Errorf("tick = %+v", tick)
+	}
+}
+
+func TestDecodePricingData_Errors(t *testing.T) {
+	full := applePricingData()
+	for name, b := range map[string][]byte{
+		"truncated":  full[:len(full)-3],
+		"no symbol":  pb(nil).float(2, 1),
+		"bad length": {0x0a, 0x20, 'A'},
+		"wire type":  {0x0b},
+	} {
+		if _, err := DecodePricingData(b); err == nil {
+			t.Errorf("%s: expected error", name)
+		}
+	}
+}
+
+func TestDecodeMessage(t *testing.T) {
+	b64 := base64.StdEncoding.EncodeToString(applePricingData())
+	for name, msg := range map[string]string{
+		"v1":       b64,
+		"v2":       `{"type":"pricing","message":"` + b64 + `"}`,
+		"unpadded": base64.RawStdEncoding.EncodeToString(applePricingData()),
+	} {
+		tick, err := DecodeMessage([]byte(msg))
+		if err != nil {
+			t.Errorf("%s: DecodeMessage() error: %v", name, err)
+			continue
+		}
+		if tick.Symbol != "AAPL" || tick.Price != float64(float32(189.5)) {
+			t.Errorf("%s: tick = %+v", name, tick)
+		}
+	}
+
+	if _, err := DecodeMessage([]byte(`{"type":"heartbeat","message":""}`)); err == nil {
+		t.Error("expected error for non-pricing message")
+	}
+	if _, err := DecodeMessage([]byte(`not base64!`)); err == nil {
+		t.Error("expected error for invalid base64")
+	}
+}
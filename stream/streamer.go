@@ -0,0 +1,323 @@
+// Package stream receives real-time prices from Yahoo's websocket streamer
+// and keeps the last tick per symbol in memory.
+//
+// The streamer pushes a base64-encoded protobuf PricingData message for every
+// trade or quote change of a subscribed symbol. Streamer maintains one
+// connection for all subscriptions, connecting on the first subscription,
+// reconnecting with backoff and resubscribing after a drop, and disconnecting
+// when the last symbol is unsubscribed.
+package stream
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultURL is Yahoo's streamer endpoint.
+const DefaultURL = "wss://streamer.finance.yahoo.com/?version=2"
+
+// Connection parameters.
+const (
+	// MaxSubscriptions bounds the symbols streamed at once.
+	MaxSubscriptions = 100
+	// heartbeat is how often subscriptions are resent; the streamer stops
+	// pushing to connections that stay silent.
+	heartbeat    = 15 * time.Second
+	dialTimeout  = 15 * time.Second
+	minBackoff   = time.Second
+	maxBackoff   = time.Minute
+	streamOrigin = "https://finance.yahoo.com"
+)
+
+// Streamer streams prices for a changing set of symbols.
+type Streamer struct {
+	url    string
+	onTick func(Tick)
+
+	mu      sync.Mutex
+	subs    map[string]bool
+	ticks   map[string]Tick
+	conn    *wsConn
+	lastErr error
+	cancel  context.CancelFunc // stops the run loop; nil until started
+	wake    chan struct{}      // signals a subscription change to the run loop
+}
+
+// New creates a Streamer for the websocket at url. onTick, if not nil, is
+// called from the receiving goroutine for every tick after it is stored.
+func New(url string, onTick func(Tick)) *Streamer {
+	return &Streamer{
+		url:    url,
+		onTick: onTick,
+		subs:   make(map[string]bool),
+		ticks:  make(map[string]Tick),
+		wake:   make(chan struct{}, 1),
+	}
+}
+
+// Subscribe adds symbols to the stream, starting it if needed. It returns
+// the symbols that were not already subscribed.
+func (s *Streamer) Subscribe(symbols ...string) ([]string, error) {
+	s.mu.Lock()
+	var added []string
+	for _, sym := range normalize(symbols) {
+		if !s.subs[sym] && !slices.Contains(added, sym) {
+			added = append(added, sym)
+		}
+	}
+	if len(s.subs)+len(added) > MaxSubscriptions {
+		s.mu.Unlock()
+		return nil, fmt.Errorf("too many subscriptions: %d (max %d)", len(s.subs)+len(added), MaxSubscriptions)
+	}
+	for _, sym := range added {
+		s.subs[sym] = true
+	}
+	conn := s.conn
+	if s.cancel == nil && len(s.subs) > 0 {
+		ctx, cancel := context.WithCancel(context.Background())
+		s.cancel = cancel
+		go s.run(ctx)
+	}
+	s.mu.Unlock()
+
+	if len(added) > 0 {
+		if conn != nil {
+			send(conn, "subscribe", added)
+		}
+		s.signal()
+	}
+	return added, nil
+}
+
+// Unsubscribe removes symbols from the stream and forgets their last ticks,
+// returning the symbols that were subscribed. The connection closes when no
+// subscriptions remain.
+func (s *Streamer) Unsubscribe(symbols ...string) []string {
+	s.mu.Lock()
+	var removed []string
+	for _, sym := range normalize(symbols) {
+		if s.subs[sym] {
+			delete(s.subs, sym)
+			delete(s.ticks, sym)
+			removed = append(removed, sym)
+		}
+	}
+	conn := s.conn
+	idle := len(s.subs) == 0
+	s.mu.Unlock()
+
+	if conn != nil && len(removed) > 0 {
+		if idle {
+			conn.close()
+		} else {
+			send(conn, "unsubscribe", removed)
+		}
+	}
+	return removed
+}
+
+// Subscriptions returns the subscribed symbols in order.
+func (s *Streamer) Subscriptions() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return sortedKeys(s.subs)
+}
+
+// Subscribed reports whether symbol is subscribed.
+func (s *Streamer) Subscribed(symbol string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.subs[strings.ToUpper(symbol)]
+}
+
+// Latest returns the last tick received for symbol.
+func (s *Streamer) Latest(symbol string) (Tick, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	t, ok := s.ticks[strings.ToUpper(symbol)]
+	return t, ok
+}
+
+// Status reports whether the streamer is connected and the last connection
+// error, if any.
+func (s *Streamer) Status() (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.conn != nil, s.lastErr
+}
+
+// Close stops streaming. The Streamer restarts on the next Subscribe.
+func (s *Streamer) Close() {
+	s.mu.Lock()
+	cancel, conn := s.cancel, s.conn
+	s.cancel = nil
+	s.mu.Unlock()
+	if cancel != nil {
+		cancel()
+	}
+	if conn != nil {
+		conn.close()
+	}
+}
+
+func (s *Streamer) signal() {
+	select {
+	case s.wake <- struct{}{}:
+	default:
+	}
+}
+
+// run connects whenever there are subscriptions, until ctx is cancelled.
+func (s *Streamer) run(ctx context.Context) {
+	backoff := minBackoff
+	for {
+		// Wait for something to stream.
+		for len(s.Subscriptions()) == 0 {
+			select {
+			case <-ctx.Done():
+				return
+			case <-s.wake:
+			}
+		}
+
+		received, err := s.connect(ctx)
+		if ctx.Err() != nil {
+			return
+		}
+		s.mu.Lock()
+		s.lastErr = err
+		s.mu.Unlock()
+		if received {
+			backoff = minBackoff
+		}
+		if err == nil || len(s.Subscriptions()) == 0 {
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(backoff):
+		}
+		backoff = min(backoff*2, maxBackoff)
+	}
+}
+
+// connect streams over one connection until it fails or is closed,
+// reporting whether any tick arrived. It returns nil when the connection was
+// closed because the last symbol was unsubscribed.
+func (s *Streamer) connect(ctx context.Context) (bool, error) {
+	dialCtx, cancel := context.WithTimeout(ctx, dialTimeout)
+	conn, err := dialWebsocket(dialCtx, s.url, http.Header{"Origin": {streamOrigin}})
+	cancel()
+	if err != nil {
+		return false, err
+	}
+
+	s.mu.Lock()
+	if len(s.subs) == 0 {
+		// Everything was unsubscribed while dialing.
+		s.mu.Unlock()
+		conn.close()
+		return false, nil
+	}
+	s.conn = conn
+	s.lastErr = nil
+	s.mu.Unlock()
+
+	done := make(chan struct{})
+	defer func() {
+		close(done)
+		s.mu.Lock()
+		s.conn = nil
+		s.mu.Unlock()
+		conn.close()
+	}()
+
+	// Subscribe, and keep resubscribing so the streamer keeps pushing.
+	resend := func() error {
+		if subs := s.Subscriptions(); len(subs) > 0 {
+			return send(conn, "subscribe", subs)
+		}
+		return nil
+	}
+	if err := resend(); err != nil {
+		return false, err
+	}
+	go func() {
+		t := time.NewTicker(heartbeat)
+		defer t.Stop()
+		for {
+			select {
+			case <-done:
+				return
+			case <-ctx.Done():
+				conn.close()
+				return
+			case <-t.C:
+				resend()
+			}
+		}
+	}()
+
+	received := false
+	for {
+		msg, err := conn.readMessage()
+		if err != nil {
+			if len(s.Subscriptions()) == 0 {
+				return received, nil
+			}
+			return received, err
+		}
+		tick, err := DecodeMessage(msg)
+		if err != nil {
+			continue
+		}
+		received = true
+		s.mu.Lock()
+		// Drop ticks that arrive after an unsubscribe.
+		subscribed := s.subs[tick.Symbol]
+		if subscribed {
+			s.ticks[tick.Symbol] = tick
+		}
+		s.mu.Unlock()
+		if subscribed && s.onTick != nil {
+			s.onTick(tick)
+		}
+	}
+}
+
+// send writes a subscribe or unsubscribe request.
+func send(conn *wsConn, action string, symbols []string) error {
+	msg, err := json.Marshal(map[string][]string{action: symbols})
+	if err != nil {
+		return err
+	}
+	return conn.writeText(msg)
+}
+
+func normalize(symbols []string) []string {
+	var out []string
+	for _, s := range symbols {
+		if s = strings.ToUpper(strings.TrimSpace(s)); s != "" {
+			out = append(out, s)
+		}
+	}
+	return out
+}
+
+func sortedKeys(m map[string]bool) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,191 @@
+package stream
+
+import (
+	"bufio"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+)
+
+// standIn is a local stand-in for Yahoo's streamer. After a client's first
+// subscribe it replays captured frames, then records every request.
+type standIn struct {
+	*httptest.Server
+	frames []string
+
+	mu       sync.Mutex
+	requests []string
+	conns    int
+	// dropAfterReplay closes the first connection once its frames are sent.
+	dropAfterReplay bool
+}
+
+func newStandIn(t *testing.T) *standIn {
+	t.Helper()
+	f, err := os.Open("testdata/frames.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	s := &standIn{}
+	sc := bufio.NewScanner(f)
+	for sc.Scan() {
+		s.frames = append(s.frames, sc.Text())
+	}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serve))
+	t.Cleanup(s.Close)
+	return s
+}
+
+func (s *standIn) serve(w http.ResponseWriter, r *http.Request) {
+	c, err := upgrade(w, r)
+	if err != nil {
+		return
+	}
+	defer c.conn.Close()
+	s.mu.Lock()
+	s.conns++
+	drop := s.dropAfterReplay && s.conns == 1
+	s.mu.Unlock()
+
+	replayed := false
+	for {
+		msg, err := c.readMessage()
+		if err != nil {
+			return
+		}
+		s.mu.Lock()
+		s.requests = append(s.requests, string(msg))
+		s.mu.Unlock()
+		if !replayed {
+			replayed = true
+			for _, f := range s.frames {
+				writeServerFrame(c.conn, true, opText, []byte(f))
+			}
+			if drop {
+				return
+			}
+		}
+	}
+}
+
+func (s *standIn) received(req string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return slices.Contains(s.requests, req)
+}
+
+func (s *standIn) connections() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.conns
+}
+
+// eventually polls cond for up to five seconds.
+func eventually(t *testing.T, what string, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatalf("timed out waiting for %s", what)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestStreamer_ReplaysFrames(t *testing.T) {
+	srv := newStandIn(t)
+	ticks := make(chan Tick, 10)
+	s := New(wsURL(srv.Server), func(t Tick) { ticks <- t })
+	defer s.Close()
+
+	added, err := s.Subscribe("aapl", "MSFT", "AAPL")
+	if err != nil {
+		t.Fatalf("Subscribe() error: %v", err)
+	}
+	if !slices.Equal(added, []string{"AAPL", "MSFT"}) {
+		t.Errorf("added = %v, want [AAPL MSFT]", added)
+	}
+
+	var got []string
+	for range 3 {
+		select {
+		case tick := <-ticks:
+			got = append(got, tick.Symbol)
+		case <-time.After(5 * time.Second):
+			t.Fatalf("timed out after ticks %v", got)
+		}
+	}
+	if !slices.Equal(got, []string{"AAPL", "MSFT", "AAPL"}) {
+		t.Errorf("ticks = %v, want AAPL MSFT AAPL (heartbeat skipped)", got)
+	}
+	if !srv.received(`{"subscribe":["AAPL","MSFT"]}`) {
+		t.Errorf("requests = %v, want a subscribe for AAPL and MSFT", srv.requests)
+	}
+
+	tick, ok := s.Latest("aapl")
+	if !ok || tick.Price != 189.75 || tick.Bid != float64(float32(189.74)) || tick.MarketHours != "REGULAR_MARKET" {
+		t.Errorf("Latest(AAPL) = %+v, %v; want the second AAPL frame", tick, ok)
+	}
+	if connected, err := s.Status(); !connected || err != nil {
+		t.Errorf("Status() = %v, %v; want connected", connected, err)
+	}
+
+	s.Unsubscribe("MSFT")
+	eventually(t, "unsubscribe request", func() bool { return srv.received(`{"unsubscribe":["MSFT"]}`) })
+	if _, ok := s.Latest("MSFT"); ok {
+		t.Error("Latest(MSFT) still set after unsubscribe")
+	}
+	if !slices.Equal(s.Subscriptions(), []string{"AAPL"}) {
+		t.Errorf("Subscriptions() = %v, want [AAPL]", s.Subscriptions())
+	}
+
+	s.Unsubscribe("AAPL")
+	eventually(t, "disconnect", func() bool {
+		connected, _ := s.Status()
+		return !connected
+	})
+}
+
+func TestStreamer_ResubscribesAfterDrop(t *testing.T) {
+	srv := newStandIn(t)
+	srv.dropAfterReplay = true
+	s := New(wsURL(srv.Server), nil)
+	defer s.Close()
+
+	if _, err := s.Subscribe("AAPL"); err != nil {
+		t.Fatalf("Subscribe() error: %v", err)
+	}
+	eventually(t, "reconnect", func() bool { return srv.connections() >= 2 })
+	eventually(t, "resubscribe", func() bool {
+		srv.mu.Lock()
+		defer srv.mu.Unlock()
+		return len(srv.requests) >= 2 && srv.requests[1] == `{"subscribe":["AAPL"]}`
+	})
+	if tick, ok := s.Latest("AAPL"); !ok || tick.Price != 189.75 {
+		t.Errorf("Latest(AAPL) = %+v, %v", tick, ok)
+	}
+	if _, ok := s.Latest("MSFT"); ok {
+		t.Error("stored a tick for an unsubscribed symbol")
+	}
+}
+
+func TestStreamer_MaxSubscriptions(t *testing.T) {
+	s := New("ws://127.0.0.1:1", nil)
+	defer s.Close()
+
+	symbols := make([]string, MaxSubscriptions+1)
+	for i := range symbols {
+		symbols[i] = string(rune('A'+i/26)) + string(rune('A'+i%26))
+	}
+	if _, err := s.Subscribe(symbols...); err == nil {
+		t.Fatal("expected error above MaxSubscriptions")
+	}
+	if len(s.Subscriptions()) != 0 {
+		t.Errorf("Subscriptions() = %v, want none after a rejected subscribe", s.Subscriptions())
+	}
+}
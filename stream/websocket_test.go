@@ -0,0 +1,145 @@
+package stream
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/binary"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// upgrade completes a server side websocket handshake, returning the
+// connection wrapped for reading client frames.
+func upgrade(w http.ResponseWriter, r *http.Request) (*wsConn, error) {
+	conn, brw, err := w.(http.Hijacker).Hijack()
+	if err != nil {
+		return nil, err
+	}
+	resp := "HTTP/1.1 101 Switching Protocols\r\nUpgrade: websocket\r\nConnection: Upgrade\r\n" +
+		"Sec-WebSocket-Accept: " + acceptKey(r.Header.Get("Sec-WebSocket-Key")) + "\r\n\r\n"
+	if _, err := conn.Write([]byte(resp)); err != nil {
+		conn.Close()
+		return nil, err
+	}
+	return &wsConn{conn: conn, br: brw.Reader}, nil
+}
+
+// writeServerFrame writes an unmasked frame, as servers send them.
+func writeServerFrame(conn net.Conn, fin bool, op byte, payload []byte) error {
+	b0 := op
+	if fin {
+		b0 |= 0x80
+	}
+	buf := []byte{b0}
+	switch n := len(payload); {
+	case n < 126:
+		buf = append(buf, byte(n))
+	case n <= 0xFFFF:
+		buf = append(buf, 126)
+		buf = binary.BigEndian.AppendUint16(buf, uint16(n))
+	default:
+		buf = append(buf, 127)
+		buf = binary.BigEndian.AppendUint64(buf, uint64(n))
+	}
+	_, err := conn.Write(append(buf, payload...))
+	return err
+}
+
+func wsURL(s *httptest.Server) string {
+	return "ws" + strings.TrimPrefix(s.URL, "http")
+}
+
+func TestWebsocket_Messages(t *testing.T) {
+	long := bytes.Repeat([]byte("x"), 300)
+	pong := make(chan []byte, 1)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Origin") != "https://example.com" || r.Header.Get("Sec-WebSocket-Version") != "13" {
+			t.Errorf("unexpected handshake headers: %v", r.Header)
+		}
+		c, err := upgrade(w, r)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		defer c.conn.Close()
+
+		// A ping between the fragments of a message, then a long message.
+		writeServerFrame(c.conn, false, opText, []byte("hel"))
+		writeServerFrame(c.conn, true, opPing, []byte("p1"))
+		writeServerFrame(c.conn, true, opContinuation, []byte("lo"))
+		writeServerFrame(c.conn, true, opBinary, long)
+
+		fin, op, payload, err := c.readFrame()
+		if err != nil || !fin || op != opPong {
+			t.Errorf("expected pong, got op %d err %v", op, err)
+		}
+		pong <- payload
+
+		msg, err := c.readMessage()
+		if err != nil || string(msg) != "bye" {
+			t.Errorf("server read %q, %v", msg, err)
+		}
+		writeServerFrame(c.conn, true, opClose, []byte{0x03, 0xE8})
+		c.readFrame() // the client's close reply
+	}))
+	defer srv.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	c, err := dialWebsocket(ctx, wsURL(srv), http.Header{"Origin": {"https://example.com"}})
+	if err != nil {
+		t.Fatalf("dialWebsocket() error: %v", err)
+	}
+	defer c.conn.Close()
+
+	if msg, err := c.readMessage(); err != nil || string(msg) != "hello" {
+		t.Fatalf("readMessage() = %q, %v; want hello", msg, err)
+	}
+	if p := <-pong; string(p) != "p1" {
+		t.Errorf("pong payload = %q, want p1", p)
+	}
+	if msg, err := c.readMessage(); err != nil || !bytes.Equal(msg, long) {
+		t.Fatalf("readMessage() = %d bytes, %v; want %d", len(msg), err, len(long))
+	}
+	if err := c.writeText([]byte("bye")); err != nil {
+		t.Fatalf("writeText() error: %v", err)
+	}
+	if _, err := c.readMessage(); err != errClosed {
+		t.Errorf("readMessage() error = %v, want errClosed", err)
+	}
+}
+
+func TestWebsocket_HandshakeRejected(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "forbidden", http.StatusForbidden)
+	}))
+	defer srv.Close()
+
+	_, err := dialWebsocket(context.Background(), wsURL(srv), nil)
+	if err == nil || !strings.Contains(err.Error(), "403") {
+		t.Errorf("expected status error, got %v", err)
+	}
+
+	if _, err := dialWebsocket(context.Background(), "http://example.com", nil); err == nil {
+		t.Error("expected error for http scheme")
+	}
+}
+
+func TestWebsocket_BadAcceptKey(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, _, _ := w.(http.Hijacker).Hijack()
+		defer conn.Close()
+		conn.Write([]byte("HTTP/1.1 101 Switching Protocols\r\nUpgrade: websocket\r\nConnection: Upgrade\r\nSec-WebSocket-Accept: nope\r\n\r\n"))
+		bufio.NewReader(conn).ReadByte()
+	}))
+	defer srv.Close()
+
+	if _, err := dialWebsocket(context.Background(), wsURL(srv), nil); err == nil || !strings.Contains(err.Error(), "accept") {
+		t.Errorf("expected accept key error, got %v", err)
+	}
+}
@@ -1,46 +1,330 @@
 package tools
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"fmt"
+	"math"
+	"slices"
+	"sort"
 	"strings"
+	"sync"
 	"time"
-
+	"unicode"
+
+	"github.com/emmanuelay/yahoo-finance-mcp/article"
+	"github.com/emmanuelay/yahoo-finance-mcp/fx"
+	"github.com/emmanuelay/yahoo-finance-mcp/options"
+	"github.com/emmanuelay/yahoo-finance-mcp/peers"
+	"github.com/emmanuelay/yahoo-finance-mcp/pricing"
+	"github.com/emmanuelay/yahoo-finance-mcp/ratios"
+	"github.com/emmanuelay/yahoo-finance-mcp/scoring"
+	"github.com/emmanuelay/yahoo-finance-mcp/sentiment"
+	"github.com/emmanuelay/yahoo-finance-mcp/stream"
+	"github.com/emmanuelay/yahoo-finance-mcp/valuation"
 	"github.com/emmanuelay/yahoo-finance-mcp/yahoo"
 	"github.com/mark3labs/mcp-go/mcp"
 )
 
 // Handlers holds the Yahoo Finance client and provides MCP tool handler functions.
 type Handlers struct {
-	client *yahoo.Client
+	client    *yahoo.Client
+	rates     *fx.Converter
+	articles  *article.Fetcher
+	stream    *stream.Streamer
+	resources *resourceServer // nil unless RegisterResources was called
 }
 
 // NewHandlers creates a new Handlers instance with the given Yahoo Finance client.
 func NewHandlers(client *yahoo.Client) *Handlers {
-	return &Handlers{client: client}
+	h := &Handlers{client: client, rates: fx.NewConverter(client), articles: article.NewFetcher(client)}
+	h.stream = stream.New(stream.DefaultURL, h.onTick)
+	return h
 }
 
 // HandleGetQuote handles the get_quote tool call.
 func (h *Handlers) HandleGetQuote(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	symbol := req.GetString("symbol", "")
-	if symbol == "" {
-		return mcp.NewToolResultError("symbol is required"), nil
+	symbol, errResult := h.symbolArg(req)
+	if errResult != nil {
+		return errResult, nil
 	}
-	symbol = strings.ToUpper(symbol)
 
 	price, detail, err := h.client.GetQuote(symbol)
 	if err != nil {
 		return mcp.NewToolResultError(fmt.Sprintf("Failed to get quote for %s: %v", symbol, err)), nil
 	}
 
-	return mcp.NewToolResultText(formatQuote(price, detail)), nil
+	// A streamed tick is more recent than the polled quote.
+	note := ""
+	if tick, ok := h.stream.Latest(symbol); ok && price != nil {
+		applyTick(price, tick)
+		note = fmtTickNote(tick)
+	}
+
+	currency := fx.Canonical(req.GetString("currency", ""))
+	if price == nil || currency == "" || currency == fx.Canonical(price.Currency) {
+		return mcp.NewToolResultText(formatQuote(price, detail) + note), nil
+	}
+	rate, err := h.rates.Latest(price.Currency, currency)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to convert %s to %s: %v", price.Currency, currency, err)), nil
+	}
+	price, detail = convertQuote(price, detail, rate)
+
+	return mcp.NewToolResultText(formatQuote(price, detail) + note + fmtRateNote([]fx.Rate{rate})), nil
+}
+
+// HandleStreamQuotes handles the stream_quotes tool call.
+func (h *Handlers) HandleStreamQuotes(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	action := strings.ToLower(req.GetString("action", "list"))
+
+	var symbols []string
+	if action == "subscribe" || action == "unsubscribe" {
+		for _, s := range splitList(req.GetString("symbols", "")) {
+			symbols = append(symbols, strings.ToUpper(s))
+		}
+		if len(symbols) == 0 {
+			return mcp.NewToolResultError(fmt.Sprintf("symbols is required to %s", action)), nil
+		}
+		var errResult *mcp.CallToolResult
+		if symbols, errResult = h.resolveSymbols(req, symbols); errResult != nil {
+			return errResult, nil
+		}
+	}
+
+	note := ""
+	switch action {
+	case "subscribe":
+		added, err := h.stream.Subscribe(symbols...)
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Failed to subscribe: %v", err)), nil
+		}
+		h.addStreamResources(added)
+		note = fmt.Sprintf("Subscribed: %s", strings.Join(symbols, ", "))
+	case "unsubscribe":
+		removed := h.stream.Unsubscribe(symbols...)
+		h.removeStreamResources(removed)
+		if len(removed) == 0 {
+			note = "None of those symbols were subscribed"
+		} else {
+			note = fmt.Sprintf("Unsubscribed: %s", strings.Join(removed, ", "))
+		}
+	case "list":
+	default:
+		return mcp.NewToolResultError(fmt.Sprintf("invalid action %q (use subscribe, unsubscribe or list)", action)), nil
+	}
+
+	subs := h.stream.Subscriptions()
+	ticks := make([]stream.Tick, 0, len(subs))
+	for _, s := range subs {
+		if t, ok := h.stream.Latest(s); ok {
+			ticks = append(ticks, t)
+		} else {
+			ticks = append(ticks, stream.Tick{Symbol: s})
+		}
+	}
+	connected, err := h.stream.Status()
+
+	return mcp.NewToolResultText(formatStreamQuotes(note, ticks, connected, err, h.resources != nil)), nil
+}
+
+// applyTick overlays a streamed tick on a polled quote.
+func applyTick(price *yahoo.PriceData, t stream.Tick) {
+	switch t.MarketHours {
+	case "REGULAR_MARKET":
+		price.RegularMarketPrice.Raw = t.Price
+		price.RegularMarketChange.Raw = t.Change
+		price.RegularMarketChangePercent.Raw = t.ChangePercent
+		if t.DayVolume > 0 {
+			price.RegularMarketVolume.Raw = t.DayVolume
+		}
+		if t.DayHigh > 0 {
+			price.RegularMarketDayHigh.Raw = t.DayHigh
+		}
+		if t.DayLow > 0 {
+			price.RegularMarketDayLow.Raw = t.DayLow
+		}
+	case "PRE_MARKET":
+		price.PreMarketPrice.Raw = t.Price
+		price.PreMarketChange.Raw = t.Change
+		price.PreMarketChangePercent.Raw = t.ChangePercent
+	case "POST_MARKET":
+		price.PostMarketPrice.Raw = t.Price
+		price.PostMarketChange.Raw = t.Change
+		price.PostMarketChangePercent.Raw = t.ChangePercent
+	}
+}
+
+func fmtTickNote(t stream.Tick) string {
+	return fmt.Sprintf("\nLive: %s from the streamer at %s (%s)\n",
+		fmtPrice(t.Price, t.Currency), t.Time.Format("2006-01-02 15:04:05 MST"), fmtMarketHours(t.MarketHours))
+}
+
+func fmtMarketHours(h string) string {
+	if h == "" {
+		return "session unknown"
+	}
+	return strings.ToLower(strings.ReplaceAll(h, "_", " "))
+}
+
+// snapshotSections are the get_company_snapshot sections, in output order.
+var snapshotSections = []string{"quote", "profile", "analysts", "financials", "news"}
+
+// snapshotItems are the line items of the snapshot's financials table.
+var snapshotItems = []string{"TotalRevenue", "GrossProfit", "OperatingIncome", "NetIncome", "DilutedEPS", "FreeCashFlow"}
+
+// companySnapshot is the data behind a get_company_snapshot brief. Data for
+// a section that was not requested or failed is nil; errs holds failures
+// by section.
+type companySnapshot struct {
+	symbol     string
+	sections   map[string]bool
+	period     string
+	summary    *yahoo.QuoteSummaryResult
+	financials *yahoo.StatementTable
+	news       *yahoo.NewsFeed
+	errs       map[string]error
+}
+
+// HandleGetCompanySnapshot handles the get_company_snapshot tool call.
+func (h *Handlers) HandleGetCompanySnapshot(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	symbol, errResult := h.symbolArg(req)
+	if errResult != nil {
+		return errResult, nil
+	}
+
+	sections := make(map[string]bool)
+	for _, s := range splitList(strings.ToLower(req.GetString("sections", ""))) {
+		if !slices.Contains(snapshotSections, s) {
+			return mcp.NewToolResultError(fmt.Sprintf("unknown section %q (use %s)", s, strings.Join(snapshotSections, ", "))), nil
+		}
+		sections[s] = true
+	}
+	if len(sections) == 0 {
+		for _, s := range snapshotSections {
+			sections[s] = true
+		}
+	}
+	newsCount := req.GetInt("news_count", 5)
+	if newsCount < 1 || newsCount > 20 {
+		return mcp.NewToolResultError("news_count must be between 1 and 20"), nil
+	}
+	period := yahoo.PeriodAnnual
+	if req.GetBool("quarterly", false) {
+		period = yahoo.PeriodQuarterly
+	}
+
+	snap := companySnapshot{symbol: symbol, sections: sections, period: period, errs: make(map[string]error)}
+	var mu sync.Mutex
+	// fail records err against the requested sections among failed.
+	fail := func(err error, failed ...string) {
+		mu.Lock()
+		defer mu.Unlock()
+		for _, s := range failed {
+			if sections[s] {
+				snap.errs[s] = err
+			}
+		}
+	}
+
+	// Quote, profile and analyst data share one quoteSummary request.
+	var wg sync.WaitGroup
+	if sections["quote"] || sections["profile"] || sections["analysts"] {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			r, err := h.client.GetSnapshot(symbol)
+			if err != nil {
+				fail(err, "quote", "profile", "analysts")
+				return
+			}
+			snap.summary = r
+		}()
+	}
+	if sections["financials"] {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results, err := h.client.GetFinancialStatement(symbol, yahoo.FinancialsRequest{Period: period, Items: snapshotItems})
+			if err != nil {
+				fail(err, "financials")
+				return
+			}
+			snap.financials = yahoo.NewStatementTable(results)
+		}()
+	}
+	if sections["news"] {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			feed, err := h.client.GetNewsFeed(yahoo.NewsQuery{Symbols: []string{symbol}, Count: newsCount})
+			if err != nil {
+				fail(err, "news")
+				return
+			}
+			snap.news = feed
+		}()
+	}
+	wg.Wait()
+
+	failed := 0
+	for s := range sections {
+		if snap.errs[s] != nil {
+			failed++
+		}
+	}
+	if failed == len(sections) {
+		var msgs []string
+		for _, s := range snapshotSections {
+			if err := snap.errs[s]; err != nil {
+				msgs = append(msgs, fmt.Sprintf("%s: %v", s, err))
+			}
+		}
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to get snapshot for %s: %s", symbol, strings.Join(msgs, "; "))), nil
+	}
+
+	// A streamed tick is more recent than the polled quote.
+	if snap.summary != nil && snap.summary.Price != nil {
+		if tick, ok := h.stream.Latest(symbol); ok {
+			applyTick(snap.summary.Price, tick)
+		}
+	}
+
+	return mcp.NewToolResultText(formatCompanySnapshot(snap)), nil
+}
+
+// HandleGetQuoteSummary handles the get_quote_summary tool call.
+func (h *Handlers) HandleGetQuoteSummary(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	symbol, errResult := h.symbolArg(req)
+	if errResult != nil {
+		return errResult, nil
+	}
+	modules := splitList(req.GetString("modules", ""))
+	if len(modules) == 0 {
+		return mcp.NewToolResultError("modules is required (e.g., \"calendarEvents,earningsHistory\")"), nil
+	}
+
+	result, err := h.client.GetQuoteSummary(symbol, modules...)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to get quote summary for %s: %v", symbol, err)), nil
+	}
+
+	if req.GetBool("raw", false) {
+		text, err := formatQuoteSummaryJSON(result)
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Failed to encode quote summary for %s: %v", symbol, err)), nil
+		}
+		return mcp.NewToolResultText(text), nil
+	}
+	return mcp.NewToolResultText(formatQuoteSummary(symbol, modules, result)), nil
 }
 
 // HandleGetChart handles the get_chart tool call.
 func (h *Handlers) HandleGetChart(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	symbol := strings.ToUpper(req.GetString("symbol", ""))
-	if symbol == "" {
-		return mcp.NewToolResultError("symbol is required"), nil
+	symbol, errResult := h.symbolArg(req)
+	if errResult != nil {
+		return errResult, nil
 	}
 
 	rangeStr := req.GetString("range", "1mo")
@@ -61,233 +345,1506 @@ func (h *Handlers) HandleSearch(ctx context.Context, req mcp.CallToolRequest) (*
 		return mcp.NewToolResultError("query is required"), nil
 	}
 
-	limit := req.GetInt("limit", 10)
+	opts := yahoo.SearchOptions{
+		Limit:     req.GetInt("limit", 10),
+		Exchanges: splitList(req.GetString("exchange", "")),
+		Region:    strings.TrimSpace(req.GetString("region", "")),
+		Fuzzy:     req.GetBool("fuzzy", false),
+		News:      req.GetInt("news", 0),
+	}
+	if opts.News < 0 || opts.News > 20 {
+		return mcp.NewToolResultError("news must be between 0 and 20"), nil
+	}
+	if t := strings.ToUpper(strings.TrimSpace(req.GetString("type", ""))); t != "" {
+		opts.QuoteTypes = []string{t}
+	}
+	if req.GetBool("lists", false) {
+		opts.Lists = 5
+	}
 
-	results, err := h.client.Search(query, limit)
+	results, err := h.client.SearchWith(query, opts)
 	if err != nil {
 		return mcp.NewToolResultError(fmt.Sprintf("Search failed: %v", err)), nil
 	}
 
-	return mcp.NewToolResultText(formatSearch(results)), nil
+	var quotes map[string]yahoo.BulkQuoteResult
+	if req.GetBool("enrich", false) && len(results.Quotes) > 0 {
+		var symbols []string
+		for _, q := range results.Quotes {
+			symbols = append(symbols, q.Symbol)
+		}
+		bulk, err := h.client.GetBulkQuotes(symbols)
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Failed to get quotes for search results: %v", err)), nil
+		}
+		quotes = make(map[string]yahoo.BulkQuoteResult, len(bulk))
+		for _, q := range bulk {
+			quotes[q.Symbol] = q
+		}
+	}
+
+	return mcp.NewToolResultText(formatSearch(results, quotes)), nil
 }
 
 // HandleGetFinancials handles the get_financials tool call.
 func (h *Handlers) HandleGetFinancials(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	symbol := strings.ToUpper(req.GetString("symbol", ""))
-	if symbol == "" {
-		return mcp.NewToolResultError("symbol is required"), nil
+	symbol, errResult := h.symbolArg(req)
+	if errResult != nil {
+		return errResult, nil
 	}
 
-	statement := req.GetString("statement", "income")
-	quarterly := req.GetBool("quarterly", false)
+	items := splitList(req.GetString("items", ""))
+	statement := req.GetString("statement", "")
+	if statement == "" && len(items) == 0 {
+		statement = "income"
+	}
+	period := req.GetString("period", "")
+	if period == "" {
+		period = yahoo.PeriodAnnual
+		if req.GetBool("quarterly", false) {
+			period = yahoo.PeriodQuarterly
+		}
+	}
 
-	results, err := h.client.GetFinancials(symbol, statement, quarterly)
+	results, err := h.client.GetFinancialStatement(symbol, yahoo.FinancialsRequest{
+		Statement: statement,
+		Period:    period,
+		Items:     items,
+	})
 	if err != nil {
 		return mcp.NewToolResultError(fmt.Sprintf("Failed to get financials for %s: %v", symbol, err)), nil
 	}
 
-	return mcp.NewToolResultText(formatFinancials(symbol, statement, quarterly, results)), nil
+	return mcp.NewToolResultText(formatFinancials(symbol, statement, period, results)), nil
 }
 
-// HandleGetOptions handles the get_options tool call.
-func (h *Handlers) HandleGetOptions(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	symbol := strings.ToUpper(req.GetString("symbol", ""))
-	if symbol == "" {
-		return mcp.NewToolResultError("symbol is required"), nil
+// HandleGetFinancialRatios handles the get_financial_ratios tool call.
+func (h *Handlers) HandleGetFinancialRatios(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	symbol, errResult := h.symbolArg(req)
+	if errResult != nil {
+		return errResult, nil
 	}
 
-	expiration := req.GetString("expiration", "")
+	period := strings.ToLower(req.GetString("period", yahoo.PeriodAnnual))
+	if period != yahoo.PeriodAnnual && period != yahoo.PeriodQuarterly {
+		return mcp.NewToolResultError(fmt.Sprintf("invalid period %q (use annual or quarterly)", period)), nil
+	}
 
-	result, err := h.client.GetOptions(symbol, expiration)
+	results, err := h.client.GetFinancialStatement(symbol, yahoo.FinancialsRequest{
+		Period: period,
+		Items:  ratios.RequiredItems,
+	})
 	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to get options for %s: %v", symbol, err)), nil
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to get financials for %s: %v", symbol, err)), nil
 	}
 
-	return mcp.NewToolResultText(formatOptions(result)), nil
+	table := yahoo.NewStatementTable(results)
+	return mcp.NewToolResultText(formatFinancialRatios(symbol, period, table, ratios.Compute(table), ratios.ComputeGrowth(table))), nil
 }
 
-// HandleGetRecommendations handles the get_recommendations tool call.
-func (h *Handlers) HandleGetRecommendations(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	symbol := strings.ToUpper(req.GetString("symbol", ""))
-	if symbol == "" {
-		return mcp.NewToolResultError("symbol is required"), nil
+// HandleGetQualityScores handles the get_quality_scores tool call.
+func (h *Handlers) HandleGetQualityScores(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	symbol, errResult := h.symbolArg(req)
+	if errResult != nil {
+		return errResult, nil
 	}
 
-	trend, err := h.client.GetRecommendations(symbol)
+	results, err := h.client.GetFinancialStatement(symbol, yahoo.FinancialsRequest{
+		Period: yahoo.PeriodAnnual,
+		Items:  scoring.RequiredItems,
+	})
 	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to get recommendations for %s: %v", symbol, err)), nil
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to get financials for %s: %v", symbol, err)), nil
 	}
+	table := yahoo.NewStatementTable(results)
 
-	return mcp.NewToolResultText(formatRecommendations(symbol, trend)), nil
-}
+	price, _, err := h.client.GetQuote(symbol)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to get quote for %s: %v", symbol, err)), nil
+	}
 
-// HandleGetNews handles the get_news tool call.
-func (h *Handlers) HandleGetNews(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	symbol := strings.ToUpper(req.GetString("symbol", ""))
-	if symbol == "" {
-		return mcp.NewToolResultError("symbol is required"), nil
+	var scores []scoring.Score
+	var notes []string
+	if s, err := scoring.Piotroski(table); err == nil {
+		scores = append(scores, s)
+	} else {
+		notes = append(notes, fmt.Sprintf("Piotroski F-Score unavailable: %v", err))
 	}
 
-	count := req.GetInt("count", 5)
+	marketCap := 0.0
+	if price != nil {
+		marketCap = float64(price.MarketCap.Raw)
+		if table.Currency != "" && price.Currency != "" && !sameMajorCurrency(price.Currency, table.Currency) {
+			notes = append(notes, fmt.Sprintf("Market cap is quoted in %s but statements are reported in %s; the Altman D term mixes currencies.", price.Currency, table.Currency))
+		}
+	}
+	if s, err := scoring.AltmanZ(table, marketCap); err == nil {
+		scores = append(scores, s)
+	} else {
+		notes = append(notes, fmt.Sprintf("Altman Z-Score unavailable: %v", err))
+	}
 
-	news, err := h.client.GetNews(symbol, count)
-	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to get news for %s: %v", symbol, err)), nil
+	if s, err := scoring.Beneish(table); err == nil {
+		scores = append(scores, s)
+	} else {
+		notes = append(notes, fmt.Sprintf("Beneish M-Score unavailable: %v", err))
 	}
 
-	return mcp.NewToolResultText(formatNews(symbol, news)), nil
+	return mcp.NewToolResultText(formatQualityScores(symbol, scores, notes)), nil
 }
 
-// HandleGetBulkQuotes handles the get_bulk_quotes tool call.
-func (h *Handlers) HandleGetBulkQuotes(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	raw := req.GetString("symbols", "")
-	if raw == "" {
-		return mcp.NewToolResultError("symbols is required"), nil
+// HandleGetDCFValuation handles the get_dcf_valuation tool call.
+func (h *Handlers) HandleGetDCFValuation(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	symbol, errResult := h.symbolArg(req)
+	if errResult != nil {
+		return errResult, nil
 	}
 
-	var symbols []string
-	for _, s := range strings.Split(raw, ",") {
-		s = strings.TrimSpace(s)
-		if s != "" {
-			symbols = append(symbols, strings.ToUpper(s))
-		}
-	}
-	if len(symbols) == 0 {
-		return mcp.NewToolResultError("at least one symbol is required"), nil
+	// Rate overrides are given in percent; NaN marks "not provided".
+	growthPct := req.GetFloat("growth", math.NaN())
+	waccPct := req.GetFloat("wacc", math.NaN())
+	terminal := req.GetFloat("terminal_growth", valuation.DefaultTerminalGrowth*100) / 100
+	erp := req.GetFloat("equity_risk_premium", valuation.DefaultEquityRiskPremium*100) / 100
+	years := req.GetInt("years", valuation.DefaultYears)
+	if years < 1 || years > 20 {
+		return mcp.NewToolResultError("years must be between 1 and 20"), nil
 	}
+	ratesSymbol := strings.ToUpper(req.GetString("rates_symbol", valuation.DefaultRatesSymbol))
 
-	results, err := h.client.GetBulkQuotes(symbols)
+	results, err := h.client.GetFinancialStatement(symbol, yahoo.FinancialsRequest{
+		Period: yahoo.PeriodAnnual,
+		Items:  valuation.RequiredItems,
+	})
 	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to get bulk quotes: %v", err)), nil
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to get financials for %s: %v", symbol, err)), nil
+	}
+	history, err := valuation.Seed(yahoo.NewStatementTable(results))
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Cannot value %s: %v", symbol, err)), nil
 	}
 
-	return mcp.NewToolResultText(formatBulkQuotes(results)), nil
-}
+	price, detail, err := h.client.GetQuote(symbol)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to get quote for %s: %v", symbol, err)), nil
+	}
 
-// HandleGetBulkSpark handles the get_bulk_spark tool call.
-func (h *Handlers) HandleGetBulkSpark(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	raw := req.GetString("symbols", "")
-	if raw == "" {
-		return mcp.NewToolResultError("symbols is required"), nil
+	a := dcfAssumptions{history: history, price: price}
+	if history.Currency != "" && price != nil && price.Currency != "" && !sameMajorCurrency(price.Currency, history.Currency) {
+		a.notes = append(a.notes, fmt.Sprintf("Statements are reported in %s but the stock trades in %s; compare the value per share after converting.", history.Currency, price.Currency))
 	}
 
-	var symbols []string
-	for _, s := range strings.Split(raw, ",") {
-		s = strings.TrimSpace(s)
-		if s != "" {
-			symbols = append(symbols, strings.ToUpper(s))
+	growth := growthPct / 100
+	switch {
+	case !math.IsNaN(growthPct):
+		a.growthSource = "caller override"
+	default:
+		trend, err := h.client.GetEarningsTrend(symbol)
+		if err != nil {
+			a.notes = append(a.notes, fmt.Sprintf("Earnings trend unavailable: %v", err))
+		}
+		if t, ok := trend.Period("+5y"); ok && t.Growth.Raw != 0 {
+			growth, a.growthSource = t.Growth.Raw, "analyst 5-year EPS growth estimate"
+		} else if t, ok := trend.Period("+1y"); ok && t.Growth.Raw != 0 {
+			growth, a.growthSource = t.Growth.Raw, "analyst next-year EPS growth estimate"
+		} else if g, ok := history.HistoricalGrowth(); ok {
+			growth = valuation.ClampHistoricalGrowth(g)
+			a.growthSource = fmt.Sprintf("historical FCF CAGR of %.2f%%, bounded to 0-20%%", g*100)
+		} else {
+			return mcp.NewToolResultError(fmt.Sprintf("No growth estimate available for %s; pass growth explicitly", symbol)), nil
 		}
-	}
-	if len(symbols) == 0 {
-		return mcp.NewToolResultError("at least one symbol is required"), nil
 	}
 
-	rangeStr := req.GetString("range", "1mo")
-	interval := req.GetString("interval", "1d")
+	wacc := waccPct / 100
+	if math.IsNaN(waccPct) {
+		rates, _, err := h.client.GetQuote(ratesSymbol)
+		if err != nil || rates == nil || rates.RegularMarketPrice.Raw == 0 {
+			return mcp.NewToolResultError(fmt.Sprintf("Failed to get risk-free rate from %s; pass wacc explicitly", ratesSymbol)), nil
+		}
+		if detail == nil || detail.Beta.Raw == 0 {
+			return mcp.NewToolResultError(fmt.Sprintf("No beta available for %s; pass wacc explicitly", symbol)), nil
+		}
+		tax := history.TaxRate
+		if tax == 0 {
+			tax = valuation.DefaultTaxRate
+		}
+		in := valuation.WACCInputs{
+			RiskFree:          rates.RegularMarketPrice.Raw / 100,
+			Beta:              detail.Beta.Raw,
+			EquityRiskPremium: erp,
+			CreditSpread:      valuation.DefaultCreditSpread,
+			TaxRate:           tax,
+			Debt:              history.Debt,
+		}
+		if price != nil {
+			in.MarketCap = float64(price.MarketCap.Raw)
+		}
+		w, err := valuation.WACC(in)
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Cannot derive WACC for %s: %v; pass wacc explicitly", symbol, err)), nil
+		}
+		wacc = w.WACC
+		a.wacc, a.waccInputs, a.ratesSymbol = &w, in, ratesSymbol
+	}
 
-	results, err := h.client.GetBulkSpark(symbols, rangeStr, interval)
+	inputs := valuation.Inputs{
+		BaseFCF:        history.FCF[0].Value,
+		Growth:         growth,
+		Years:          years,
+		TerminalGrowth: terminal,
+		WACC:           wacc,
+		NetDebt:        history.NetDebt(),
+		Shares:         history.Shares,
+	}
+	result, err := valuation.DCF(inputs)
 	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to get bulk spark data: %v", err)), nil
+		return mcp.NewToolResultError(fmt.Sprintf("Cannot value %s: %v", symbol, err)), nil
 	}
+	grid := valuation.SensitivityGrid(inputs, 0.01, 0.005)
 
-	return mcp.NewToolResultText(formatBulkSpark(symbols, results)), nil
+	return mcp.NewToolResultText(formatDCFValuation(symbol, a, result, grid)), nil
 }
 
-// HandleGetProfile handles the get_profile tool call.
-func (h *Handlers) HandleGetProfile(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	symbol := strings.ToUpper(req.GetString("symbol", ""))
-	if symbol == "" {
-		return mcp.NewToolResultError("symbol is required"), nil
+// HandleGetOptions handles the get_options tool call.
+func (h *Handlers) HandleGetOptions(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	symbol, errResult := h.symbolArg(req)
+	if errResult != nil {
+		return errResult, nil
 	}
 
-	profile, quoteType, err := h.client.GetProfile(symbol)
+	expiration := req.GetString("expiration", "")
+	model, err := pricing.ParseModel(req.GetString("model", ""))
 	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to get profile for %s: %v", symbol, err)), nil
+		return mcp.NewToolResultError(err.Error()), nil
 	}
 
-	return mcp.NewToolResultText(formatProfile(symbol, profile, quoteType)), nil
-}
-
-// HandleGetSector handles the get_sector tool call.
-func (h *Handlers) HandleGetSector(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	key := strings.ToLower(strings.TrimSpace(req.GetString("key", "")))
-	if key == "" {
-		return mcp.NewToolResultError("key is required"), nil
+	filter := options.Filter{
+		Strikes:         req.GetInt("strikes", 10),
+		MinMoneyness:    req.GetFloat("min_moneyness", 0) / 100,
+		MaxMoneyness:    req.GetFloat("max_moneyness", 0) / 100,
+		MinVolume:       req.GetInt("min_volume", 0),
+		MinOpenInterest: req.GetInt("min_open_interest", 0),
 	}
 
-	data, err := h.client.GetSector(key)
+	result, err := h.client.GetOptions(symbol, expiration)
 	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to get sector %q: %v", key, err)), nil
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to get options for %s: %v", symbol, err)), nil
 	}
 
-	return mcp.NewToolResultText(formatSector(data)), nil
+	return mcp.NewToolResultText(formatOptions(result, h.optionPricing(req, symbol, model), filter)), nil
 }
 
-// HandleGetIndustry handles the get_industry tool call.
-func (h *Handlers) HandleGetIndustry(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	key := strings.ToLower(strings.TrimSpace(req.GetString("key", "")))
-	if key == "" {
-		return mcp.NewToolResultError("key is required"), nil
+// optionPricing resolves the rate and dividend yield for pricing a chain.
+// Rates given in percent take precedence over the defaults fetched from
+// the rates symbol and the underlying's summary detail.
+func (h *Handlers) optionPricing(req mcp.CallToolRequest, symbol string, model pricing.Model) optionPricingInputs {
+	in := optionPricingInputs{model: model, now: time.Now()}
+
+	if v := req.GetFloat("rate", math.NaN()); !math.IsNaN(v) {
+		in.rate, in.rateSource = v/100, "caller override"
+	} else if q, _, err := h.client.GetQuote(pricing.DefaultRatesSymbol); err == nil && q != nil && q.RegularMarketPrice.Raw > 0 {
+		in.rate, in.rateSource = q.RegularMarketPrice.Raw/100, pricing.DefaultRatesSymbol
+	} else {
+		in.rate, in.rateSource = pricing.DefaultRate, "default"
 	}
 
-	data, err := h.client.GetIndustry(key)
-	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to get industry %q: %v", key, err)), nil
+	if v := req.GetFloat("dividend_yield", math.NaN()); !math.IsNaN(v) {
+		in.dividend, in.dividendSource = v/100, "caller override"
+	} else if _, detail, err := h.client.GetQuote(symbol); err == nil && detail != nil {
+		switch {
+		case detail.DividendYield.Raw > 0:
+			in.dividend, in.dividendSource = detail.DividendYield.Raw, "forward yield"
+		case detail.Yield.Raw > 0:
+			in.dividend, in.dividendSource = detail.Yield.Raw, "fund yield"
+		default:
+			in.dividendSource = "none reported, assumed 0"
+		}
+	} else {
+		in.dividendSource = "unavailable, assumed 0"
 	}
 
-	return mcp.NewToolResultText(formatIndustry(data)), nil
+	return in
 }
 
-// HandleGetMarketSummary handles the get_market_summary tool call.
-func (h *Handlers) HandleGetMarketSummary(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	market := strings.ToUpper(strings.TrimSpace(req.GetString("market", "")))
-	if market == "" {
-		return mcp.NewToolResultError("market is required"), nil
+// HandleGetVolatilitySurface handles the get_volatility_surface tool call.
+func (h *Handlers) HandleGetVolatilitySurface(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	symbol, errResult := h.symbolArg(req)
+	if errResult != nil {
+		return errResult, nil
 	}
 
-	items, err := h.client.GetMarketSummary(market)
+	filter := yahoo.ExpirationFilter{Max: req.GetInt("max_expirations", 8)}
+	if filter.Max < 1 || filter.Max > 24 {
+		return mcp.NewToolResultError("max_expirations must be between 1 and 24"), nil
+	}
+	if v := req.GetString("from", ""); v != "" {
+		t, err := time.Parse("2006-01-02", v)
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("invalid from date %q (use YYYY-MM-DD)", v)), nil
+		}
+		filter.From = t
+	}
+	if v := req.GetString("to", ""); v != "" {
+		t, err := time.Parse("2006-01-02", v)
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("invalid to date %q (use YYYY-MM-DD)", v)), nil
+		}
+		filter.To = t
+	}
+
+	result, errs, err := h.client.GetOptionChains(symbol, filter)
 	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to get market summary for %s: %v", market, err)), nil
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to get options for %s: %v", symbol, err)), nil
+	}
+	skipped := make([]string, len(errs))
+	for i, err := range errs {
+		skipped[i] = err.Error()
 	}
 
-	return mcp.NewToolResultText(formatMarketSummary(market, items)), nil
+	surface := options.BuildSurface(result, options.DefaultMoneyness, time.Now())
+	return mcp.NewToolResultText(formatVolatilitySurface(symbol, surface, skipped)), nil
 }
 
-// HandleGetMarketStatus handles the get_market_status tool call.
-func (h *Handlers) HandleGetMarketStatus(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	market := strings.ToUpper(strings.TrimSpace(req.GetString("market", "")))
-	if market == "" {
-		return mcp.NewToolResultError("market is required"), nil
+// HandleAnalyzeOptionStrategy handles the analyze_option_strategy tool call.
+func (h *Handlers) HandleAnalyzeOptionStrategy(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	symbol, errResult := h.symbolArg(req)
+	if errResult != nil {
+		return errResult, nil
 	}
-
-	groups, err := h.client.GetMarketStatus(market)
+	legs, err := options.ParseLegs(req.GetString("legs", ""))
 	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to get market status for %s: %v", market, err)), nil
+		return mcp.NewToolResultError(fmt.Sprintf("invalid legs: %v", err)), nil
 	}
 
-	return mcp.NewToolResultText(formatMarketStatus(market, groups)), nil
-}
+	result, err := h.client.GetOptions(symbol, req.GetString("expiration", ""))
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to get options for %s: %v", symbol, err)), nil
+	}
+	if len(result.Options) == 0 {
+		return mcp.NewToolResultError(fmt.Sprintf("No options data available for %s", symbol)), nil
+	}
 
-// --- Text formatters ---
+	chain := result.Options[0]
+	spot := result.Quote.RegularMarketPrice
+	priced, err := options.PriceLegs(legs, chain, spot)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Cannot price strategy: %v", err)), nil
+	}
 
-func formatBulkQuotes(results []yahoo.BulkQuoteResult) string {
-	if len(results) == 0 {
-		return "No quotes returned"
+	in := h.optionPricing(req, symbol, pricing.BlackScholes)
+	m := options.Market{
+		Spot:     spot,
+		Years:    pricing.YearsToExpiry(chain.ExpirationDate, in.now),
+		Rate:     in.rate,
+		Dividend: in.dividend,
+		Vol:      options.ATMVol(chain, spot),
+	}
+	if math.IsNaN(m.Vol) {
+		m.Vol = meanLegVol(priced)
+	}
+	if m.Vol < pricing.MinVolatility {
+		return mcp.NewToolResultError(fmt.Sprintf("Cannot analyze strategy: no usable implied volatility in the %s chain or legs", symbol)), nil
 	}
 
-	var b strings.Builder
-	fmt.Fprintf(&b, "=== Bulk Quotes (%d symbols) ===\n\n", len(results))
-	fmt.Fprintf(&b, "%-8s %-25s %10s %10s %8s %14s %12s\n",
-		"Symbol", "Name", "Price", "Change", "Chg%", "Volume", "Mkt Cap")
-	fmt.Fprintf(&b, "%s\n", strings.Repeat("-", 95))
+	st := options.AnalyzeStrategy(priced, m, options.PayoffGrid(priced, spot, 15))
+	return mcp.NewToolResultText(formatOptionStrategy(symbol, chain.ExpirationDate, m, in, st)), nil
+}
 
-	for _, q := range results {
-		name := q.LongName
-		if name == "" {
-			name = q.ShortName
+// meanLegVol averages the usable implied volatilities of option legs.
+func meanLegVol(legs []options.Leg) float64 {
+	sum, n := 0.0, 0
+	for _, l := range legs {
+		if l.Type != options.LegStock && l.IV >= pricing.MinVolatility {
+			sum += l.IV
+			n++
 		}
-		if len(name) > 24 {
-			name = name[:22] + ".."
+	}
+	if n == 0 {
+		return 0
+	}
+	return sum / float64(n)
+}
+
+// HandleGetRecommendations handles the get_recommendations tool call.
+func (h *Handlers) HandleGetRecommendations(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	symbol, errResult := h.symbolArg(req)
+	if errResult != nil {
+		return errResult, nil
+	}
+
+	trend, err := h.client.GetRecommendations(symbol)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to get recommendations for %s: %v", symbol, err)), nil
+	}
+
+	return mcp.NewToolResultText(formatRecommendations(symbol, trend)), nil
+}
+
+// HandleGetAnalystActions handles the get_analyst_actions tool call.
+func (h *Handlers) HandleGetAnalystActions(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	symbol, errResult := h.symbolArg(req)
+	if errResult != nil {
+		return errResult, nil
+	}
+
+	var filter yahoo.AnalystActionFilter
+	if v := req.GetString("from", ""); v != "" {
+		t, err := time.Parse("2006-01-02", v)
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("invalid from date %q (use YYYY-MM-DD)", v)), nil
+		}
+		filter.From = t
+	}
+	if v := req.GetString("to", ""); v != "" {
+		t, err := time.Parse("2006-01-02", v)
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("invalid to date %q (use YYYY-MM-DD)", v)), nil
+		}
+		filter.To = t
+	}
+	filter.Firm = req.GetString("firm", "")
+	days := req.GetInt("days", 90)
+	if days < 1 {
+		return mcp.NewToolResultError("days must be at least 1"), nil
+	}
+	limit := req.GetInt("limit", 25)
+	if limit < 1 {
+		return mcp.NewToolResultError("limit must be at least 1"), nil
+	}
+
+	history, fin, err := h.client.GetAnalystActions(symbol)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to get analyst actions for %s: %v", symbol, err)), nil
+	}
+
+	var actions []yahoo.AnalystAction
+	if history != nil {
+		actions = yahoo.FilterAnalystActions(history.History, filter)
+	}
+	momentum := yahoo.ComputeRatingMomentum(actions, days, time.Now())
+
+	return mcp.NewToolResultText(formatAnalystActions(symbol, actions, fin, momentum, limit)), nil
+}
+
+// HandleGetNews handles the get_news tool call.
+func (h *Handlers) HandleGetNews(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	symbols, errResult := h.newsSymbols(req)
+	if errResult != nil {
+		return errResult, nil
+	}
+
+	q := yahoo.NewsQuery{
+		Symbols: symbols,
+		Count:   req.GetInt("count", 5),
+		Offset:  req.GetInt("offset", 0),
+	}
+	if q.Count < 1 || q.Count > 50 {
+		return mcp.NewToolResultError("count must be between 1 and 50"), nil
+	}
+	if q.After, q.Before, errResult = newsWindow(req); errResult != nil {
+		return errResult, nil
+	}
+
+	feed, err := h.client.GetNewsFeed(q)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to get news for %s: %v", strings.Join(symbols, ", "), err)), nil
+	}
+
+	return mcp.NewToolResultText(formatNews(strings.Join(symbols, ", "), feed)), nil
+}
+
+// newsSymbols reads the symbols of a news tool from symbols, or failing that
+// symbol.
+func (h *Handlers) newsSymbols(req mcp.CallToolRequest) ([]string, *mcp.CallToolResult) {
+	var symbols []string
+	if raw := splitList(req.GetString("symbols", "")); len(raw) > 0 {
+		for _, s := range raw {
+			symbols = append(symbols, strings.ToUpper(s))
+		}
+		var errResult *mcp.CallToolResult
+		if symbols, errResult = h.resolveSymbols(req, symbols); errResult != nil {
+			return nil, errResult
+		}
+	} else {
+		if strings.TrimSpace(req.GetString("symbol", "")) == "" {
+			return nil, mcp.NewToolResultError("symbol or symbols is required")
+		}
+		symbol, errResult := h.symbolArg(req)
+		if errResult != nil {
+			return nil, errResult
+		}
+		symbols = []string{symbol}
+	}
+	if len(symbols) > 20 {
+		return nil, mcp.NewToolResultError(fmt.Sprintf("too many symbols: %d (max 20)", len(symbols)))
+	}
+	return symbols, nil
+}
+
+// newsWindow reads the from and to dates of a news tool as publish time
+// bounds; to is inclusive, so before is the start of the following day.
+func newsWindow(req mcp.CallToolRequest) (after, before time.Time, errResult *mcp.CallToolResult) {
+	if v := req.GetString("from", ""); v != "" {
+		t, err := time.Parse("2006-01-02", v)
+		if err != nil {
+			return after, before, mcp.NewToolResultError(fmt.Sprintf("invalid from date %q (use YYYY-MM-DD)", v))
+		}
+		after = t
+	}
+	if v := req.GetString("to", ""); v != "" {
+		t, err := time.Parse("2006-01-02", v)
+		if err != nil {
+			return after, before, mcp.NewToolResultError(fmt.Sprintf("invalid to date %q (use YYYY-MM-DD)", v))
+		}
+		before = t.AddDate(0, 0, 1)
+	}
+	return after, before, nil
+}
+
+// HandleGetNewsSentiment handles the get_news_sentiment tool call.
+func (h *Handlers) HandleGetNewsSentiment(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	symbols, errResult := h.newsSymbols(req)
+	if errResult != nil {
+		return errResult, nil
+	}
+	count := req.GetInt("count", 10)
+	if count < 1 || count > 50 {
+		return mcp.NewToolResultError("count must be between 1 and 50"), nil
+	}
+	after, before, errResult := newsWindow(req)
+	if errResult != nil {
+		return errResult, nil
+	}
+
+	// Each symbol gets its own feed so shared articles count toward every
+	// symbol they were returned for.
+	feeds := make([][]yahoo.SearchNews, len(symbols))
+	articles := make(map[string]yahoo.SearchNews)
+	for i, s := range symbols {
+		feed, err := h.client.GetNewsFeed(yahoo.NewsQuery{Symbols: []string{s}, Count: count, After: after, Before: before})
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Failed to get news for %s: %v", s, err)), nil
+		}
+		feeds[i] = feed.Articles
+		for _, n := range feed.Articles {
+			articles[newsKey(n)] = n
+		}
+	}
+
+	var bodies map[string]string
+	if req.GetBool("bodies", false) {
+		bodies = h.fetchArticleBodies(articles)
+	}
+
+	scores := make(map[string]sentiment.Score, len(articles))
+	for key, n := range articles {
+		scores[key] = sentiment.ForArticle(n.Title, bodies[key])
+	}
+
+	results := make([]newsSentiment, len(symbols))
+	for i, s := range symbols {
+		r := newsSentiment{symbol: s, articles: feeds[i]}
+		for _, n := range feeds[i] {
+			r.scores = append(r.scores, scores[newsKey(n)])
+		}
+		r.summary = sentiment.Summarize(r.scores)
+		results[i] = r
+	}
+
+	fetched := -1
+	if bodies != nil {
+		fetched = len(bodies)
+	}
+	return mcp.NewToolResultText(formatNewsSentiment(results, len(articles), fetched)), nil
+}
+
+// newsSentiment is the scored coverage of one symbol.
+type newsSentiment struct {
+	symbol   string
+	articles []yahoo.SearchNews
+	scores   []sentiment.Score // parallel to articles
+	summary  sentiment.Summary
+}
+
+func newsKey(n yahoo.SearchNews) string {
+	if n.UUID != "" {
+		return n.UUID
+	}
+	return n.Link
+}
+
+// fetchArticleBodies fetches the text of each article by key, four at a
+// time. Articles that cannot be fetched are left out and scored on their
+// headline alone.
+func (h *Handlers) fetchArticleBodies(articles map[string]yahoo.SearchNews) map[string]string {
+	bodies := make(map[string]string, len(articles))
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, 4)
+	for key, n := range articles {
+		if n.Link == "" {
+			continue
+		}
+		wg.Add(1)
+		go func(key, link string) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			a, err := h.articles.Fetch(link)
+			if err != nil || a.Text == "" {
+				return
+			}
+			mu.Lock()
+			bodies[key] = a.Text
+			mu.Unlock()
+		}(key, n.Link)
+	}
+	wg.Wait()
+	return bodies
+}
+
+// HandleFetchArticle handles the fetch_article tool call.
+func (h *Handlers) HandleFetchArticle(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	pageURL := strings.TrimSpace(req.GetString("url", ""))
+	if pageURL == "" {
+		return mcp.NewToolResultError("url is required"), nil
+	}
+	maxChars := req.GetInt("max_chars", article.DefaultMaxChars)
+	if maxChars < 100 || maxChars > 50000 {
+		return mcp.NewToolResultError("max_chars must be between 100 and 50000"), nil
+	}
+
+	a, err := h.articles.Fetch(pageURL)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to fetch article %s: %v", pageURL, err)), nil
+	}
+
+	return mcp.NewToolResultText(formatArticle(a.Limit(maxChars))), nil
+}
+
+// HandleScreenStocks handles the screen_stocks tool call.
+func (h *Handlers) HandleScreenStocks(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	if req.GetBool("list_fields", false) {
+		return mcp.NewToolResultText(formatScreenerFields(yahoo.ScreenerFields())), nil
+	}
+
+	filters := req.GetString("filters", "")
+	if filters == "" {
+		return mcp.NewToolResultError("filters is required (or set list_fields to see available fields)"), nil
+	}
+	query, err := yahoo.ParseScreenerFilters(filters)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("invalid filters: %v", err)), nil
+	}
+
+	order := strings.ToLower(req.GetString("sort_order", "desc"))
+	if order != "asc" && order != "desc" {
+		return mcp.NewToolResultError(fmt.Sprintf("invalid sort_order %q (use asc or desc)", order)), nil
+	}
+
+	result, err := h.client.Screen(yahoo.ScreenerRequest{
+		Query:     query,
+		SortField: req.GetString("sort_field", ""),
+		Ascending: order == "asc",
+		Offset:    req.GetInt("offset", 0),
+		Size:      req.GetInt("count", 25),
+	})
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to screen stocks: %v", err)), nil
+	}
+
+	return mcp.NewToolResultText(formatScreener(filters, result)), nil
+}
+
+// HandleGetMovers handles the get_movers tool call.
+func (h *Handlers) HandleGetMovers(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	list := strings.ToLower(strings.TrimSpace(req.GetString("list", "day_gainers")))
+	region := strings.ToUpper(strings.TrimSpace(req.GetString("region", "US")))
+	count := req.GetInt("count", 25)
+	if count < 1 || count > 100 {
+		return mcp.NewToolResultError("count must be between 1 and 100"), nil
+	}
+
+	if list == yahoo.Trending {
+		// Trending only returns symbols; the bulk quote endpoint fills in prices.
+		symbols, err := h.client.GetTrending(region, min(count, 50))
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Failed to get trending tickers for %s: %v", region, err)), nil
+		}
+		var quotes []yahoo.BulkQuoteResult
+		if len(symbols) > 0 {
+			results, err := h.client.GetBulkQuotes(symbols)
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Failed to get quotes for trending tickers: %v", err)), nil
+			}
+			bySymbol := make(map[string]yahoo.BulkQuoteResult, len(results))
+			for _, q := range results {
+				bySymbol[q.Symbol] = q
+			}
+			for _, sym := range symbols {
+				q, ok := bySymbol[sym]
+				if !ok {
+					q = yahoo.BulkQuoteResult{Symbol: sym}
+				}
+				quotes = append(quotes, q)
+			}
+		}
+		return mcp.NewToolResultText(formatMovers("Trending Tickers", "", region, quotes)), nil
+	}
+
+	result, err := h.client.GetPredefinedScreener(list, count, region)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to get %s: %v", list, err)), nil
+	}
+	title := result.Title
+	if title == "" {
+		title = strings.ReplaceAll(list, "_", " ")
+	}
+	return mcp.NewToolResultText(formatMovers(title, result.Description, region, result.Quotes)), nil
+}
+
+// HandleGetSimilarSymbols handles the get_similar_symbols tool call.
+func (h *Handlers) HandleGetSimilarSymbols(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	symbol, errResult := h.symbolArg(req)
+	if errResult != nil {
+		return errResult, nil
+	}
+	count := req.GetInt("count", 10)
+	if count < 1 || count > 25 {
+		return mcp.NewToolResultError("count must be between 1 and 25"), nil
+	}
+
+	similar, err := h.client.GetSimilarSymbols(symbol, count)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to get similar symbols for %s: %v", symbol, err)), nil
+	}
+	if len(similar) == 0 || !req.GetBool("quotes", true) {
+		return mcp.NewToolResultText(formatSimilarSymbols(symbol, similar, nil)), nil
+	}
+
+	// The requested symbol leads the table so peers can be read against it.
+	symbols := []string{symbol}
+	for _, s := range similar {
+		symbols = append(symbols, s.Symbol)
+	}
+	quotes, err := h.client.GetBulkQuotes(symbols)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to get quotes for peers of %s: %v", symbol, err)), nil
+	}
+
+	return mcp.NewToolResultText(formatSimilarSymbols(symbol, similar, quotes)), nil
+}
+
+// HandleCompareFundamentals handles the compare_fundamentals tool call.
+func (h *Handlers) HandleCompareFundamentals(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	var symbols []string
+	seen := make(map[string]bool)
+	add := func(s string) {
+		s = strings.ToUpper(strings.TrimSpace(s))
+		if s != "" && !seen[s] {
+			seen[s] = true
+			symbols = append(symbols, s)
+		}
+	}
+	requested, errResult := h.resolveSymbols(req, splitList(req.GetString("symbols", "")))
+	if errResult != nil {
+		return errResult, nil
+	}
+	for _, s := range requested {
+		add(s)
+	}
+	if len(symbols) == 0 {
+		return mcp.NewToolResultError("symbols is required"), nil
+	}
+
+	// A single symbol is compared against the top companies in its industry.
+	industry := ""
+	if len(symbols) == 1 {
+		maxPeers := req.GetInt("max_peers", 10)
+		if maxPeers < 1 || maxPeers > 20 {
+			return mcp.NewToolResultError("max_peers must be between 1 and 20"), nil
+		}
+		profile, _, err := h.client.GetProfile(symbols[0])
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Failed to get profile for %s: %v", symbols[0], err)), nil
+		}
+		if profile == nil || profile.IndustryKey == "" {
+			return mcp.NewToolResultError(fmt.Sprintf("No industry reported for %s; pass a list of symbols to compare", symbols[0])), nil
+		}
+		data, err := h.client.GetIndustry(profile.IndustryKey)
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Failed to get industry %q: %v", profile.IndustryKey, err)), nil
+		}
+		industry = data.Name
+		for _, c := range data.TopCompanies {
+			if len(symbols) > maxPeers {
+				break
+			}
+			add(c.Symbol)
+		}
+	}
+	if len(symbols) > 25 {
+		return mcp.NewToolResultError(fmt.Sprintf("too many symbols: %d (max 25)", len(symbols))), nil
+	}
+
+	results, errs := h.client.GetFundamentalsBatch(symbols)
+	var companies []peers.Company
+	var skipped []string
+	for i, s := range symbols {
+		if errs[i] != nil {
+			skipped = append(skipped, fmt.Sprintf("%s (%v)", s, errs[i]))
+			continue
+		}
+		companies = append(companies, peers.FromSummary(s, results[i]))
+	}
+	if len(companies) == 0 {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to get fundamentals: %s", strings.Join(skipped, "; "))), nil
+	}
+
+	var rates []fx.Rate
+	if currency := fx.Canonical(req.GetString("currency", "")); currency != "" {
+		byCurrency := make(map[string]fx.Rate)
+		for i, c := range companies {
+			if c.Currency == "" || fx.Canonical(c.Currency) == currency {
+				continue
+			}
+			rate, ok := byCurrency[c.Currency]
+			if !ok {
+				var err error
+				if rate, err = h.rates.Latest(c.Currency, currency); err != nil {
+					return mcp.NewToolResultError(fmt.Sprintf("Failed to convert %s to %s: %v", c.Currency, currency, err)), nil
+				}
+				byCurrency[c.Currency] = rate
+				rates = append(rates, rate)
+			}
+			if v, ok := c.Values[peers.MarketCap]; ok {
+				companies[i].Values[peers.MarketCap] = rate.ConvertMajor(v)
+			}
+			companies[i].Currency = currency
+		}
+	}
+
+	return mcp.NewToolResultText(formatPeerComparison(industry, peers.Compare(companies), skipped) + fmtRateNote(rates)), nil
+}
+
+// HandleResolveSymbol handles the resolve_symbol tool call.
+func (h *Handlers) HandleResolveSymbol(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	query := strings.TrimSpace(req.GetString("query", ""))
+	if query == "" {
+		return mcp.NewToolResultError("query is required"), nil
+	}
+	limit := req.GetInt("limit", 5)
+	if limit < 1 || limit > 20 {
+		return mcp.NewToolResultError("limit must be between 1 and 20"), nil
+	}
+
+	results, err := h.client.ResolveSymbol(query, req.GetString("type", ""), limit)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to resolve %q: %v", query, err)), nil
+	}
+
+	return mcp.NewToolResultText(formatResolvedSymbols(query, results)), nil
+}
+
+// symbolArg returns the upper-cased symbol parameter. With resolve set, the
+// input is first mapped to the best matching Yahoo symbol, so ISINs, names
+// and exchange-qualified tickers work anywhere a symbol is expected.
+func (h *Handlers) symbolArg(req mcp.CallToolRequest) (string, *mcp.CallToolResult) {
+	symbol := strings.TrimSpace(req.GetString("symbol", ""))
+	if symbol == "" {
+		return "", mcp.NewToolResultError("symbol is required")
+	}
+	if !req.GetBool("resolve", false) {
+		return strings.ToUpper(symbol), nil
+	}
+	resolved, err := h.client.ResolveSymbol(symbol, "", 1)
+	if err != nil {
+		return "", mcp.NewToolResultError(fmt.Sprintf("Failed to resolve symbol %q: %v", symbol, err))
+	}
+	return resolved[0].Symbol, nil
+}
+
+// resolveSymbols is symbolArg for tools that take a symbol list.
+func (h *Handlers) resolveSymbols(req mcp.CallToolRequest, symbols []string) ([]string, *mcp.CallToolResult) {
+	if !req.GetBool("resolve", false) {
+		return symbols, nil
+	}
+	out := make([]string, len(symbols))
+	for i, s := range symbols {
+		resolved, err := h.client.ResolveSymbol(s, "", 1)
+		if err != nil {
+			return nil, mcp.NewToolResultError(fmt.Sprintf("Failed to resolve symbol %q: %v", s, err))
+		}
+		out[i] = resolved[0].Symbol
+	}
+	return out, nil
+}
+
+// HandleConvertCurrency handles the convert_currency tool call.
+func (h *Handlers) HandleConvertCurrency(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	from := strings.TrimSpace(req.GetString("from", ""))
+	to := strings.TrimSpace(req.GetString("to", ""))
+	if from == "" || to == "" {
+		return mcp.NewToolResultError("from and to are required"), nil
+	}
+	amount := req.GetFloat("amount", 1)
+
+	var rate fx.Rate
+	var err error
+	if date := strings.TrimSpace(req.GetString("date", "")); date != "" {
+		d, perr := time.Parse("2006-01-02", date)
+		if perr != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Invalid date %q: use YYYY-MM-DD", date)), nil
+		}
+		rate, err = h.rates.On(from, to, d)
+	} else {
+		rate, err = h.rates.Latest(from, to)
+	}
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to convert %s to %s: %v", from, to, err)), nil
+	}
+
+	return mcp.NewToolResultText(formatCurrencyConversion(amount, rate)), nil
+}
+
+// convertBulkQuotes restates quotes in currency, a code as fx.Canonical
+// returns it, and returns the rates used.
+func (h *Handlers) convertBulkQuotes(quotes []yahoo.BulkQuoteResult, currency string) ([]fx.Rate, error) {
+	var rates []fx.Rate
+	byCurrency := make(map[string]fx.Rate)
+	for i, q := range quotes {
+		if q.Currency == "" || fx.Canonical(q.Currency) == currency {
+			continue
+		}
+		rate, ok := byCurrency[q.Currency]
+		if !ok {
+			var err error
+			if rate, err = h.rates.Latest(q.Currency, currency); err != nil {
+				return nil, err
+			}
+			byCurrency[q.Currency] = rate
+			rates = append(rates, rate)
+		}
+
+		c := &quotes[i]
+		for _, v := range []*float64{
+			&c.RegularMarketPrice, &c.RegularMarketChange, &c.RegularMarketOpen,
+			&c.RegularMarketDayHigh, &c.RegularMarketDayLow, &c.RegularMarketPreviousClose,
+			&c.FiftyTwoWeekLow, &c.FiftyTwoWeekHigh, &c.FiftyDayAverage, &c.TwoHundredDayAverage,
+			&c.EpsTrailingTwelveMonths,
+		} {
+			*v = rate.Convert(*v)
+		}
+		c.MarketCap = int64(rate.ConvertMajor(float64(c.MarketCap)))
+		c.Currency = currency
+	}
+	return rates, nil
+}
+
+// convertQuote returns copies of a quote's price data restated at rate.
+func convertQuote(price *yahoo.PriceData, detail *yahoo.SummaryDetailData, rate fx.Rate) (*yahoo.PriceData, *yahoo.SummaryDetailData) {
+	p := *price
+	for _, v := range []*yahoo.YahooValue{
+		&p.RegularMarketPrice, &p.RegularMarketChange, &p.RegularMarketOpen,
+		&p.RegularMarketDayHigh, &p.RegularMarketDayLow, &p.RegularMarketPreviousClose,
+		&p.PreMarketPrice, &p.PreMarketChange, &p.PostMarketPrice, &p.PostMarketChange,
+	} {
+		v.Raw = rate.Convert(v.Raw)
+	}
+	p.MarketCap.Raw = int64(rate.ConvertMajor(float64(p.MarketCap.Raw)))
+	p.Currency = rate.To
+
+	if detail == nil {
+		return &p, nil
+	}
+	d := *detail
+	for _, v := range []*yahoo.YahooValue{
+		&d.FiftyTwoWeekLow, &d.FiftyTwoWeekHigh, &d.FiftyDayAverage, &d.TwoHundredDayAverage,
+	} {
+		v.Raw = rate.Convert(v.Raw)
+	}
+	return &p, &d
+}
+
+// HandleGetBulkQuotes handles the get_bulk_quotes tool call.
+func (h *Handlers) HandleGetBulkQuotes(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	raw := req.GetString("symbols", "")
+	if raw == "" {
+		return mcp.NewToolResultError("symbols is required"), nil
+	}
+
+	var symbols []string
+	for _, s := range strings.Split(raw, ",") {
+		s = strings.TrimSpace(s)
+		if s != "" {
+			symbols = append(symbols, strings.ToUpper(s))
+		}
+	}
+	if len(symbols) == 0 {
+		return mcp.NewToolResultError("at least one symbol is required"), nil
+	}
+	symbols, errResult := h.resolveSymbols(req, symbols)
+	if errResult != nil {
+		return errResult, nil
+	}
+
+	results, err := h.client.GetBulkQuotes(symbols)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to get bulk quotes: %v", err)), nil
+	}
+
+	currency := fx.Canonical(req.GetString("currency", ""))
+	if currency == "" {
+		return mcp.NewToolResultText(formatBulkQuotes(results)), nil
+	}
+	rates, err := h.convertBulkQuotes(results, currency)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to convert quotes to %s: %v", currency, err)), nil
+	}
+
+	return mcp.NewToolResultText(formatBulkQuotes(results) + fmtRateNote(rates)), nil
+}
+
+// HandleGetBulkSpark handles the get_bulk_spark tool call.
+func (h *Handlers) HandleGetBulkSpark(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	raw := req.GetString("symbols", "")
+	if raw == "" {
+		return mcp.NewToolResultError("symbols is required"), nil
+	}
+
+	var symbols []string
+	for _, s := range strings.Split(raw, ",") {
+		s = strings.TrimSpace(s)
+		if s != "" {
+			symbols = append(symbols, strings.ToUpper(s))
+		}
+	}
+	if len(symbols) == 0 {
+		return mcp.NewToolResultError("at least one symbol is required"), nil
+	}
+	symbols, errResult := h.resolveSymbols(req, symbols)
+	if errResult != nil {
+		return errResult, nil
+	}
+
+	rangeStr := req.GetString("range", "1mo")
+	interval := req.GetString("interval", "1d")
+
+	results, err := h.client.GetBulkSpark(symbols, rangeStr, interval)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to get bulk spark data: %v", err)), nil
+	}
+
+	return mcp.NewToolResultText(formatBulkSpark(symbols, results)), nil
+}
+
+// HandleGetProfile handles the get_profile tool call.
+func (h *Handlers) HandleGetProfile(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	symbol, errResult := h.symbolArg(req)
+	if errResult != nil {
+		return errResult, nil
+	}
+
+	profile, quoteType, err := h.client.GetProfile(symbol)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to get profile for %s: %v", symbol, err)), nil
+	}
+
+	return mcp.NewToolResultText(formatProfile(symbol, profile, quoteType)), nil
+}
+
+// HandleGetFundProfile handles the get_fund_profile tool call.
+func (h *Handlers) HandleGetFundProfile(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	symbol, errResult := h.symbolArg(req)
+	if errResult != nil {
+		return errResult, nil
+	}
+
+	result, err := h.client.GetFundProfile(symbol)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to get fund profile for %s: %v", symbol, err)), nil
+	}
+
+	return mcp.NewToolResultText(formatFundProfile(symbol, result)), nil
+}
+
+// HandleGetSector handles the get_sector tool call.
+func (h *Handlers) HandleGetSector(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	key := strings.ToLower(strings.TrimSpace(req.GetString("key", "")))
+	if key == "" {
+		return mcp.NewToolResultError("key is required"), nil
+	}
+
+	data, err := h.client.GetSector(key)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to get sector %q: %v", key, err)), nil
+	}
+
+	return mcp.NewToolResultText(formatSector(data)), nil
+}
+
+// HandleGetIndustry handles the get_industry tool call.
+func (h *Handlers) HandleGetIndustry(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	key := strings.ToLower(strings.TrimSpace(req.GetString("key", "")))
+	if key == "" {
+		return mcp.NewToolResultError("key is required"), nil
+	}
+
+	data, err := h.client.GetIndustry(key)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to get industry %q: %v", key, err)), nil
+	}
+
+	return mcp.NewToolResultText(formatIndustry(data)), nil
+}
+
+// HandleGetMarketSummary handles the get_market_summary tool call.
+func (h *Handlers) HandleGetMarketSummary(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	market := strings.ToUpper(strings.TrimSpace(req.GetString("market", "")))
+	if market == "" {
+		return mcp.NewToolResultError("market is required"), nil
+	}
+
+	items, err := h.client.GetMarketSummary(market)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to get market summary for %s: %v", market, err)), nil
+	}
+
+	return mcp.NewToolResultText(formatMarketSummary(market, items)), nil
+}
+
+// HandleGetMarketStatus handles the get_market_status tool call.
+func (h *Handlers) HandleGetMarketStatus(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	market := strings.ToUpper(strings.TrimSpace(req.GetString("market", "")))
+	if market == "" {
+		return mcp.NewToolResultError("market is required"), nil
+	}
+
+	groups, err := h.client.GetMarketStatus(market)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to get market status for %s: %v", market, err)), nil
+	}
+
+	return mcp.NewToolResultText(formatMarketStatus(market, groups)), nil
+}
+
+// --- Text formatters ---
+
+func formatScreener(filters string, result *yahoo.ScreenerResult) string {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "=== Stock Screener ===\n")
+	fmt.Fprintf(&b, "Filters: %s\n", filters)
+
+	if len(result.Quotes) == 0 {
+		fmt.Fprintf(&b, "\nNo matching stocks\n")
+		return b.String()
+	}
+	fmt.Fprintf(&b, "Showing %d-%d of %s matches\n\n", result.Start+1, result.Start+len(result.Quotes), fmtInt(int64(result.Total)))
+	writeScreenerQuotes(&b, result.Quotes)
+
+	return b.String()
+}
+
+func formatMovers(title, description, region string, quotes []yahoo.BulkQuoteResult) string {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "=== %s (%s) ===\n", title, region)
+	if description != "" {
+		fmt.Fprintf(&b, "%s\n", description)
+	}
+
+	if len(quotes) == 0 {
+		fmt.Fprintf(&b, "\nNo results\n")
+		return b.String()
+	}
+	fmt.Fprintln(&b)
+	writeScreenerQuotes(&b, quotes)
+
+	return b.String()
+}
+
+func writeScreenerQuotes(b *strings.Builder, quotes []yahoo.BulkQuoteResult) {
+	fmt.Fprintf(b, "%-8s %-25s %10s %8s %12s %8s %8s %14s\n",
+		"Symbol", "Name", "Price", "Chg%", "Mkt Cap", "P/E", "Fwd P/E", "Volume")
+	fmt.Fprintf(b, "%s\n", strings.Repeat("-", 100))
+	for _, q := range quotes {
+		name := q.LongName
+		if name == "" {
+			name = q.ShortName
+		}
+		fmt.Fprintf(b, "%-8s %-25s %10.2f %+7.2f%% %12s %8s %8s %14s\n",
+			q.Symbol,
+			truncate(name, 25),
+			q.RegularMarketPrice,
+			q.RegularMarketChangePercent,
+			fmtLargeNumber(float64(q.MarketCap)),
+			fmtOptRatio(q.TrailingPE),
+			fmtOptRatio(q.ForwardPE),
+			fmtInt(q.RegularMarketVolume),
+		)
+	}
+}
+
+func formatScreenerFields(fields []yahoo.ScreenerField) string {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "=== Screener Fields ===\n")
+	category := ""
+	for _, f := range fields {
+		if f.Category != category {
+			category = f.Category
+			fmt.Fprintf(&b, "\n--- %s ---\n", strings.ToUpper(category[:1])+category[1:])
+		}
+		if f.Numeric() {
+			fmt.Fprintf(&b, "  %s\n", f.Name)
+			continue
+		}
+		fmt.Fprintf(&b, "  %s: %s\n", f.Name, strings.Join(f.Values, ", "))
+	}
+	fmt.Fprintf(&b, "\nNumeric fields support =, >, >=, <, <=, between; classification fields support = and in (values separated by |).\n")
+	fmt.Fprintf(&b, "Names may omit a .lasttwelvemonths/.quarterly suffix; numbers accept K/M/B/T suffixes.\n")
+
+	return b.String()
+}
+
+func fmtOptRatio(v float64) string {
+	if v == 0 {
+		return "-"
+	}
+	return fmt.Sprintf("%.2f", v)
+}
+
+func formatSimilarSymbols(symbol string, similar []yahoo.SimilarSymbol, quotes []yahoo.BulkQuoteResult) string {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "=== Similar Symbols: %s ===\n", symbol)
+	if len(similar) == 0 {
+		fmt.Fprintf(&b, "\nNo similar symbols found\n")
+		return b.String()
+	}
+
+	if quotes == nil {
+		fmt.Fprintf(&b, "\n%-10s %8s\n", "Symbol", "Score")
+		fmt.Fprintf(&b, "%s\n", strings.Repeat("-", 19))
+		for _, s := range similar {
+			fmt.Fprintf(&b, "%-10s %8.3f\n", s.Symbol, s.Score)
+		}
+		return b.String()
+	}
+
+	bySymbol := make(map[string]yahoo.BulkQuoteResult, len(quotes))
+	for _, q := range quotes {
+		bySymbol[q.Symbol] = q
+	}
+
+	fmt.Fprintf(&b, "\n%-10s %-25s %6s %10s %8s %12s %8s %8s %6s %7s\n",
+		"Symbol", "Name", "Score", "Price", "Chg%", "Mkt Cap", "P/E", "Fwd P/E", "P/B", "Div Yld")
+	fmt.Fprintf(&b, "%s\n", strings.Repeat("-", 109))
+
+	row := func(sym, score string) {
+		q, ok := bySymbol[sym]
+		if !ok {
+			fmt.Fprintf(&b, "%-10s %-25s %6s %10s\n", sym, "(no quote)", score, "-")
+			return
+		}
+		name := q.LongName
+		if name == "" {
+			name = q.ShortName
+		}
+		divYield := "-"
+		if q.TrailingAnnualDividendYield > 0 {
+			divYield = fmt.Sprintf("%.2f%%", q.TrailingAnnualDividendYield*100)
+		}
+		fmt.Fprintf(&b, "%-10s %-25s %6s %10.2f %+7.2f%% %12s %8s %8s %6s %7s\n",
+			sym,
+			truncate(name, 25),
+			score,
+			q.RegularMarketPrice,
+			q.RegularMarketChangePercent,
+			fmtLargeNumber(float64(q.MarketCap)),
+			fmtOptRatio(q.TrailingPE),
+			fmtOptRatio(q.ForwardPE),
+			fmtOptRatio(q.PriceToBook),
+			divYield,
+		)
+	}
+
+	row(symbol, "-")
+	for _, s := range similar {
+		row(s.Symbol, fmt.Sprintf("%.3f", s.Score))
+	}
+
+	fmt.Fprintf(&b, "\nScore is Yahoo's relative similarity ranking (higher is closer).\n")
+
+	return b.String()
+}
+
+func formatPeerComparison(industry string, cmp peers.Comparison, skipped []string) string {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "=== Peer Comparison ===\n")
+	if industry != "" {
+		fmt.Fprintf(&b, "Industry: %s (%s vs top companies)\n", industry, cmp.Companies[0].Symbol)
+	}
+	currencies := make(map[string]bool)
+	for _, c := range cmp.Companies {
+		if c.Currency != "" {
+			currencies[c.Currency] = true
+		}
+	}
+	if len(currencies) > 1 {
+		fmt.Fprintf(&b, "Note: market caps are in each company's own currency\n")
+	}
+
+	header := func() {
+		fmt.Fprintf(&b, "%-8s", "Symbol")
+		for _, col := range peers.Columns {
+			fmt.Fprintf(&b, " %9s", col.Name)
+		}
+		fmt.Fprintf(&b, "\n%s\n", strings.Repeat("-", 8+10*len(peers.Columns)))
+	}
+	row := func(label string, values map[string]float64, format func(peers.Column, float64) string) {
+		fmt.Fprintf(&b, "%-8s", label)
+		for _, col := range peers.Columns {
+			v, ok := values[col.Name]
+			if !ok {
+				fmt.Fprintf(&b, " %9s", "-")
+				continue
+			}
+			fmt.Fprintf(&b, " %9s", format(col, v))
+		}
+		fmt.Fprintln(&b)
+	}
+	value := func(col peers.Column, v float64) string {
+		switch {
+		case col.Money:
+			return fmtCompact(v)
+		case col.Percent:
+			return fmt.Sprintf("%.1f%%", v*100)
+		default:
+			return fmt.Sprintf("%.1f", v)
+		}
+	}
+	rank := func(_ peers.Column, v float64) string {
+		return fmt.Sprintf("%.0f", v)
+	}
+
+	fmt.Fprintf(&b, "\n--- Fundamentals ---\n")
+	header()
+	for _, c := range cmp.Companies {
+		row(c.Symbol, c.Values, value)
+	}
+	fmt.Fprintf(&b, "%s\n", strings.Repeat("-", 8+10*len(peers.Columns)))
+	row("Median", cmp.Medians, value)
+
+	fmt.Fprintf(&b, "\n--- Percentile Rank (0-100, higher = larger value) ---\n")
+	header()
+	for i, c := range cmp.Companies {
+		row(c.Symbol, cmp.Ranks[i], rank)
+	}
+
+	fmt.Fprintf(&b, "\nNames:\n")
+	for _, c := range cmp.Companies {
+		fmt.Fprintf(&b, "  %-8s %s\n", c.Symbol, c.Name)
+	}
+
+	if len(skipped) > 0 {
+		fmt.Fprintf(&b, "\nSkipped: %s\n", strings.Join(skipped, "; "))
+	}
+
+	return b.String()
+}
+
+func formatResolvedSymbols(query string, results []yahoo.ResolvedSymbol) string {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "=== Symbol Resolution: %s ===\n\n", query)
+	fmt.Fprintf(&b, "%-4s %-14s %-30s %-16s %-8s %-14s %s\n",
+		"Rank", "Symbol", "Name", "Exchange", "Currency", "Type", "Matched By")
+	fmt.Fprintf(&b, "%s\n", strings.Repeat("-", 100))
+	for i, r := range results {
+		fmt.Fprintf(&b, "%-4d %-14s %-30s %-16s %-8s %-14s %s\n",
+			i+1,
+			r.Symbol,
+			truncate(r.Name, 30),
+			truncate(r.Exchange, 16),
+			r.Currency,
+			r.QuoteType,
+			r.Method,
+		)
+	}
+
+	fmt.Fprintf(&b, "\nBest match: %s\n", results[0].Symbol)
+
+	return b.String()
+}
+
+func formatCurrencyConversion(amount float64, rate fx.Rate) string {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "=== Currency Conversion ===\n\n")
+	fmt.Fprintf(&b, "%s %s = %s %s\n", fmtAmount(amount), rate.From, fmtAmount(rate.Convert(amount)), rate.To)
+	fmt.Fprintf(&b, "\nRate:      1 %s = %.6f %s\n", rate.From, rate.Value, rate.To)
+	if rate.Value != 0 {
+		fmt.Fprintf(&b, "Inverse:   1 %s = %.6f %s\n", rate.To, 1/rate.Value, rate.From)
+	}
+	fmt.Fprintf(&b, "As of:     %s\n", rate.Date.UTC().Format("2006-01-02 15:04 MST"))
+	if len(rate.Pairs) > 0 {
+		fmt.Fprintf(&b, "Source:    %s\n", strings.Join(rate.Pairs, " x "))
+	}
+
+	return b.String()
+}
+
+// fmtRateNote lists the exchange rates a result was converted at.
+func fmtRateNote(rates []fx.Rate) string {
+	if len(rates) == 0 {
+		return ""
+	}
+	var b strings.Builder
+	fmt.Fprintf(&b, "\nConverted to %s at:\n", rates[0].To)
+	for _, r := range rates {
+		fmt.Fprintf(&b, "  1 %s = %.6f %s (%s)\n", r.From, r.Value, r.To, strings.Join(r.Pairs, " x "))
+	}
+	return b.String()
+}
+
+func fmtAmount(v float64) string {
+	s := fmt.Sprintf("%.2f", math.Abs(v))
+	whole, frac, _ := strings.Cut(s, ".")
+	var out []byte
+	for i := range whole {
+		if i > 0 && (len(whole)-i)%3 == 0 {
+			out = append(out, ',')
+		}
+		out = append(out, whole[i])
+	}
+	sign := ""
+	if v < 0 {
+		sign = "-"
+	}
+	return sign + string(out) + "." + frac
+}
+
+func formatBulkQuotes(results []yahoo.BulkQuoteResult) string {
+	if len(results) == 0 {
+		return "No quotes returned"
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "=== Bulk Quotes (%d symbols) ===\n\n", len(results))
+	fmt.Fprintf(&b, "%-8s %-25s %10s %10s %8s %14s %12s\n",
+		"Symbol", "Name", "Price", "Change", "Chg%", "Volume", "Mkt Cap")
+	fmt.Fprintf(&b, "%s\n", strings.Repeat("-", 95))
+
+	for _, q := range results {
+		name := q.LongName
+		if name == "" {
+			name = q.ShortName
+		}
+		if len(name) > 24 {
+			name = name[:22] + ".."
 		}
 
 		changeSign := "+"
@@ -306,136 +1863,721 @@ func formatBulkQuotes(results []yahoo.BulkQuoteResult) string {
 		)
 	}
 
-	return b.String()
+	return b.String()
+}
+
+func formatBulkSpark(symbolOrder []string, results yahoo.SparkResponse) string {
+	if len(results) == 0 {
+		return "No spark data returned"
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "=== Bulk Price History (%d symbols) ===\n", len(results))
+
+	for _, sym := range symbolOrder {
+		sr, ok := results[sym]
+		if !ok {
+			fmt.Fprintf(&b, "\n--- %s: no data ---\n", sym)
+			continue
+		}
+
+		granularity := fmt.Sprintf("%ds", sr.DataGranularity)
+		if sr.DataGranularity >= 86400 {
+			granularity = fmt.Sprintf("%dd", sr.DataGranularity/86400)
+		} else if sr.DataGranularity >= 3600 {
+			granularity = fmt.Sprintf("%dh", sr.DataGranularity/3600)
+		} else if sr.DataGranularity >= 60 {
+			granularity = fmt.Sprintf("%dm", sr.DataGranularity/60)
+		}
+		fmt.Fprintf(&b, "\n--- %s (interval: %s) ---\n", sr.Symbol, granularity)
+		if sr.ChartPreviousClose > 0 {
+			fmt.Fprintf(&b, "Previous Close: %.2f\n", sr.ChartPreviousClose)
+		}
+
+		n := len(sr.Timestamps)
+		if n == 0 {
+			fmt.Fprintf(&b, "No data points\n")
+			continue
+		}
+
+		// Cap at 50 rows per symbol to keep output manageable
+		step := 1
+		if n > 50 {
+			step = n / 50
+		}
+
+		fmt.Fprintf(&b, "%-20s %10s %10s\n", "Date", "Close", "Change%")
+		for i := 0; i < n; i += step {
+			ts := time.Unix(sr.Timestamps[i], 0)
+			close := sr.Close[i]
+			chgPct := 0.0
+			if sr.ChartPreviousClose > 0 {
+				chgPct = (close - sr.ChartPreviousClose) / sr.ChartPreviousClose * 100
+			}
+			sign := "+"
+			if chgPct < 0 {
+				sign = ""
+			}
+			fmt.Fprintf(&b, "%-20s %10.2f %s%8.2f%%\n", ts.Format("2006-01-02 15:04"), close, sign, chgPct)
+		}
+		fmt.Fprintf(&b, "(%d of %d data points)\n", min(n, 50), n)
+	}
+
+	return b.String()
+}
+
+func formatQuote(price *yahoo.PriceData, detail *yahoo.SummaryDetailData) string {
+	if price == nil {
+		return "No price data available"
+	}
+
+	var b strings.Builder
+	name := price.LongName
+	if name == "" {
+		name = price.ShortName
+	}
+	fmt.Fprintf(&b, "=== %s (%s) ===\n", price.Symbol, name)
+	fmt.Fprintf(&b, "Exchange: %s | Currency: %s | Market: %s\n\n", price.ExchangeName, price.Currency, price.MarketState)
+
+	fmt.Fprintf(&b, "Price:           %s\n", fmtPrice(price.RegularMarketPrice.Raw, price.Currency))
+	changeSign := "+"
+	if price.RegularMarketChange.Raw < 0 {
+		changeSign = ""
+	}
+	fmt.Fprintf(&b, "Change:          %s%.2f (%s%.2f%%)\n", changeSign, price.RegularMarketChange.Raw, changeSign, price.RegularMarketChangePercent.Raw)
+	fmt.Fprintf(&b, "Volume:          %s\n", fmtInt(price.RegularMarketVolume.Raw))
+
+	if price.MarketCap.Raw > 0 {
+		fmt.Fprintf(&b, "Market Cap:      %s\n", fmtMarketCap(float64(price.MarketCap.Raw), price.Currency))
+	}
+
+	fmt.Fprintf(&b, "Open:            %s\n", fmtPrice(price.RegularMarketOpen.Raw, price.Currency))
+	fmt.Fprintf(&b, "Day High:        %s\n", fmtPrice(price.RegularMarketDayHigh.Raw, price.Currency))
+	fmt.Fprintf(&b, "Day Low:         %s\n", fmtPrice(price.RegularMarketDayLow.Raw, price.Currency))
+	fmt.Fprintf(&b, "Previous Close:  %s\n", fmtPrice(price.RegularMarketPreviousClose.Raw, price.Currency))
+
+	if detail != nil {
+		if detail.TrailingPE.Raw > 0 {
+			fmt.Fprintf(&b, "P/E Ratio:       %.2f\n", detail.TrailingPE.Raw)
+		}
+		if detail.ForwardPE.Raw > 0 {
+			fmt.Fprintf(&b, "Forward P/E:     %.2f\n", detail.ForwardPE.Raw)
+		}
+		if detail.FiftyTwoWeekLow.Raw > 0 || detail.FiftyTwoWeekHigh.Raw > 0 {
+			fmt.Fprintf(&b, "52-Week Range:   %s - %s\n",
+				fmtPrice(detail.FiftyTwoWeekLow.Raw, price.Currency),
+				fmtPrice(detail.FiftyTwoWeekHigh.Raw, price.Currency))
+		}
+		if detail.DividendYield.Raw > 0 {
+			fmt.Fprintf(&b, "Dividend Yield:  %.2f%%\n", detail.DividendYield.Raw*100)
+		}
+		if detail.Beta.Raw > 0 {
+			fmt.Fprintf(&b, "Beta:            %.2f\n", detail.Beta.Raw)
+		}
+		if detail.FiftyDayAverage.Raw > 0 {
+			fmt.Fprintf(&b, "50-Day Avg:      %s\n", fmtPrice(detail.FiftyDayAverage.Raw, price.Currency))
+		}
+		if detail.TwoHundredDayAverage.Raw > 0 {
+			fmt.Fprintf(&b, "200-Day Avg:     %s\n", fmtPrice(detail.TwoHundredDayAverage.Raw, price.Currency))
+		}
+	}
+
+	// Pre/Post market info
+	if price.MarketState == "PRE" && price.PreMarketPrice.Raw > 0 {
+		fmt.Fprintf(&b, "\n--- Pre-Market ---\n")
+		fmt.Fprintf(&b, "Price:  %s (%.2f, %.2f%%)\n", fmtPrice(price.PreMarketPrice.Raw, price.Currency), price.PreMarketChange.Raw, price.PreMarketChangePercent.Raw)
+	}
+	if price.MarketState == "POST" && price.PostMarketPrice.Raw > 0 {
+		fmt.Fprintf(&b, "\n--- Post-Market ---\n")
+		fmt.Fprintf(&b, "Price:  %s (%.2f, %.2f%%)\n", fmtPrice(price.PostMarketPrice.Raw, price.Currency), price.PostMarketChange.Raw, price.PostMarketChangePercent.Raw)
+	}
+
+	return b.String()
+}
+
+func formatStreamQuotes(note string, ticks []stream.Tick, connected bool, connErr error, resources bool) string {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "=== Streaming Quotes ===\n")
+	if note != "" {
+		fmt.Fprintf(&b, "%s\n", note)
+	}
+	switch {
+	case len(ticks) == 0:
+		fmt.Fprintf(&b, "\nNo subscriptions. Subscribe to symbols to stream their prices.\n")
+		return b.String()
+	case connected:
+		fmt.Fprintf(&b, "Status: connected\n")
+	case connErr != nil:
+		fmt.Fprintf(&b, "Status: reconnecting (last error: %v)\n", connErr)
+	default:
+		fmt.Fprintf(&b, "Status: connecting\n")
+	}
+
+	fmt.Fprintf(&b, "\n%-10s %12s %8s %14s %12s %12s  %-8s %s\n", "Symbol", "Price", "Chg%", "Volume", "Bid", "Ask", "Time", "Session")
+	fmt.Fprintf(&b, "%s\n", strings.Repeat("-", 100))
+	for _, t := range ticks {
+		if t.Time.IsZero() {
+			fmt.Fprintf(&b, "%-10s waiting for first tick\n", t.Symbol)
+			continue
+		}
+		bid, ask := "N/A", "N/A"
+		if t.Bid > 0 {
+			bid = fmt.Sprintf("%.2f", t.Bid)
+		}
+		if t.Ask > 0 {
+			ask = fmt.Sprintf("%.2f", t.Ask)
+		}
+		fmt.Fprintf(&b, "%-10s %12.2f %+7.2f%% %14s %12s %12s  %-8s %s\n",
+			t.Symbol, t.Price, t.ChangePercent, fmtInt(t.DayVolume), bid, ask,
+			t.Time.Format("15:04:05"), fmtMarketHours(t.MarketHours))
+	}
+
+	fmt.Fprintf(&b, "\nTimes are UTC. get_quote uses the latest tick of subscribed symbols.\n")
+	if resources {
+		fmt.Fprintf(&b, "Resources: %s{symbol}, with update notifications for subscribers\n", streamURIPrefix)
+	}
+
+	return b.String()
+}
+
+func formatTick(t stream.Tick) string {
+	var b strings.Builder
+
+	name := t.ShortName
+	if name == "" {
+		name = t.Symbol
+	}
+	fmt.Fprintf(&b, "=== %s (%s) Live Quote ===\n", t.Symbol, name)
+	fmt.Fprintf(&b, "Exchange: %s | Currency: %s | Session: %s\n\n", t.Exchange, t.Currency, fmtMarketHours(t.MarketHours))
+
+	fmt.Fprintf(&b, "Price:           %s\n", fmtPrice(t.Price, t.Currency))
+	fmt.Fprintf(&b, "Change:          %+.2f (%+.2f%%)\n", t.Change, t.ChangePercent)
+	if t.DayVolume > 0 {
+		fmt.Fprintf(&b, "Volume:          %s\n", fmtInt(t.DayVolume))
+	}
+	if t.DayHigh > 0 || t.DayLow > 0 {
+		fmt.Fprintf(&b, "Day Range:       %s - %s\n", fmtPrice(t.DayLow, t.Currency), fmtPrice(t.DayHigh, t.Currency))
+	}
+	if t.Bid > 0 || t.Ask > 0 {
+		fmt.Fprintf(&b, "Bid / Ask:       %s x %d / %s x %d\n", fmtPrice(t.Bid, t.Currency), t.BidSize, fmtPrice(t.Ask, t.Currency), t.AskSize)
+	}
+	if t.MarketCap > 0 {
+		fmt.Fprintf(&b, "Market Cap:      %s\n", fmtMarketCap(t.MarketCap, t.Currency))
+	}
+	fmt.Fprintf(&b, "Time:            %s\n", t.Time.Format("2006-01-02 15:04:05 MST"))
+
+	return b.String()
+}
+
+func formatCompanySnapshot(s companySnapshot) string {
+	var b strings.Builder
+
+	var r yahoo.QuoteSummaryResult
+	if s.summary != nil {
+		r = *s.summary
+	}
+	name := ""
+	switch {
+	case r.QuoteType != nil && r.QuoteType.LongName != "":
+		name = r.QuoteType.LongName
+	case r.Price != nil && r.Price.LongName != "":
+		name = r.Price.LongName
+	case r.Price != nil:
+		name = r.Price.ShortName
+	}
+	if name != "" {
+		fmt.Fprintf(&b, "=== %s (%s) Company Snapshot ===\n", s.symbol, name)
+	} else {
+		fmt.Fprintf(&b, "=== %s Company Snapshot ===\n", s.symbol)
+	}
+	if r.Price != nil {
+		fmt.Fprintf(&b, "Exchange: %s | Currency: %s | Market: %s\n", r.Price.ExchangeName, r.Price.Currency, r.Price.MarketState)
+	}
+
+	for _, sec := range snapshotSections {
+		if !s.sections[sec] {
+			continue
+		}
+		title := strings.ToUpper(sec[:1]) + sec[1:]
+		if sec == "financials" {
+			title = "Financials (Annual)"
+			if s.period == yahoo.PeriodQuarterly {
+				title = "Financials (Quarterly)"
+			}
+		}
+		fmt.Fprintf(&b, "\n--- %s ---\n", title)
+		if err := s.errs[sec]; err != nil {
+			fmt.Fprintf(&b, "Unavailable: %v\n", err)
+			continue
+		}
+		switch sec {
+		case "quote":
+			writeSnapshotQuote(&b, r)
+		case "profile":
+			writeSnapshotProfile(&b, r.AssetProfile)
+		case "analysts":
+			writeSnapshotAnalysts(&b, r)
+		case "financials":
+			writeSnapshotFinancials(&b, r.FinancialData, s.financials)
+		case "news":
+			writeSnapshotNews(&b, s.news)
+		}
+	}
+
+	return b.String()
+}
+
+func writeSnapshotQuote(b *strings.Builder, r yahoo.QuoteSummaryResult) {
+	price := r.Price
+	if price == nil {
+		fmt.Fprintf(b, "No price data available\n")
+		return
+	}
+	cur := price.Currency
+
+	fmt.Fprintf(b, "Price: %s (%+.2f, %+.2f%%) | Volume: %s", fmtPrice(price.RegularMarketPrice.Raw, cur),
+		price.RegularMarketChange.Raw, price.RegularMarketChangePercent.Raw, fmtInt(price.RegularMarketVolume.Raw))
+	if price.MarketCap.Raw > 0 {
+		fmt.Fprintf(b, " | Market Cap: %s", fmtMarketCap(float64(price.MarketCap.Raw), cur))
+	}
+	fmt.Fprintln(b)
+
+	var valuation []string
+	if d := r.SummaryDetail; d != nil {
+		if d.TrailingPE.Raw > 0 {
+			valuation = append(valuation, fmt.Sprintf("P/E %.2f", d.TrailingPE.Raw))
+		}
+		if d.ForwardPE.Raw > 0 {
+			valuation = append(valuation, fmt.Sprintf("Fwd P/E %.2f", d.ForwardPE.Raw))
+		}
+	}
+	if k := r.DefaultKeyStatistics; k != nil {
+		if k.EnterpriseToEbitda.Raw > 0 {
+			valuation = append(valuation, fmt.Sprintf("EV/EBITDA %.2f", k.EnterpriseToEbitda.Raw))
+		}
+		if k.PriceToBook.Raw > 0 {
+			valuation = append(valuation, fmt.Sprintf("P/B %.2f", k.PriceToBook.Raw))
+		}
+		if k.PegRatio.Raw > 0 {
+			valuation = append(valuation, fmt.Sprintf("PEG %.2f", k.PegRatio.Raw))
+		}
+	}
+	if len(valuation) > 0 {
+		fmt.Fprintf(b, "Valuation: %s\n", strings.Join(valuation, " | "))
+	}
+
+	d := r.SummaryDetail
+	if d == nil {
+		return
+	}
+	if d.FiftyTwoWeekLow.Raw > 0 || d.FiftyTwoWeekHigh.Raw > 0 {
+		fmt.Fprintf(b, "52-Week Range: %s - %s", fmtPrice(d.FiftyTwoWeekLow.Raw, cur), fmtPrice(d.FiftyTwoWeekHigh.Raw, cur))
+		if k := r.DefaultKeyStatistics; k != nil && k.FiftyTwoWeekChange.Fmt != "" {
+			fmt.Fprintf(b, " (1-year change %+.1f%%)", k.FiftyTwoWeekChange.Raw*100)
+		}
+		fmt.Fprintln(b)
+	}
+	var other []string
+	if d.DividendYield.Raw > 0 {
+		other = append(other, fmt.Sprintf("Dividend Yield %.2f%%", d.DividendYield.Raw*100))
+	}
+	if d.Beta.Raw > 0 {
+		other = append(other, fmt.Sprintf("Beta %.2f", d.Beta.Raw))
+	}
+	if d.TwoHundredDayAverage.Raw > 0 {
+		other = append(other, fmt.Sprintf("200-Day Avg %s", fmtPrice(d.TwoHundredDayAverage.Raw, cur)))
+	}
+	if len(other) > 0 {
+		fmt.Fprintf(b, "%s\n", strings.Join(other, " | "))
+	}
+}
+
+func writeSnapshotProfile(b *strings.Builder, p *yahoo.AssetProfileData) {
+	if p == nil {
+		fmt.Fprintf(b, "No profile data available\n")
+		return
+	}
+	var facts []string
+	if p.Sector != "" {
+		facts = append(facts, "Sector: "+p.Sector)
+	}
+	if p.Industry != "" {
+		facts = append(facts, "Industry: "+p.Industry)
+	}
+	if p.FullTimeEmployees > 0 {
+		facts = append(facts, "Employees: "+fmtInt(int64(p.FullTimeEmployees)))
+	}
+	if p.Country != "" {
+		facts = append(facts, "Country: "+p.Country)
+	}
+	if len(facts) > 0 {
+		fmt.Fprintf(b, "%s\n", strings.Join(facts, " | "))
+	}
+	if p.Website != "" {
+		fmt.Fprintf(b, "Website: %s\n", p.Website)
+	}
+	for _, o := range p.CompanyOfficers {
+		if strings.Contains(o.Title, "CEO") || strings.Contains(o.Title, "Chief Executive") {
+			fmt.Fprintf(b, "CEO: %s\n", o.Name)
+			break
+		}
+	}
+	if p.LongBusinessSummary != "" {
+		fmt.Fprintf(b, "%s\n", truncate(p.LongBusinessSummary, 400))
+	}
+}
+
+func writeSnapshotAnalysts(b *strings.Builder, r yahoo.QuoteSummaryResult) {
+	fin := r.FinancialData
+	written := false
+	if fin != nil && fin.RecommendationKey != "" && fin.RecommendationKey != "none" {
+		fmt.Fprintf(b, "Consensus: %s (mean %.2f, 1=strong buy, 5=sell; %d analysts)\n",
+			fin.RecommendationKey, fin.RecommendationMean.Raw, fin.NumberOfAnalystOpinions.Raw)
+		written = true
+	}
+	if fin != nil && fin.TargetMeanPrice.Raw > 0 {
+		fmt.Fprintf(b, "Price Target: mean %.2f", fin.TargetMeanPrice.Raw)
+		if fin.CurrentPrice.Raw > 0 {
+			fmt.Fprintf(b, " (%+.1f%% vs current)", (fin.TargetMeanPrice.Raw-fin.CurrentPrice.Raw)/fin.CurrentPrice.Raw*100)
+		}
+		fmt.Fprintf(b, " | range %.2f - %.2f\n", fin.TargetLowPrice.Raw, fin.TargetHighPrice.Raw)
+		written = true
+	}
+
+	if trend := r.RecommendationTrend; trend != nil && len(trend.Trend) > 0 {
+		t := trend.Trend[0]
+		fmt.Fprintf(b, "Ratings (%s): %d strong buy, %d buy, %d hold, %d sell, %d strong sell\n",
+			t.Period, t.StrongBuy, t.Buy, t.Hold, t.Sell, t.StrongSell)
+		bullish := func(t yahoo.RecommendationTrend) (float64, bool) {
+			total := t.StrongBuy + t.Buy + t.Hold + t.Sell + t.StrongSell
+			if total == 0 {
+				return 0, false
+			}
+			return float64(t.StrongBuy+t.Buy) / float64(total) * 100, true
+		}
+		if now, ok := bullish(t); ok {
+			fmt.Fprintf(b, "Bullish: %.0f%%", now)
+			for _, prev := range trend.Trend[1:] {
+				if prev.Period != "-3m" {
+					continue
+				}
+				if then, ok := bullish(prev); ok {
+					fmt.Fprintf(b, " (%.0f%% three months ago)", then)
+				}
+			}
+			fmt.Fprintln(b)
+		}
+		written = true
+	}
+
+	if !written {
+		fmt.Fprintf(b, "No analyst coverage available\n")
+	}
+}
+
+func writeSnapshotFinancials(b *strings.Builder, fin *yahoo.FinancialDataModule, table *yahoo.StatementTable) {
+	if fin != nil {
+		var metrics []string
+		add := func(label string, v yahoo.YahooValue) {
+			if v.Fmt != "" {
+				metrics = append(metrics, fmt.Sprintf("%s %.1f%%", label, v.Raw*100))
+			}
+		}
+		add("Revenue Growth", fin.RevenueGrowth)
+		add("Earnings Growth", fin.EarningsGrowth)
+		add("Gross Margin", fin.GrossMargins)
+		add("Operating Margin", fin.OperatingMargins)
+		add("Net Margin", fin.ProfitMargins)
+		add("ROE", fin.ReturnOnEquity)
+		if fin.DebtToEquity.Fmt != "" {
+			metrics = append(metrics, fmt.Sprintf("Debt/Equity %.2f", fin.DebtToEquity.Raw/100))
+		}
+		if len(metrics) > 0 {
+			fmt.Fprintf(b, "%s\n\n", strings.Join(metrics, " | "))
+		}
+	}
+
+	if table == nil || len(table.Dates) == 0 {
+		fmt.Fprintf(b, "No financial data available\n")
+		return
+	}
+	dates := table.Dates
+	if len(dates) > 4 {
+		dates = dates[:4]
+	}
+	if table.Currency != "" {
+		fmt.Fprintf(b, "Currency: %s\n", table.Currency)
+	}
+	fmt.Fprintf(b, "%-20s", "Line Item")
+	for _, d := range dates {
+		fmt.Fprintf(b, " %12s", d)
+	}
+	fmt.Fprintln(b)
+	for _, item := range snapshotItems {
+		fmt.Fprintf(b, "%-20s", addSpaces(item))
+		for _, d := range dates {
+			if v, ok := table.Value(item, d); ok {
+				fmt.Fprintf(b, " %12s", fmtCompact(v))
+			} else {
+				fmt.Fprintf(b, " %12s", "-")
+			}
+		}
+		fmt.Fprintln(b)
+	}
 }
 
-func formatBulkSpark(symbolOrder []string, results yahoo.SparkResponse) string {
-	if len(results) == 0 {
-		return "No spark data returned"
+func writeSnapshotNews(b *strings.Builder, feed *yahoo.NewsFeed) {
+	if feed == nil || len(feed.Articles) == 0 {
+		fmt.Fprintf(b, "No recent news found\n")
+		return
 	}
+	for i, n := range feed.Articles {
+		fmt.Fprintf(b, "%d. %s | %s | %s\n", i+1, time.Unix(n.ProviderPublishTime, 0).Format("2006-01-02"), n.Publisher, n.Title)
+		if n.Link != "" {
+			fmt.Fprintf(b, "   %s\n", n.Link)
+		}
+	}
+}
 
+// maxModuleListItems caps the array elements formatQuoteSummary lists.
+const maxModuleListItems = 25
+
+func formatQuoteSummary(symbol string, requested []string, result *yahoo.QuoteSummaryResult) string {
 	var b strings.Builder
-	fmt.Fprintf(&b, "=== Bulk Price History (%d symbols) ===\n", len(results))
 
-	for _, sym := range symbolOrder {
-		sr, ok := results[sym]
-		if !ok {
-			fmt.Fprintf(&b, "\n--- %s: no data ---\n", sym)
-			continue
-		}
+	fmt.Fprintf(&b, "=== %s Quote Summary ===\n", symbol)
 
-		granularity := fmt.Sprintf("%ds", sr.DataGranularity)
-		if sr.DataGranularity >= 86400 {
-			granularity = fmt.Sprintf("%dd", sr.DataGranularity/86400)
-		} else if sr.DataGranularity >= 3600 {
-			granularity = fmt.Sprintf("%dh", sr.DataGranularity/3600)
-		} else if sr.DataGranularity >= 60 {
-			granularity = fmt.Sprintf("%dm", sr.DataGranularity/60)
+	// Yahoo answers with the canonical module names; list them in the
+	// order they were asked for.
+	returned := result.Modules()
+	var names []string
+	for _, r := range requested {
+		for _, name := range returned {
+			if strings.EqualFold(r, name) && !slices.Contains(names, name) {
+				names = append(names, name)
+			}
 		}
-		fmt.Fprintf(&b, "\n--- %s (interval: %s) ---\n", sr.Symbol, granularity)
-		if sr.ChartPreviousClose > 0 {
-			fmt.Fprintf(&b, "Previous Close: %.2f\n", sr.ChartPreviousClose)
+	}
+	for _, name := range returned {
+		if !slices.Contains(names, name) {
+			names = append(names, name)
 		}
+	}
 
-		n := len(sr.Timestamps)
-		if n == 0 {
-			fmt.Fprintf(&b, "No data points\n")
-			continue
+	for _, name := range names {
+		raw, _ := result.Module(name)
+		fmt.Fprintf(&b, "\n--- %s ---\n", name)
+		before := b.Len()
+		writeJSONFields(&b, raw, "")
+		if b.Len() == before {
+			fmt.Fprintf(&b, "No data\n")
 		}
+	}
 
-		// Cap at 50 rows per symbol to keep output manageable
-		step := 1
-		if n > 50 {
-			step = n / 50
+	var missing []string
+	for _, r := range requested {
+		found := false
+		for _, name := range returned {
+			found = found || strings.EqualFold(r, name)
 		}
-
-		fmt.Fprintf(&b, "%-20s %10s %10s\n", "Date", "Close", "Change%")
-		for i := 0; i < n; i += step {
-			ts := time.Unix(sr.Timestamps[i], 0)
-			close := sr.Close[i]
-			chgPct := 0.0
-			if sr.ChartPreviousClose > 0 {
-				chgPct = (close - sr.ChartPreviousClose) / sr.ChartPreviousClose * 100
-			}
-			sign := "+"
-			if chgPct < 0 {
-				sign = ""
-			}
-			fmt.Fprintf(&b, "%-20s %10.2f %s%8.2f%%\n", ts.Format("2006-01-02 15:04"), close, sign, chgPct)
+		if !found {
+			missing = append(missing, r)
 		}
-		fmt.Fprintf(&b, "(%d of %d data points)\n", min(n, 50), n)
+	}
+	if len(missing) > 0 {
+		fmt.Fprintf(&b, "\nNot returned by Yahoo: %s\n", strings.Join(missing, ", "))
 	}
 
 	return b.String()
 }
 
-func formatQuote(price *yahoo.PriceData, detail *yahoo.SummaryDetailData) string {
-	if price == nil {
-		return "No price data available"
+func formatQuoteSummaryJSON(result *yahoo.QuoteSummaryResult) (string, error) {
+	modules := make(map[string]json.RawMessage)
+	for _, name := range result.Modules() {
+		modules[name], _ = result.Module(name)
 	}
-
-	var b strings.Builder
-	name := price.LongName
-	if name == "" {
-		name = price.ShortName
+	out, err := json.MarshalIndent(modules, "", "  ")
+	if err != nil {
+		return "", err
 	}
-	fmt.Fprintf(&b, "=== %s (%s) ===\n", price.Symbol, name)
-	fmt.Fprintf(&b, "Exchange: %s | Currency: %s | Market: %s\n\n", price.ExchangeName, price.Currency, price.MarketState)
+	return string(out) + "\n", nil
+}
 
-	fmt.Fprintf(&b, "Price:           %s\n", fmtPrice(price.RegularMarketPrice.Raw, price.Currency))
-	changeSign := "+"
-	if price.RegularMarketChange.Raw < 0 {
-		changeSign = ""
+// writeJSONFields writes a JSON object as "key: value" lines in its own
+// field order, indenting nested objects. Yahoo's {raw, fmt} wrappers print
+// their formatted value, maxAge and empty values are skipped, and arrays of
+// objects print one line per element.
+func writeJSONFields(b *strings.Builder, raw json.RawMessage, indent string) {
+	fields, ok := jsonObject(raw)
+	if !ok {
+		if v, ok := jsonScalar(raw); ok && v != "" {
+			fmt.Fprintf(b, "%s%s\n", indent, v)
+		}
+		return
 	}
-	fmt.Fprintf(&b, "Change:          %s%.2f (%s%.2f%%)\n", changeSign, price.RegularMarketChange.Raw, changeSign, price.RegularMarketChangePercent.Raw)
-	fmt.Fprintf(&b, "Volume:          %s\n", fmtInt(price.RegularMarketVolume.Raw))
-
-	if price.MarketCap.Raw > 0 {
-		fmt.Fprintf(&b, "Market Cap:      %s\n", fmtLargeNumber(float64(price.MarketCap.Raw)))
+	for _, f := range fields {
+		if f.key == "maxAge" {
+			continue
+		}
+		if v, ok := jsonScalar(f.value); ok {
+			if v != "" {
+				fmt.Fprintf(b, "%s%s: %s\n", indent, f.key, v)
+			}
+			continue
+		}
+		if elems, ok := jsonArray(f.value); ok {
+			writeJSONArray(b, f.key, elems, indent)
+			continue
+		}
+		var nested strings.Builder
+		writeJSONFields(&nested, f.value, indent+"  ")
+		if nested.Len() > 0 {
+			fmt.Fprintf(b, "%s%s:\n%s", indent, f.key, nested.String())
+		}
 	}
+}
 
-	fmt.Fprintf(&b, "Open:            %s\n", fmtPrice(price.RegularMarketOpen.Raw, price.Currency))
-	fmt.Fprintf(&b, "Day High:        %s\n", fmtPrice(price.RegularMarketDayHigh.Raw, price.Currency))
-	fmt.Fprintf(&b, "Day Low:         %s\n", fmtPrice(price.RegularMarketDayLow.Raw, price.Currency))
-	fmt.Fprintf(&b, "Previous Close:  %s\n", fmtPrice(price.RegularMarketPreviousClose.Raw, price.Currency))
+func writeJSONArray(b *strings.Builder, key string, elems []json.RawMessage, indent string) {
+	var scalars []string
+	allScalar := true
+	for _, e := range elems {
+		v, ok := jsonScalar(e)
+		if !ok {
+			allScalar = false
+			break
+		}
+		if v != "" {
+			scalars = append(scalars, v)
+		}
+	}
+	if allScalar {
+		if len(scalars) > 0 {
+			fmt.Fprintf(b, "%s%s: %s\n", indent, key, strings.Join(scalars, ", "))
+		}
+		return
+	}
 
-	if detail != nil {
-		if detail.TrailingPE.Raw > 0 {
-			fmt.Fprintf(&b, "P/E Ratio:       %.2f\n", detail.TrailingPE.Raw)
+	fmt.Fprintf(b, "%s%s:\n", indent, key)
+	for i, e := range elems {
+		if i == maxModuleListItems {
+			fmt.Fprintf(b, "%s  ... and %d more\n", indent, len(elems)-i)
+			break
 		}
-		if detail.ForwardPE.Raw > 0 {
-			fmt.Fprintf(&b, "Forward P/E:     %.2f\n", detail.ForwardPE.Raw)
+		var pairs []string
+		jsonPairs("", e, &pairs)
+		fmt.Fprintf(b, "%s  - %s\n", indent, strings.Join(pairs, " | "))
+	}
+}
+
+// jsonPairs flattens a JSON value into "path: value" pairs.
+func jsonPairs(prefix string, raw json.RawMessage, out *[]string) {
+	if v, ok := jsonScalar(raw); ok {
+		if v != "" {
+			if prefix == "" {
+				*out = append(*out, v)
+			} else {
+				*out = append(*out, prefix+": "+v)
+			}
 		}
-		if detail.FiftyTwoWeekLow.Raw > 0 || detail.FiftyTwoWeekHigh.Raw > 0 {
-			fmt.Fprintf(&b, "52-Week Range:   %s - %s\n",
-				fmtPrice(detail.FiftyTwoWeekLow.Raw, price.Currency),
-				fmtPrice(detail.FiftyTwoWeekHigh.Raw, price.Currency))
+		return
+	}
+	if elems, ok := jsonArray(raw); ok {
+		for i, e := range elems {
+			jsonPairs(fmt.Sprintf("%s[%d]", prefix, i), e, out)
 		}
-		if detail.DividendYield.Raw > 0 {
-			fmt.Fprintf(&b, "Dividend Yield:  %.2f%%\n", detail.DividendYield.Raw*100)
+		return
+	}
+	fields, _ := jsonObject(raw)
+	for _, f := range fields {
+		if f.key == "maxAge" {
+			continue
 		}
-		if detail.Beta.Raw > 0 {
-			fmt.Fprintf(&b, "Beta:            %.2f\n", detail.Beta.Raw)
+		key := f.key
+		if prefix != "" {
+			key = prefix + "." + key
 		}
-		if detail.FiftyDayAverage.Raw > 0 {
-			fmt.Fprintf(&b, "50-Day Avg:      %s\n", fmtPrice(detail.FiftyDayAverage.Raw, price.Currency))
+		jsonPairs(key, f.value, out)
+	}
+}
+
+type jsonField struct {
+	key   string
+	value json.RawMessage
+}
+
+// jsonObject splits a JSON object into its fields, keeping their order.
+func jsonObject(raw json.RawMessage) ([]jsonField, bool) {
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	if t, err := dec.Token(); err != nil || t != json.Delim('{') {
+		return nil, false
+	}
+	var fields []jsonField
+	for dec.More() {
+		t, err := dec.Token()
+		if err != nil {
+			return nil, false
 		}
-		if detail.TwoHundredDayAverage.Raw > 0 {
-			fmt.Fprintf(&b, "200-Day Avg:     %s\n", fmtPrice(detail.TwoHundredDayAverage.Raw, price.Currency))
+		key, _ := t.(string)
+		var v json.RawMessage
+		if err := dec.Decode(&v); err != nil {
+			return nil, false
 		}
+		fields = append(fields, jsonField{key, v})
 	}
+	return fields, true
+}
 
-	// Pre/Post market info
-	if price.MarketState == "PRE" && price.PreMarketPrice.Raw > 0 {
-		fmt.Fprintf(&b, "\n--- Pre-Market ---\n")
-		fmt.Fprintf(&b, "Price:  %s (%.2f, %.2f%%)\n", fmtPrice(price.PreMarketPrice.Raw, price.Currency), price.PreMarketChange.Raw, price.PreMarketChangePercent.Raw)
+func jsonArray(raw json.RawMessage) ([]json.RawMessage, bool) {
+	var elems []json.RawMessage
+	if len(bytes.TrimSpace(raw)) == 0 || bytes.TrimSpace(raw)[0] != '[' {
+		return nil, false
 	}
-	if price.MarketState == "POST" && price.PostMarketPrice.Raw > 0 {
-		fmt.Fprintf(&b, "\n--- Post-Market ---\n")
-		fmt.Fprintf(&b, "Price:  %s (%.2f, %.2f%%)\n", fmtPrice(price.PostMarketPrice.Raw, price.Currency), price.PostMarketChange.Raw, price.PostMarketChangePercent.Raw)
+	if err := json.Unmarshal(raw, &elems); err != nil {
+		return nil, false
 	}
+	return elems, true
+}
 
-	return b.String()
+// jsonScalar renders a JSON value that prints on one line: strings, numbers,
+// booleans, null and empty objects (as ""), and Yahoo's {raw, fmt, longFmt}
+// value wrappers.
+func jsonScalar(raw json.RawMessage) (string, bool) {
+	raw = bytes.TrimSpace(raw)
+	if len(raw) == 0 {
+		return "", true
+	}
+	switch raw[0] {
+	case '[':
+		return "", false
+	case '{':
+		fields, ok := jsonObject(raw)
+		if !ok {
+			return "", false
+		}
+		wrapped := make(map[string]json.RawMessage)
+		for _, f := range fields {
+			if f.key != "raw" && f.key != "fmt" && f.key != "longFmt" {
+				return "", false
+			}
+			wrapped[f.key] = f.value
+		}
+		for _, key := range []string{"fmt", "longFmt", "raw"} {
+			if v, _ := jsonScalar(wrapped[key]); v != "" {
+				return v, true
+			}
+		}
+		return "", true // {} or an empty wrapper
+	case '"':
+		var s string
+		if err := json.Unmarshal(raw, &s); err != nil {
+			return "", false
+		}
+		return strings.TrimSpace(s), true
+	case 'n':
+		return "", true
+	}
+	return string(raw), true
 }
 
 func formatChart(chart *yahoo.ChartResult) string {
@@ -480,10 +2622,10 @@ func formatChart(chart *yahoo.ChartResult) string {
 	return b.String()
 }
 
-func formatSearch(results *yahoo.SearchResponse) string {
+func formatSearch(results *yahoo.SearchResponse, quotes map[string]yahoo.BulkQuoteResult) string {
 	var b strings.Builder
 
-	if len(results.Quotes) == 0 {
+	if len(results.Quotes) == 0 && len(results.News) == 0 && len(results.Lists) == 0 {
 		return "No results found"
 	}
 
@@ -494,54 +2636,355 @@ func formatSearch(results *yahoo.SearchResponse) string {
 		if name == "" {
 			name = q.ShortName
 		}
+		exchange := q.Exchange
+		if q.ExchDisp != "" && q.ExchDisp != q.Exchange {
+			exchange = fmt.Sprintf("%s (%s)", q.ExchDisp, q.Exchange)
+		}
 		fmt.Fprintf(&b, "%d. %s - %s\n", i+1, q.Symbol, name)
-		fmt.Fprintf(&b, "   Exchange: %s | Type: %s\n", q.Exchange, q.QuoteType)
+		fmt.Fprintf(&b, "   Exchange: %s | Type: %s\n", exchange, q.QuoteType)
 		if q.Sector != "" {
 			fmt.Fprintf(&b, "   Sector: %s | Industry: %s\n", q.Sector, q.Industry)
 		}
+		if bq, ok := quotes[q.Symbol]; ok {
+			fmt.Fprintf(&b, "   Price: %s (%+.2f%%)", fmtPrice(bq.RegularMarketPrice, bq.Currency), bq.RegularMarketChangePercent)
+			if bq.MarketCap > 0 {
+				fmt.Fprintf(&b, " | Market Cap: %s", fmtMarketCap(float64(bq.MarketCap), bq.Currency))
+			}
+			fmt.Fprintln(&b)
+		}
 		if i < len(results.Quotes)-1 {
 			fmt.Fprintln(&b)
 		}
 	}
 
+	if len(results.News) > 0 {
+		fmt.Fprintf(&b, "\n--- News ---\n")
+		for _, n := range results.News {
+			published := ""
+			if n.ProviderPublishTime > 0 {
+				published = time.Unix(n.ProviderPublishTime, 0).UTC().Format("2006-01-02") + " "
+			}
+			fmt.Fprintf(&b, "%s%s (%s)\n", published, n.Title, n.Publisher)
+			if n.Link != "" {
+				fmt.Fprintf(&b, "  %s\n", n.Link)
+			}
+		}
+	}
+
+	if len(results.Lists) > 0 {
+		fmt.Fprintf(&b, "\n--- Lists ---\n")
+		for _, l := range results.Lists {
+			fmt.Fprintf(&b, "%s", l.Name)
+			if l.Slug != "" {
+				fmt.Fprintf(&b, " (%s)", l.Slug)
+			}
+			if l.FollowerCount > 0 {
+				fmt.Fprintf(&b, " - %s followers", fmtInt(l.FollowerCount))
+			}
+			fmt.Fprintln(&b)
+		}
+	}
+
+	return b.String()
+}
+
+func formatFinancials(symbol, statement, period string, results []yahoo.FinancialResult) string {
+	var b strings.Builder
+
+	periodTitle := "Annual"
+	switch strings.ToLower(period) {
+	case yahoo.PeriodQuarterly:
+		periodTitle = "Quarterly"
+	case yahoo.PeriodTrailing, "ttm":
+		periodTitle = "Trailing Twelve Months"
+	}
+
+	stmtTitle := "Selected Items"
+	if statement != "" {
+		stmtTitle = strings.ToUpper(statement[:1]) + statement[1:]
+	}
+	fmt.Fprintf(&b, "=== %s %s Financial Data (%s) ===\n\n", symbol, periodTitle, stmtTitle)
+
+	if len(results) == 0 {
+		fmt.Fprintf(&b, "No financial data available\n")
+		return b.String()
+	}
+
+	table := yahoo.NewStatementTable(results)
+
+	// Cap columns so wide quarterly histories stay readable
+	dates := table.Dates
+	if len(dates) > 8 {
+		dates = dates[:8]
+	}
+
+	if table.Currency != "" {
+		fmt.Fprintf(&b, "Currency: %s\n\n", table.Currency)
+	}
+
+	fmt.Fprintf(&b, "%-40s", "Line Item")
+	for _, d := range dates {
+		fmt.Fprintf(&b, " %12s", d)
+	}
+	fmt.Fprintf(&b, "\n%s\n", strings.Repeat("-", 40+13*len(dates)))
+
+	for _, item := range table.Items {
+		fmt.Fprintf(&b, "%-40s", truncate(addSpaces(item), 39))
+		for _, d := range dates {
+			v, ok := table.Value(item, d)
+			if !ok {
+				fmt.Fprintf(&b, " %12s", "-")
+				continue
+			}
+			fmt.Fprintf(&b, " %12s", fmtCompact(v))
+		}
+		fmt.Fprintln(&b)
+	}
+
+	if len(table.Dates) > len(dates) {
+		fmt.Fprintf(&b, "\n(showing %d most recent of %d periods)\n", len(dates), len(table.Dates))
+	}
+
+	return b.String()
+}
+
+func formatFinancialRatios(symbol, period string, table *yahoo.StatementTable, metrics []ratios.Metric, growth []ratios.Growth) string {
+	var b strings.Builder
+
+	periodTitle := "Annual"
+	if period == yahoo.PeriodQuarterly {
+		periodTitle = "Quarterly"
+	}
+	fmt.Fprintf(&b, "=== %s %s Financial Ratios ===\n\n", symbol, periodTitle)
+
+	if len(table.Dates) == 0 {
+		fmt.Fprintf(&b, "No financial data available\n")
+		return b.String()
+	}
+
+	dates := table.Dates
+	if len(dates) > 6 {
+		dates = dates[:6]
+	}
+
+	header := func(label string) {
+		fmt.Fprintf(&b, "%-26s", label)
+		for _, d := range dates {
+			fmt.Fprintf(&b, " %11s", d)
+		}
+		fmt.Fprintf(&b, "\n%s\n", strings.Repeat("-", 26+12*len(dates)))
+	}
+
+	fmt.Fprintf(&b, "--- Ratios ---\n")
+	header("Ratio")
+	for _, m := range metrics {
+		fmt.Fprintf(&b, "%-26s", m.Name)
+		for _, d := range dates {
+			v, ok := m.Values[d]
+			switch {
+			case !ok:
+				fmt.Fprintf(&b, " %11s", "-")
+			case isMultipleRatio(m.Name):
+				fmt.Fprintf(&b, " %10.2fx", v)
+			default:
+				fmt.Fprintf(&b, " %10.1f%%", v*100)
+			}
+		}
+		fmt.Fprintln(&b)
+	}
+
+	fmt.Fprintf(&b, "\n--- YoY Growth ---\n")
+	header("Line Item")
+	for _, g := range growth {
+		if g.Item == "TaxRateForCalcs" {
+			continue
+		}
+		writeGrowthRow(&b, g.Item, g.YoY, dates)
+	}
+
+	if period == yahoo.PeriodQuarterly {
+		fmt.Fprintf(&b, "\n--- QoQ Growth ---\n")
+		header("Line Item")
+		for _, g := range growth {
+			if g.Item == "TaxRateForCalcs" {
+				continue
+			}
+			writeGrowthRow(&b, g.Item, g.QoQ, dates)
+		}
+	}
+
+	fmt.Fprintf(&b, "\n--- CAGR ---\n")
+	for _, g := range growth {
+		if !g.HasCAGR || g.Item == "TaxRateForCalcs" {
+			continue
+		}
+		fmt.Fprintf(&b, "%-26s %+7.1f%% over %.1f years\n", truncate(addSpaces(g.Item), 25), g.CAGR*100, g.Years)
+	}
+
+	fmt.Fprintf(&b, "\nReturns use period-end balances; growth from a negative base is measured against its absolute value.\n")
+
+	return b.String()
+}
+
+func writeGrowthRow(b *strings.Builder, item string, values map[string]float64, dates []string) {
+	fmt.Fprintf(b, "%-26s", truncate(addSpaces(item), 25))
+	for _, d := range dates {
+		if v, ok := values[d]; ok {
+			fmt.Fprintf(b, " %+10.1f%%", v*100)
+		} else {
+			fmt.Fprintf(b, " %11s", "-")
+		}
+	}
+	fmt.Fprintln(b)
+}
+
+// isMultipleRatio reports whether a ratio is shown as a multiple (x) rather than a percentage.
+func isMultipleRatio(name string) bool {
+	switch name {
+	case ratios.CurrentRatio, ratios.QuickRatio, ratios.DebtToEquity, ratios.InterestCoverage:
+		return true
+	}
+	return false
+}
+
+func formatQualityScores(symbol string, scores []scoring.Score, notes []string) string {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "=== %s Quality Scores ===\n", symbol)
+
+	for _, s := range scores {
+		fmt.Fprintf(&b, "\n--- %s: %.2f (%s) ---\n", s.Name, s.Value, s.Interpretation)
+		if s.PriorDate != "" {
+			fmt.Fprintf(&b, "Fiscal year %s vs %s\n", s.Date, s.PriorDate)
+		} else {
+			fmt.Fprintf(&b, "Fiscal year %s\n", s.Date)
+		}
+		fmt.Fprintf(&b, "  %-34s %10s %8s  %s\n", "Component", "Value", "Points", "Inputs")
+		for _, c := range s.Components {
+			if !c.OK {
+				fmt.Fprintf(&b, "  %-34s %10s %8s  missing inputs\n", c.Name, "-", "-")
+				continue
+			}
+			fmt.Fprintf(&b, "  %-34s %10.4f %8.3f  %s\n", c.Name, c.Value, c.Points, c.Detail)
+		}
+	}
+
+	if len(notes) > 0 {
+		fmt.Fprintln(&b)
+		for _, n := range notes {
+			fmt.Fprintf(&b, "Note: %s\n", n)
+		}
+	}
+
+	fmt.Fprintf(&b, "\nF-score: 8-9 strong, 0-2 weak. Z-score: >%.2f safe, %.2f-%.2f grey, <%.2f distress. M-score: >%.2f suggests likely manipulation.\n",
+		scoring.AltmanSafe, scoring.AltmanDistress, scoring.AltmanSafe, scoring.AltmanDistress, scoring.BeneishThreshold)
+
 	return b.String()
 }
 
-func formatFinancials(symbol, statement string, quarterly bool, results []yahoo.FinancialResult) string {
+// dcfAssumptions records where each DCF input came from.
+type dcfAssumptions struct {
+	history      valuation.History
+	price        *yahoo.PriceData
+	growthSource string
+	wacc         *valuation.WACCResult // nil when the caller set WACC
+	waccInputs   valuation.WACCInputs
+	ratesSymbol  string
+	notes        []string
+}
+
+func formatDCFValuation(symbol string, a dcfAssumptions, r valuation.Result, grid valuation.Sensitivity) string {
 	var b strings.Builder
+	in := r.Inputs
 
-	period := "Annual"
-	if quarterly {
-		period = "Quarterly"
+	fmt.Fprintf(&b, "=== %s Discounted Cash Flow Valuation ===\n", symbol)
+	if a.history.Currency != "" {
+		fmt.Fprintf(&b, "Currency: %s\n", a.history.Currency)
 	}
 
-	stmtTitle := strings.ToUpper(statement[:1]) + statement[1:]
-	fmt.Fprintf(&b, "=== %s %s Financial Data (%s) ===\n\n", symbol, period, stmtTitle)
+	fmt.Fprintf(&b, "\n--- Free Cash Flow History ---\n")
+	for _, v := range a.history.FCF {
+		fmt.Fprintf(&b, "  %-12s %12s\n", v.Date, fmtCompact(v.Value))
+	}
+	if g, ok := a.history.HistoricalGrowth(); ok {
+		fmt.Fprintf(&b, "  CAGR: %.2f%%\n", g*100)
+	}
 
-	if len(results) == 0 {
-		fmt.Fprintf(&b, "No financial data available\n")
-		return b.String()
+	fmt.Fprintf(&b, "\n--- Assumptions ---\n")
+	fmt.Fprintf(&b, "Base FCF (%s): %s\n", a.history.FCF[0].Date, fmtCompact(in.BaseFCF))
+	fmt.Fprintf(&b, "Growth: %.2f%% for %d years (%s)\n", in.Growth*100, in.Years, a.growthSource)
+	fmt.Fprintf(&b, "Terminal Growth: %.2f%%\n", in.TerminalGrowth*100)
+	if a.wacc != nil {
+		w, wi := a.wacc, a.waccInputs
+		fmt.Fprintf(&b, "WACC: %.2f%% (derived)\n", in.WACC*100)
+		fmt.Fprintf(&b, "  Risk-Free Rate (%s): %.2f%%\n", a.ratesSymbol, wi.RiskFree*100)
+		fmt.Fprintf(&b, "  Cost of Equity: %.2f%% + %.2f beta x %.2f%% ERP = %.2f%%\n", wi.RiskFree*100, wi.Beta, wi.EquityRiskPremium*100, w.CostOfEquity*100)
+		fmt.Fprintf(&b, "  Cost of Debt: (%.2f%% + %.2f%% spread) x (1 - %.1f%% tax) = %.2f%%\n", wi.RiskFree*100, wi.CreditSpread*100, wi.TaxRate*100, w.CostOfDebt*100)
+		fmt.Fprintf(&b, "  Weights: equity %.1f%% (market cap %s), debt %.1f%% (%s)\n", w.EquityWeight*100, fmtCompact(wi.MarketCap), w.DebtWeight*100, fmtCompact(wi.Debt))
+	} else {
+		fmt.Fprintf(&b, "WACC: %.2f%% (caller override)\n", in.WACC*100)
 	}
+	fmt.Fprintf(&b, "Net Debt (%s): %s (debt %s - cash %s)\n", a.history.Date, fmtCompact(in.NetDebt), fmtCompact(a.history.Debt), fmtCompact(a.history.Cash))
+	fmt.Fprintf(&b, "Shares Outstanding: %s\n", fmtCompact(in.Shares))
 
-	for _, r := range results {
-		// Clean up type name for display
-		displayName := r.Type
-		displayName = strings.TrimPrefix(displayName, "annual")
-		displayName = strings.TrimPrefix(displayName, "quarterly")
-		// Add spaces before capital letters
-		displayName = addSpaces(displayName)
+	fmt.Fprintf(&b, "\n--- Projection ---\n")
+	fmt.Fprintf(&b, "  %-6s %12s %10s %12s\n", "Year", "FCF", "Discount", "PV")
+	for _, p := range r.Projections {
+		fmt.Fprintf(&b, "  %-6d %12s %10.4f %12s\n", p.Year, fmtCompact(p.FCF), p.DiscountFactor, fmtCompact(p.PresentValue))
+	}
+
+	fmt.Fprintf(&b, "\n--- Valuation ---\n")
+	fmt.Fprintf(&b, "PV of Projected FCF: %s\n", fmtCompact(r.SumPV))
+	fmt.Fprintf(&b, "Terminal Value: %s (PV %s, %.1f%% of EV)\n", fmtCompact(r.TerminalValue), fmtCompact(r.PVTerminal), r.TerminalShare()*100)
+	fmt.Fprintf(&b, "Enterprise Value: %s\n", fmtCompact(r.EnterpriseValue))
+	fmt.Fprintf(&b, "Equity Value: %s\n", fmtCompact(r.EquityValue))
+	fmt.Fprintf(&b, "Intrinsic Value per Share: %.2f\n", r.PerShare)
+	if a.price != nil && a.price.RegularMarketPrice.Raw > 0 {
+		cur := a.price.RegularMarketPrice.Raw
+		// Statements are in the major unit; a GBp price is in pence.
+		_, factor := fx.Normalize(a.price.Currency)
+		fmt.Fprintf(&b, "Current Price: %s (%+.1f%% upside)\n", fmtPrice(cur, a.price.Currency), (r.PerShare/(cur*factor)-1)*100)
+	}
 
-		fmt.Fprintf(&b, "%s:\n", displayName)
-		for _, item := range r.Items {
-			fmt.Fprintf(&b, "  %s: %s %s\n", item.Date, fmtLargeNumber(item.ReportedValue), item.CurrencyCode)
+	fmt.Fprintf(&b, "\n--- Sensitivity: Value per Share (rows: terminal growth, columns: WACC) ---\n")
+	fmt.Fprintf(&b, "  %8s", "")
+	for _, w := range grid.WACCs {
+		fmt.Fprintf(&b, " %9.2f%%", w*100)
+	}
+	fmt.Fprintln(&b)
+	for i, g := range grid.TerminalGrowths {
+		fmt.Fprintf(&b, "  %7.2f%%", g*100)
+		for _, v := range grid.Values[i] {
+			if math.IsNaN(v) {
+				fmt.Fprintf(&b, " %10s", "n/a")
+				continue
+			}
+			fmt.Fprintf(&b, " %10.2f", v)
 		}
 		fmt.Fprintln(&b)
 	}
 
+	if len(a.notes) > 0 {
+		fmt.Fprintln(&b)
+		for _, n := range a.notes {
+			fmt.Fprintf(&b, "Note: %s\n", n)
+		}
+	}
+
 	return b.String()
 }
 
-func formatOptions(result *yahoo.OptionsResult) string {
+// optionPricingInputs are the market inputs used to price a chain.
+type optionPricingInputs struct {
+	model          pricing.Model
+	rate           float64
+	rateSource     string
+	dividend       float64
+	dividendSource string
+	now            time.Time
+}
+
+func formatOptions(result *yahoo.OptionsResult, in optionPricingInputs, filter options.Filter) string {
 	var b strings.Builder
 
 	fmt.Fprintf(&b, "=== %s Options Chain ===\n", result.UnderlyingSymbol)
@@ -557,7 +3000,7 @@ func formatOptions(result *yahoo.OptionsResult) string {
 			if i > 0 {
 				fmt.Fprintf(&b, ", ")
 			}
-			fmt.Fprintf(&b, "%s", time.Unix(d, 0).Format("2006-01-02"))
+			fmt.Fprintf(&b, "%s", time.Unix(d, 0).UTC().Format("2006-01-02"))
 		}
 		fmt.Fprintln(&b)
 	}
@@ -567,51 +3010,274 @@ func formatOptions(result *yahoo.OptionsResult) string {
 		return b.String()
 	}
 
+	spot := result.Quote.RegularMarketPrice
 	chain := result.Options[0]
-	expDate := time.Unix(chain.ExpirationDate, 0).Format("2006-01-02")
+	expDate := time.Unix(chain.ExpirationDate, 0).UTC().Format("2006-01-02")
+	years := pricing.YearsToExpiry(chain.ExpirationDate, in.now)
+
+	fmt.Fprintf(&b, "Pricing: %s, rate %.2f%% (%s), dividend yield %.2f%% (%s), %.1f days to expiry\n",
+		in.model, in.rate*100, in.rateSource, in.dividend*100, in.dividendSource, math.Max(years, 0)*365)
+
+	writeChainAnalytics(&b, options.Analyze(chain, spot), spot)
+
+	shown := options.FilterChain(chain, spot, filter)
+	if desc := describeOptionFilter(filter); desc != "" {
+		fmt.Fprintf(&b, "\nShowing %d of %d calls and %d of %d puts: %s\n",
+			len(shown.Calls), len(chain.Calls), len(shown.Puts), len(chain.Puts), desc)
+	}
 
-	// Calls
 	fmt.Fprintf(&b, "\n--- CALLS (Exp: %s) ---\n", expDate)
-	fmt.Fprintf(&b, "%-10s %10s %10s %10s %10s %10s %8s\n", "Strike", "Last", "Bid", "Ask", "Volume", "OI", "IV")
-	fmt.Fprintf(&b, "%s\n", strings.Repeat("-", 78))
+	writeOptionRows(&b, shown.Calls, pricing.Call, spot, years, in)
+
+	fmt.Fprintf(&b, "\n--- PUTS (Exp: %s) ---\n", expDate)
+	writeOptionRows(&b, shown.Puts, pricing.Put, spot, years, in)
+
+	fmt.Fprintf(&b, "\n* = In the money\n")
+	fmt.Fprintf(&b, "Theo and Greeks use each contract's implied volatility. Theta is per calendar day; vega and rho are per 1 percentage point.\n")
+
+	return b.String()
+}
+
+func writeChainAnalytics(b *strings.Builder, a options.Analytics, spot float64) {
+	fmt.Fprintf(b, "\n--- Chain Analytics (all strikes) ---\n")
+	fmt.Fprintf(b, "Volume: %s calls / %s puts (put/call %s)\n",
+		fmtInt(int64(a.CallVolume)), fmtInt(int64(a.PutVolume)), fmtRatio(a.PutCallVolume))
+	fmt.Fprintf(b, "Open Interest: %s calls / %s puts (put/call %s)\n",
+		fmtInt(int64(a.CallOpenInterest)), fmtInt(int64(a.PutOpenInterest)), fmtRatio(a.PutCallOI))
+	if a.HasMaxPain {
+		fmt.Fprintf(b, "Max Pain: $%.2f\n", a.MaxPain)
+	}
+	if a.HasStraddle {
+		fmt.Fprintf(b, "Expected Move: ±$%.2f (±%.1f%%, range $%.2f - $%.2f) from the $%.2f straddle\n",
+			a.Straddle, a.ExpectedMovePct*100, spot-a.Straddle, spot+a.Straddle, a.StraddleStrike)
+	}
+
+	if len(a.Unusual) == 0 {
+		return
+	}
+	fmt.Fprintf(b, "Unusual Activity (volume >= %.0fx open interest, min %d contracts):\n",
+		options.UnusualVolumeOIRatio, options.UnusualMinVolume)
+	for _, u := range a.Unusual {
+		kind := "Call"
+		if u.Put {
+			kind = "Put"
+		}
+		ratio := "new"
+		if !math.IsInf(u.Ratio, 1) {
+			ratio = fmt.Sprintf("%.1fx", u.Ratio)
+		}
+		fmt.Fprintf(b, "  %-4s %8.2f  volume %8s  OI %8s  %s\n",
+			kind, u.Contract.Strike, fmtInt(int64(u.Contract.Volume)), fmtInt(int64(u.Contract.OpenInterest)), ratio)
+	}
+}
+
+func describeOptionFilter(f options.Filter) string {
+	var parts []string
+	if f.Strikes > 0 {
+		parts = append(parts, fmt.Sprintf("%d strikes each side of spot", f.Strikes))
+	}
+	if f.MinMoneyness > 0 || f.MaxMoneyness > 0 {
+		lo, hi := "0", "any"
+		if f.MinMoneyness > 0 {
+			lo = fmt.Sprintf("%.0f", f.MinMoneyness*100)
+		}
+		if f.MaxMoneyness > 0 {
+			hi = fmt.Sprintf("%.0f", f.MaxMoneyness*100)
+		}
+		parts = append(parts, fmt.Sprintf("strike %s%%-%s%% of spot", lo, hi))
+	}
+	if f.MinVolume > 0 {
+		parts = append(parts, fmt.Sprintf("volume >= %d", f.MinVolume))
+	}
+	if f.MinOpenInterest > 0 {
+		parts = append(parts, fmt.Sprintf("open interest >= %d", f.MinOpenInterest))
+	}
+	return strings.Join(parts, ", ")
+}
 
-	maxContracts := 25
-	for i, c := range chain.Calls {
+func fmtRatio(v float64) string {
+	if math.IsNaN(v) {
+		return "N/A"
+	}
+	return fmt.Sprintf("%.2f", v)
+}
+
+func writeOptionRows(b *strings.Builder, contracts []yahoo.OptionContract, kind pricing.Kind, spot, years float64, in optionPricingInputs) {
+	fmt.Fprintf(b, "%-10s %9s %9s %9s %8s %8s %7s %9s %7s %7s %8s %7s %7s\n",
+		"Strike", "Last", "Bid", "Ask", "Volume", "OI", "IV", "Theo", "Delta", "Gamma", "Theta", "Vega", "Rho")
+	fmt.Fprintf(b, "%s\n", strings.Repeat("-", 120))
+
+	maxContracts := 50
+	for i, c := range contracts {
 		if i >= maxContracts {
-			fmt.Fprintf(&b, "... and %d more contracts\n", len(chain.Calls)-maxContracts)
+			fmt.Fprintf(b, "... and %d more contracts\n", len(contracts)-maxContracts)
 			break
 		}
 		itm := ""
 		if c.InTheMoney {
 			itm = "*"
 		}
-		fmt.Fprintf(&b, "%-10s %10.2f %10.2f %10.2f %10d %10d %7.1f%%\n",
+		fmt.Fprintf(b, "%-10s %9.2f %9.2f %9.2f %8d %8d %6.1f%%",
 			fmt.Sprintf("%.2f%s", c.Strike, itm), c.LastPrice, c.Bid, c.Ask, c.Volume, c.OpenInterest, c.ImpliedVolatility*100)
+
+		// Yahoo reports near-zero IV for illiquid contracts; Greeks from it are meaningless.
+		if c.ImpliedVolatility < pricing.MinVolatility || spot <= 0 {
+			fmt.Fprintf(b, " %9s %7s %7s %8s %7s %7s\n", "-", "-", "-", "-", "-", "-")
+			continue
+		}
+		g := pricing.Price(in.model, pricing.Params{
+			Kind:     kind,
+			Spot:     spot,
+			Strike:   c.Strike,
+			Years:    years,
+			Rate:     in.rate,
+			Dividend: in.dividend,
+			Vol:      c.ImpliedVolatility,
+		})
+		fmt.Fprintf(b, " %9.2f %7.3f %7.4f %8.3f %7.3f %7.3f\n", g.Value, g.Delta, g.Gamma, g.Theta, g.Vega, g.Rho)
 	}
+}
 
-	// Puts
-	fmt.Fprintf(&b, "\n--- PUTS (Exp: %s) ---\n", expDate)
-	fmt.Fprintf(&b, "%-10s %10s %10s %10s %10s %10s %8s\n", "Strike", "Last", "Bid", "Ask", "Volume", "OI", "IV")
-	fmt.Fprintf(&b, "%s\n", strings.Repeat("-", 78))
+func formatVolatilitySurface(symbol string, s options.Surface, skipped []string) string {
+	var b strings.Builder
 
-	for i, p := range chain.Puts {
-		if i >= maxContracts {
-			fmt.Fprintf(&b, "... and %d more contracts\n", len(chain.Puts)-maxContracts)
-			break
+	fmt.Fprintf(&b, "=== %s Implied Volatility Surface ===\n", symbol)
+	fmt.Fprintf(&b, "Underlying Price: $%.2f\n", s.Spot)
+
+	if len(s.Slices) == 0 {
+		fmt.Fprintf(&b, "\nNo options data available\n")
+		return b.String()
+	}
+
+	fmt.Fprintf(&b, "\n--- IV by Moneyness (strike / spot) ---\n")
+	fmt.Fprintf(&b, "%-12s %6s", "Expiration", "Days")
+	for _, m := range s.Moneyness {
+		fmt.Fprintf(&b, " %6.0f%%", m*100)
+	}
+	fmt.Fprintln(&b)
+	for _, sl := range s.Slices {
+		fmt.Fprintf(&b, "%-12s %6.0f", time.Unix(sl.Expiration, 0).UTC().Format("2006-01-02"), sl.Days)
+		for _, iv := range sl.IVs {
+			fmt.Fprintf(&b, " %7s", fmtVol(iv))
 		}
-		itm := ""
-		if p.InTheMoney {
-			itm = "*"
+		fmt.Fprintln(&b)
+	}
+
+	fmt.Fprintf(&b, "\n--- Term Structure and Skew ---\n")
+	fmt.Fprintf(&b, "%-12s %6s %8s %10s %8s\n", "Expiration", "Days", "ATM IV", "Skew", "Quotes")
+	for _, sl := range s.Slices {
+		skew := "-"
+		if !math.IsNaN(sl.Skew) {
+			skew = fmt.Sprintf("%+.1f pts", sl.Skew*100)
 		}
-		fmt.Fprintf(&b, "%-10s %10.2f %10.2f %10.2f %10d %10d %7.1f%%\n",
-			fmt.Sprintf("%.2f%s", p.Strike, itm), p.LastPrice, p.Bid, p.Ask, p.Volume, p.OpenInterest, p.ImpliedVolatility*100)
+		fmt.Fprintf(&b, "%-12s %6.0f %8s %10s %8d\n",
+			time.Unix(sl.Expiration, 0).UTC().Format("2006-01-02"), sl.Days, fmtVol(sl.ATM), skew, sl.Quotes)
 	}
 
-	fmt.Fprintf(&b, "\n* = In the money\n")
+	if t := s.Term(); t.OK && t.BackDays > t.FrontDays {
+		shape := "contango"
+		if t.Slope < 0 {
+			shape = "backwardation"
+		}
+		fmt.Fprintf(&b, "\nATM term structure: %s front (%.0fd) to %s back (%.0fd), %+.1f pts (%s)\n",
+			fmtVol(t.Front), t.FrontDays, fmtVol(t.Back), t.BackDays, t.Slope*100, shape)
+	}
+
+	fmt.Fprintf(&b, "\nOut-of-the-money contracts only (puts below spot, calls above), linearly interpolated between strikes; no extrapolation.\n")
+	fmt.Fprintf(&b, "Skew = IV at %.0f%% moneyness minus IV at %.0f%%.\n", options.SkewPutWing*100, options.SkewCallWing*100)
+	if len(skipped) > 0 {
+		fmt.Fprintf(&b, "\nSkipped: %s\n", strings.Join(skipped, "; "))
+	}
+
+	return b.String()
+}
+
+func formatOptionStrategy(symbol string, expiration int64, m options.Market, in optionPricingInputs, st options.Strategy) string {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "=== %s Option Strategy (Exp: %s) ===\n", symbol, time.Unix(expiration, 0).UTC().Format("2006-01-02"))
+	fmt.Fprintf(&b, "Underlying Price: $%.2f, %.1f days to expiry\n", m.Spot, math.Max(m.Years, 0)*365)
+	fmt.Fprintf(&b, "Pricing: rate %.2f%% (%s), dividend yield %.2f%% (%s), ATM IV %.1f%%\n",
+		in.rate*100, in.rateSource, in.dividend*100, in.dividendSource, m.Vol*100)
+
+	fmt.Fprintf(&b, "\n--- Legs ---\n")
+	fmt.Fprintf(&b, "%-6s %6s %-6s %10s %10s %8s %12s\n", "Side", "Qty", "Type", "Strike", "Price", "IV", "Cost")
+	for _, l := range st.Legs {
+		side := "Long"
+		if l.Quantity < 0 {
+			side = "Short"
+		}
+		qty := l.Quantity
+		if qty < 0 {
+			qty = -qty
+		}
+		units := float64(l.Quantity)
+		strike, iv := "-", "-"
+		if l.Type != options.LegStock {
+			units *= options.Multiplier
+			strike = fmt.Sprintf("%.2f", l.Strike)
+			iv = fmtVol(l.IV)
+		}
+		fmt.Fprintf(&b, "%-6s %6d %-6s %10s %10.2f %8s %12s\n", side, qty, l.Type, strike, l.Price, iv, fmtSignedDollars(units*l.Price))
+	}
+
+	fmt.Fprintf(&b, "\n--- Profile at Expiration ---\n")
+	if st.NetCost >= 0 {
+		fmt.Fprintf(&b, "Net Debit: %s\n", fmtSignedDollars(st.NetCost))
+	} else {
+		fmt.Fprintf(&b, "Net Credit: %s\n", fmtSignedDollars(-st.NetCost))
+	}
+	if st.UnlimitedProfit {
+		fmt.Fprintf(&b, "Max Profit: Unlimited\n")
+	} else {
+		fmt.Fprintf(&b, "Max Profit: %s\n", fmtSignedDollars(st.MaxProfit))
+	}
+	if st.UnlimitedLoss {
+		fmt.Fprintf(&b, "Max Loss: Unlimited\n")
+	} else {
+		fmt.Fprintf(&b, "Max Loss: %s\n", fmtSignedDollars(st.MaxLoss))
+	}
+	if len(st.Breakevens) == 0 {
+		fmt.Fprintf(&b, "Breakevens: none\n")
+	} else {
+		parts := make([]string, len(st.Breakevens))
+		for i, x := range st.Breakevens {
+			parts[i] = fmt.Sprintf("$%.2f (%+.1f%%)", x, (x/m.Spot-1)*100)
+		}
+		fmt.Fprintf(&b, "Breakevens: %s\n", strings.Join(parts, ", "))
+	}
+	fmt.Fprintf(&b, "Probability of Profit: %.1f%%\n", st.ProbProfit*100)
+
+	fmt.Fprintf(&b, "\n--- Position Greeks ---\n")
+	fmt.Fprintf(&b, "Delta: %.2f shares\n", st.Greeks.Delta)
+	fmt.Fprintf(&b, "Gamma: %.4f shares per $1\n", st.Greeks.Gamma)
+	fmt.Fprintf(&b, "Theta: %s per day\n", fmtSignedDollars(st.Greeks.Theta))
+	fmt.Fprintf(&b, "Vega: %s per vol point\n", fmtSignedDollars(st.Greeks.Vega))
+	fmt.Fprintf(&b, "Rho: %s per rate point\n", fmtSignedDollars(st.Greeks.Rho))
+
+	fmt.Fprintf(&b, "\n--- Payoff at Expiration ---\n")
+	fmt.Fprintf(&b, "%12s %10s %14s\n", "Price", "Move", "P&L")
+	for _, p := range st.Payoff {
+		fmt.Fprintf(&b, "%12.2f %+9.1f%% %14s\n", p.Price, (p.Price/m.Spot-1)*100, fmtSignedDollars(p.PnL))
+	}
+
+	fmt.Fprintf(&b, "\nLegs are priced at bid/ask midpoints (last price when unquoted); one contract covers %d shares.\n", options.Multiplier)
+	fmt.Fprintf(&b, "Probability of profit assumes a lognormal price at expiration with ATM implied volatility.\n")
 
 	return b.String()
 }
 
+// fmtSignedDollars formats a dollar amount with a sign and thousands separators.
+func fmtSignedDollars(v float64) string {
+	sign := ""
+	if v < 0 {
+		sign = "-"
+		v = -v
+	}
+	cents := int64(math.Round(v * 100))
+	return fmt.Sprintf("%s$%s.%02d", sign, fmtInt(cents/100), cents%100)
+}
+
 func formatRecommendations(symbol string, trend *yahoo.RecommendationTrendData) string {
 	var b strings.Builder
 
@@ -646,28 +3312,220 @@ func formatRecommendations(symbol string, trend *yahoo.RecommendationTrendData)
 	return b.String()
 }
 
-func formatNews(symbol string, news []yahoo.SearchNews) string {
+func formatAnalystActions(symbol string, actions []yahoo.AnalystAction, fin *yahoo.FinancialDataModule, m yahoo.RatingMomentum, limit int) string {
 	var b strings.Builder
 
-	fmt.Fprintf(&b, "=== %s Recent News ===\n\n", symbol)
+	fmt.Fprintf(&b, "=== %s Analyst Actions ===\n", symbol)
 
-	if len(news) == 0 {
-		fmt.Fprintf(&b, "No recent news found\n")
+	if fin != nil && fin.NumberOfAnalystOpinions.Raw > 0 {
+		fmt.Fprintf(&b, "\n--- Price Targets (%d analysts) ---\n", fin.NumberOfAnalystOpinions.Raw)
+		if fin.CurrentPrice.Raw > 0 {
+			fmt.Fprintf(&b, "Current:  %.2f\n", fin.CurrentPrice.Raw)
+		}
+		fmt.Fprintf(&b, "Low:      %.2f\n", fin.TargetLowPrice.Raw)
+		fmt.Fprintf(&b, "Mean:     %.2f", fin.TargetMeanPrice.Raw)
+		if fin.CurrentPrice.Raw > 0 {
+			upside := (fin.TargetMeanPrice.Raw - fin.CurrentPrice.Raw) / fin.CurrentPrice.Raw * 100
+			fmt.Fprintf(&b, " (%+.1f%% vs current)", upside)
+		}
+		fmt.Fprintln(&b)
+		fmt.Fprintf(&b, "Median:   %.2f\n", fin.TargetMedianPrice.Raw)
+		fmt.Fprintf(&b, "High:     %.2f\n", fin.TargetHighPrice.Raw)
+		if fin.RecommendationKey != "" {
+			fmt.Fprintf(&b, "Consensus: %s (mean %.2f, 1=strong buy, 5=sell)\n", fin.RecommendationKey, fin.RecommendationMean.Raw)
+		}
+	}
+
+	fmt.Fprintf(&b, "\n--- Rating Momentum (last %d days) ---\n", m.Days)
+	fmt.Fprintf(&b, "Upgrades: %d | Downgrades: %d | Net: %+d\n", m.Upgrades, m.Downgrades, m.Net)
+	fmt.Fprintf(&b, "Initiations: %d | Reiterations: %d\n", m.Initiations, m.Reiterated)
+	fmt.Fprintf(&b, "Target raises: %d | Target cuts: %d\n", m.TargetRaises, m.TargetCuts)
+
+	fmt.Fprintf(&b, "\n--- Rating Changes ---\n")
+	if len(actions) == 0 {
+		fmt.Fprintf(&b, "No analyst actions found\n")
 		return b.String()
 	}
 
-	for i, n := range news {
+	fmt.Fprintf(&b, "%-12s %-24s %-6s %-18s %-18s %10s %10s\n", "Date", "Firm", "Action", "From", "To", "Target", "Prior")
+	fmt.Fprintf(&b, "%s\n", strings.Repeat("-", 104))
+
+	for i, a := range actions {
+		if i >= limit {
+			fmt.Fprintf(&b, "... and %d more actions\n", len(actions)-limit)
+			break
+		}
+		firm := a.Firm
+		if len(firm) > 23 {
+			firm = firm[:21] + ".."
+		}
+		target, prior := "", ""
+		if a.CurrentPriceTarget > 0 {
+			target = fmt.Sprintf("%.2f", a.CurrentPriceTarget)
+		}
+		if a.PriorPriceTarget > 0 {
+			prior = fmt.Sprintf("%.2f", a.PriorPriceTarget)
+		}
+		fmt.Fprintf(&b, "%-12s %-24s %-6s %-18s %-18s %10s %10s\n",
+			time.Unix(a.EpochGradeDate, 0).UTC().Format("2006-01-02"), firm, a.Action, a.FromGrade, a.ToGrade, target, prior)
+	}
+
+	return b.String()
+}
+
+func formatNews(symbols string, feed *yahoo.NewsFeed) string {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "=== %s Recent News ===\n\n", symbols)
+
+	if len(feed.Articles) == 0 {
+		fmt.Fprintf(&b, "No recent news found\n")
+		return b.String() + fmtNewsFailures(feed.Failed)
+	}
+
+	for i, n := range feed.Articles {
 		pubTime := time.Unix(n.ProviderPublishTime, 0)
-		fmt.Fprintf(&b, "%d. %s\n", i+1, n.Title)
-		fmt.Fprintf(&b, "   Publisher: %s | %s\n", n.Publisher, pubTime.Format("2006-01-02 15:04 MST"))
+		fmt.Fprintf(&b, "%d. %s\n", feed.Offset+i+1, n.Title)
+		fmt.Fprintf(&b, "   Publisher: %s | %s", n.Publisher, pubTime.Format("2006-01-02 15:04 MST"))
+		if n.Type != "" && n.Type != "STORY" {
+			fmt.Fprintf(&b, " | %s", n.Type)
+		}
+		fmt.Fprintln(&b)
+		if len(n.RelatedTickers) > 0 {
+			fmt.Fprintf(&b, "   Tickers: %s\n", strings.Join(n.RelatedTickers, ", "))
+		}
 		if n.Link != "" {
 			fmt.Fprintf(&b, "   Link: %s\n", n.Link)
 		}
-		if i < len(news)-1 {
+		if thumb := n.Thumbnail.URL(); thumb != "" {
+			fmt.Fprintf(&b, "   Thumbnail: %s\n", thumb)
+		}
+		if i < len(feed.Articles)-1 {
+			fmt.Fprintln(&b)
+		}
+	}
+
+	last := feed.Offset + len(feed.Articles)
+	if feed.HasMore {
+		fmt.Fprintf(&b, "\nShowing %d-%d of at least %d articles (next page: offset %d)\n", feed.Offset+1, last, feed.Total, last)
+	} else {
+		fmt.Fprintf(&b, "\nShowing %d-%d of %d articles\n", feed.Offset+1, last, feed.Total)
+	}
+	b.WriteString(fmtNewsFailures(feed.Failed))
+
+	return b.String()
+}
+
+// fmtNewsFailures lists the symbols a news feed skipped, if any.
+func fmtNewsFailures(failed []error) string {
+	if len(failed) == 0 {
+		return ""
+	}
+	skipped := make([]string, len(failed))
+	for i, err := range failed {
+		skipped[i] = err.Error()
+	}
+	return fmt.Sprintf("Skipped: %s\n", strings.Join(skipped, "; "))
+}
+
+func formatNewsSentiment(results []newsSentiment, articles, fetched int) string {
+	var b strings.Builder
+
+	names := make([]string, len(results))
+	for i, r := range results {
+		names[i] = r.symbol
+	}
+	fmt.Fprintf(&b, "=== %s News Sentiment ===\n", strings.Join(names, ", "))
+	if fetched >= 0 {
+		fmt.Fprintf(&b, "Scored %d articles on headline and body (%d bodies fetched; the rest on headline only)\n\n", articles, fetched)
+	} else {
+		fmt.Fprintf(&b, "Scored %d articles on headline only\n\n", articles)
+	}
+
+	fmt.Fprintf(&b, "%-10s %8s %7s %-8s %5s %5s %5s  %s\n", "Symbol", "Articles", "Tone", "Label", "Pos", "Neg", "Neu", "Flag")
+	fmt.Fprintf(&b, "%s\n", strings.Repeat("-", 75))
+	for _, r := range results {
+		sum := r.summary
+		flag := ""
+		if sum.SharplyNegative() {
+			flag = "SHARPLY NEGATIVE"
+		}
+		tone, label := "N/A", ""
+		if sum.Articles > 0 {
+			tone, label = fmt.Sprintf("%+.2f", sum.Tone), sentiment.Label(sum.Tone)
+		}
+		line := fmt.Sprintf("%-10s %8d %7s %-8s %5d %5d %5d  %s",
+			r.symbol, sum.Articles, tone, label, sum.Positive, sum.Negative, sum.Neutral, flag)
+		fmt.Fprintf(&b, "%s\n", strings.TrimRight(line, " "))
+	}
+
+	for _, r := range results {
+		fmt.Fprintf(&b, "\n--- %s ---\n", r.symbol)
+		if len(r.articles) == 0 {
+			fmt.Fprintf(&b, "No recent news found\n")
+			continue
+		}
+		if terms := fmtSentimentTerms(r.summary.Terms, 10); terms != "" {
+			fmt.Fprintf(&b, "Driving terms: %s\n", terms)
+		}
+		for i, n := range r.articles {
+			s := r.scores[i]
+			fmt.Fprintf(&b, "%d. [%+.2f %s] %s\n", i+1, s.Tone(), sentiment.Label(s.Tone()), n.Title)
+			fmt.Fprintf(&b, "   %s | %s", n.Publisher, time.Unix(n.ProviderPublishTime, 0).Format("2006-01-02 15:04 MST"))
+			if terms := fmtSentimentTerms(s.Terms, 5); terms != "" {
+				fmt.Fprintf(&b, " | %s", terms)
+			}
 			fmt.Fprintln(&b)
 		}
 	}
 
+	fmt.Fprintf(&b, "\nTone runs from -1 (negative) to +1 (positive) using a finance word list; headlines count double.\n")
+
+	return b.String()
+}
+
+// fmtSentimentTerms lists up to limit terms as "plunge -3, beat +1".
+func fmtSentimentTerms(terms []sentiment.Term, limit int) string {
+	parts := make([]string, 0, min(len(terms), limit))
+	for _, t := range terms[:min(len(terms), limit)] {
+		parts = append(parts, fmt.Sprintf("%s %+d", t.Term, t.Polarity*t.Count))
+	}
+	return strings.Join(parts, ", ")
+}
+
+func formatArticle(a article.Article) string {
+	var b strings.Builder
+
+	title := a.Title
+	if title == "" {
+		title = "Article"
+	}
+	fmt.Fprintf(&b, "=== %s ===\n\n", title)
+
+	if a.Publisher != "" {
+		fmt.Fprintf(&b, "Publisher: %s\n", a.Publisher)
+	}
+	if a.Author != "" {
+		fmt.Fprintf(&b, "Author:    %s\n", a.Author)
+	}
+	if !a.Published.IsZero() {
+		fmt.Fprintf(&b, "Published: %s\n", a.Published.Format("2006-01-02 15:04 MST"))
+	}
+	fmt.Fprintf(&b, "URL:       %s\n", a.URL)
+	if a.Paywalled {
+		fmt.Fprintf(&b, "\nNote: this article is behind a paywall; only the free preview is shown.\n")
+	}
+
+	fmt.Fprintf(&b, "\n--- Text ---\n")
+	if a.Text == "" {
+		fmt.Fprintf(&b, "No text available\n")
+		return b.String()
+	}
+	fmt.Fprintf(&b, "%s\n", a.Text)
+	if a.Truncated {
+		fmt.Fprintf(&b, "\n[Truncated; raise max_chars for more]\n")
+	}
+
 	return b.String()
 }
 
@@ -743,6 +3601,141 @@ func formatProfile(symbol string, profile *yahoo.AssetProfileData, quoteType *ya
 	return b.String()
 }
 
+func formatFundProfile(symbol string, result *yahoo.QuoteSummaryResult) string {
+	var b strings.Builder
+
+	name := symbol
+	if result.QuoteType != nil && result.QuoteType.LongName != "" {
+		name = result.QuoteType.LongName
+	}
+	fmt.Fprintf(&b, "=== %s (%s) Fund Profile ===\n\n", name, symbol)
+
+	if result.FundProfile == nil && result.TopHoldings == nil && result.FundPerformance == nil {
+		fmt.Fprintf(&b, "No fund data available (is %s an ETF or mutual fund?)\n", symbol)
+		return b.String()
+	}
+
+	if fp := result.FundProfile; fp != nil {
+		if fp.Family != "" {
+			fmt.Fprintf(&b, "Family:         %s\n", fp.Family)
+		}
+		if fp.CategoryName != "" {
+			fmt.Fprintf(&b, "Category:       %s\n", fp.CategoryName)
+		}
+		if fp.LegalType != "" {
+			fmt.Fprintf(&b, "Legal Type:     %s\n", fp.LegalType)
+		}
+		if fees := fp.FeesExpensesInvestment; fees != nil && fees.AnnualReportExpenseRatio.Raw > 0 {
+			fmt.Fprintf(&b, "Expense Ratio:  %.2f%%", fees.AnnualReportExpenseRatio.Raw*100)
+			if cat := fp.FeesExpensesInvestmentCat; cat != nil && cat.AnnualReportExpenseRatio.Raw > 0 {
+				fmt.Fprintf(&b, " (category avg %.2f%%)", cat.AnnualReportExpenseRatio.Raw*100)
+			}
+			fmt.Fprintln(&b)
+		}
+		if fees := fp.FeesExpensesInvestment; fees != nil && fees.AnnualHoldingsTurnover.Raw > 0 {
+			fmt.Fprintf(&b, "Turnover:       %.0f%%\n", fees.AnnualHoldingsTurnover.Raw*100)
+		}
+	}
+	if sd := result.SummaryDetail; sd != nil {
+		if sd.TotalAssets.Raw > 0 {
+			fmt.Fprintf(&b, "AUM:            %s\n", fmtLargeNumber(sd.TotalAssets.Raw))
+		}
+		if sd.Yield.Raw > 0 {
+			fmt.Fprintf(&b, "Yield:          %.2f%%\n", sd.Yield.Raw*100)
+		}
+	}
+
+	if th := result.TopHoldings; th != nil {
+		fmt.Fprintf(&b, "\n--- Asset Allocation ---\n")
+		fmt.Fprintf(&b, "Stocks: %.2f%% | Bonds: %.2f%% | Cash: %.2f%% | Other: %.2f%%\n",
+			th.StockPosition.Raw*100, th.BondPosition.Raw*100, th.CashPosition.Raw*100, th.OtherPosition.Raw*100)
+
+		if len(th.Holdings) > 0 {
+			fmt.Fprintf(&b, "\n--- Top Holdings ---\n")
+			fmt.Fprintf(&b, "  %-10s %-35s %8s\n", "Symbol", "Name", "Weight")
+			total := 0.0
+			for _, hd := range th.Holdings {
+				hname := hd.HoldingName
+				if len(hname) > 34 {
+					hname = hname[:32] + ".."
+				}
+				fmt.Fprintf(&b, "  %-10s %-35s %7.2f%%\n", hd.Symbol, hname, hd.HoldingPercent.Raw*100)
+				total += hd.HoldingPercent.Raw
+			}
+			fmt.Fprintf(&b, "  Top %d holdings: %.2f%% of fund\n", len(th.Holdings), total*100)
+		}
+
+		if weights := flattenWeightings(th.SectorWeightings); len(weights) > 0 {
+			fmt.Fprintf(&b, "\n--- Sector Weightings ---\n")
+			for _, w := range weights {
+				fmt.Fprintf(&b, "  %-25s %7.2f%%\n", w.name, w.value*100)
+			}
+		}
+
+		if ratings := flattenWeightings(th.BondRatings); len(ratings) > 0 && th.BondPosition.Raw > 0 {
+			fmt.Fprintf(&b, "\n--- Bond Ratings ---\n")
+			for _, r := range ratings {
+				fmt.Fprintf(&b, "  %-25s %7.2f%%\n", strings.ToUpper(r.name), r.value*100)
+			}
+		}
+	}
+
+	if perf := result.FundPerformance; perf != nil {
+		fund, cat := perf.TrailingReturns, perf.TrailingReturnsCat
+		fmt.Fprintf(&b, "\n--- Trailing Returns ---\n")
+		fmt.Fprintf(&b, "  %-10s %10s %10s %10s\n", "Period", "Fund", "Category", "Diff")
+		rows := []struct {
+			label     string
+			fund, cat yahoo.YahooValue
+		}{
+			{"1M", fund.OneMonth, cat.OneMonth},
+			{"3M", fund.ThreeMonth, cat.ThreeMonth},
+			{"YTD", fund.YTD, cat.YTD},
+			{"1Y", fund.OneYear, cat.OneYear},
+			{"3Y", fund.ThreeYear, cat.ThreeYear},
+			{"5Y", fund.FiveYear, cat.FiveYear},
+			{"10Y", fund.TenYear, cat.TenYear},
+		}
+		for _, r := range rows {
+			if r.fund.Fmt == "" && r.fund.Raw == 0 {
+				continue
+			}
+			catStr, diffStr := "N/A", ""
+			if r.cat.Fmt != "" || r.cat.Raw != 0 {
+				catStr = fmt.Sprintf("%.2f%%", r.cat.Raw*100)
+				diffStr = fmt.Sprintf("%+.2f%%", (r.fund.Raw-r.cat.Raw)*100)
+			}
+			fmt.Fprintf(&b, "  %-10s %9.2f%% %10s %10s\n", r.label, r.fund.Raw*100, catStr, diffStr)
+		}
+		if fund.AsOfDate.Fmt != "" {
+			fmt.Fprintf(&b, "  (as of %s; 3Y+ returns are annualized)\n", fund.AsOfDate.Fmt)
+		}
+	}
+
+	return b.String()
+}
+
+type weighting struct {
+	name  string
+	value float64
+}
+
+// flattenWeightings converts Yahoo's list of single-key weight objects into
+// a slice sorted by descending weight.
+func flattenWeightings(items []map[string]yahoo.YahooValue) []weighting {
+	var out []weighting
+	for _, item := range items {
+		for k, v := range item {
+			if v.Raw <= 0 {
+				continue
+			}
+			out = append(out, weighting{name: strings.ReplaceAll(k, "_", " "), value: v.Raw})
+		}
+	}
+	sort.SliceStable(out, func(i, j int) bool { return out[i].value > out[j].value })
+	return out
+}
+
 func formatSector(data *yahoo.SectorData) string {
 	var b strings.Builder
 
@@ -958,9 +3951,14 @@ func formatMarketStatus(market string, groups []yahoo.MarketTimeGroup) string {
 func fmtPrice(val float64, currency string) string {
 	symbol := "$"
 	switch currency {
+	case "", "USD":
+	case "GBp", "GBX":
+		return fmt.Sprintf("%.2fp", val)
+	case "ILA", "ZAc", "ZAC":
+		return fmt.Sprintf("%.2f %s", val, currency)
 	case "EUR":
 		symbol = "\u20ac"
-	case "GBP", "GBp":
+	case "GBP":
 		symbol = "\u00a3"
 	case "JPY":
 		symbol = "\u00a5"
@@ -976,10 +3974,30 @@ func fmtPrice(val float64, currency string) string {
 		symbol = "C$"
 	case "AUD":
 		symbol = "A$"
+	default:
+		symbol = currency + " "
 	}
 	return fmt.Sprintf("%s%.2f", symbol, val)
 }
 
+// sameMajorCurrency reports whether two currency codes share a major
+// currency, so a GBp listing matches statements in GBP.
+func sameMajorCurrency(a, b string) bool {
+	ma, _ := fx.Normalize(a)
+	mb, _ := fx.Normalize(b)
+	return ma == mb
+}
+
+// fmtMarketCap formats a market capitalization, which Yahoo reports in the
+// major unit of the listing currency.
+func fmtMarketCap(val float64, currency string) string {
+	major, _ := fx.Normalize(currency)
+	if major == "" || major == "USD" {
+		return fmtLargeNumber(val)
+	}
+	return fmtCompact(val) + " " + major
+}
+
 func fmtInt(val int64) string {
 	if val == 0 {
 		return "0"
@@ -1038,6 +4056,31 @@ func fmtLargeNumber(val float64) string {
 	}
 }
 
+// fmtCompact formats a number with a K/M/B/T suffix and no currency symbol.
+// Values below 1000 (per-share figures, ratios) keep two decimals.
+func fmtCompact(val float64) string {
+	abs := math.Abs(val)
+	switch {
+	case abs >= 1e12:
+		return fmt.Sprintf("%.2fT", val/1e12)
+	case abs >= 1e9:
+		return fmt.Sprintf("%.2fB", val/1e9)
+	case abs >= 1e6:
+		return fmt.Sprintf("%.2fM", val/1e6)
+	case abs >= 1e3:
+		return fmt.Sprintf("%.2fK", val/1e3)
+	default:
+		return fmt.Sprintf("%.2f", val)
+	}
+}
+
+func fmtVol(iv float64) string {
+	if math.IsNaN(iv) {
+		return "-"
+	}
+	return fmt.Sprintf("%.1f%%", iv*100)
+}
+
 func fmtOptFloat(vals []*float64, idx int) string {
 	if idx >= len(vals) || vals[idx] == nil {
 		return "N/A"
@@ -1052,13 +4095,40 @@ func fmtOptInt(vals []*int64, idx int) string {
 	return fmtInt(*vals[idx])
 }
 
+// addSpaces splits a CamelCase identifier into words, keeping acronyms
+// together (e.g. "NetPPEPurchaseAndSale" -> "Net PPE Purchase And Sale").
 func addSpaces(s string) string {
+	runes := []rune(s)
 	var result strings.Builder
-	for i, r := range s {
-		if i > 0 && r >= 'A' && r <= 'Z' {
-			result.WriteRune(' ')
+	for i, r := range runes {
+		if i > 0 && unicode.IsUpper(r) {
+			prevLower := !unicode.IsUpper(runes[i-1])
+			nextLower := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+			if prevLower || nextLower {
+				result.WriteRune(' ')
+			}
 		}
 		result.WriteRune(r)
 	}
 	return result.String()
 }
+
+// truncate shortens s to at most n bytes, marking the cut with "..".
+func truncate(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	return s[:n-2] + ".."
+}
+
+// splitList splits a comma-separated argument into trimmed, non-empty values.
+func splitList(raw string) []string {
+	var out []string
+	for _, s := range strings.Split(raw, ",") {
+		s = strings.TrimSpace(s)
+		if s != "" {
+			out = append(out, s)
+		}
+	}
+	return out
+}
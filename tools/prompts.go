@@ -0,0 +1,281 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// Prompts are templates for common research workflows. Each renders a single
+// user message that names the tools to call, in order, and the shape of the
+// answer, so the model gathers the same data every time.
+
+// horizonArg is the horizon argument shared by the prompts that take one.
+func horizonArg(example string) mcp.PromptOption {
+	return mcp.WithArgument("horizon",
+		mcp.ArgumentDescription(fmt.Sprintf("Investment horizon (e.g., %s)", example)),
+	)
+}
+
+// promptArg returns a trimmed prompt argument, or def when it is empty.
+func promptArg(req mcp.GetPromptRequest, name, def string) string {
+	if v := strings.TrimSpace(req.Params.Arguments[name]); v != "" {
+		return v
+	}
+	return def
+}
+
+// promptSymbol returns the required symbol argument, upper-cased.
+func promptSymbol(req mcp.GetPromptRequest) (string, error) {
+	symbol := strings.ToUpper(promptArg(req, "symbol", ""))
+	if symbol == "" {
+		return "", fmt.Errorf("symbol is required")
+	}
+	return symbol, nil
+}
+
+// promptResult wraps text as a single user message.
+func promptResult(description, text string) *mcp.GetPromptResult {
+	return mcp.NewGetPromptResult(description, []mcp.PromptMessage{
+		mcp.NewPromptMessage(mcp.RoleUser, mcp.NewTextContent(text)),
+	})
+}
+
+// horizonRange maps a free-text horizon to the get_chart range that covers
+// it, defaulting to 1y.
+func horizonRange(horizon string) string {
+	h := strings.ToLower(horizon)
+	switch {
+	case strings.Contains(h, "day"), strings.Contains(h, "week"):
+		return "1mo"
+	case strings.Contains(h, "month"):
+		var n int
+		fmt.Sscanf(h, "%d", &n)
+		switch {
+		case n > 0 && n <= 3:
+			return "3mo"
+		case n > 0 && n <= 6:
+			return "6mo"
+		}
+		return "1y"
+	case strings.Contains(h, "year"):
+		var n int
+		fmt.Sscanf(h, "%d", &n)
+		switch {
+		case n >= 5:
+			return "10y"
+		case n >= 2:
+			return "5y"
+		}
+		return "2y"
+	}
+	return "1y"
+}
+
+// EquityResearchPrompt returns the MCP prompt definition for equity_research_brief.
+func EquityResearchPrompt() mcp.Prompt {
+	return mcp.NewPrompt("equity_research_brief",
+		mcp.WithPromptDescription("Research brief on a stock: business, valuation, financial health, analyst view, news and risks, ending in a view for the horizon"),
+		mcp.WithArgument("symbol",
+			mcp.ArgumentDescription("Stock ticker symbol (e.g., AAPL, MSFT, GOOGL)"),
+			mcp.RequiredArgument(),
+		),
+		horizonArg("6 months, 1 year, 3 years; default: 1 year"),
+	)
+}
+
+// HandleEquityResearchPrompt renders equity_research_brief.
+func HandleEquityResearchPrompt(ctx context.Context, req mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
+	symbol, err := promptSymbol(req)
+	if err != nil {
+		return nil, err
+	}
+	horizon := promptArg(req, "horizon", "1 year")
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "Write an equity research brief on %s for a %s horizon.\n\n", symbol, horizon)
+	sb.WriteString("Gather the data first:\n")
+	fmt.Fprintf(&sb, "1. get_quote for %s: price, market cap, valuation multiples and 52-week range.\n", symbol)
+	fmt.Fprintf(&sb, "2. get_profile for %s: what the company does, its sector and industry.\n", symbol)
+	fmt.Fprintf(&sb, "3. get_chart for %s with range %s and interval 1d: trend and drawdowns.\n", symbol, horizonRange(horizon))
+	fmt.Fprintf(&sb, "4. get_financials for %s (income, then cashflow, annual) and get_financial_ratios: growth, margins, returns and leverage.\n", symbol)
+	fmt.Fprintf(&sb, "5. get_quality_scores for %s: Piotroski, Altman and Beneish red flags.\n", symbol)
+	fmt.Fprintf(&sb, "6. compare_fundamentals for %s against its peers from get_similar_symbols.\n", symbol)
+	fmt.Fprintf(&sb, "7. get_recommendations and get_analyst_actions for %s: consensus, price targets and recent rating changes.\n", symbol)
+	fmt.Fprintf(&sb, "8. get_news for %s (count 10): recent developments.\n\n", symbol)
+	sb.WriteString("Then write the brief with these sections: Summary (one paragraph with your view), Business, Valuation vs peers, Financial health, Analyst view, Recent news, Risks, and What would change the view. ")
+	sb.WriteString("Cite figures from the tool output; say so where data is missing rather than estimating it.")
+
+	return promptResult(fmt.Sprintf("Equity research brief for %s", symbol), sb.String()), nil
+}
+
+// EarningsPreviewPrompt returns the MCP prompt definition for earnings_preview.
+func EarningsPreviewPrompt() mcp.Prompt {
+	return mcp.NewPrompt("earnings_preview",
+		mcp.WithPromptDescription("Preview of a company's next earnings report: recent quarterly trend, analyst expectations, options-implied move and what to watch"),
+		mcp.WithArgument("symbol",
+			mcp.ArgumentDescription("Stock ticker symbol (e.g., AAPL, MSFT, GOOGL)"),
+			mcp.RequiredArgument(),
+		),
+	)
+}
+
+// HandleEarningsPreviewPrompt renders earnings_preview.
+func HandleEarningsPreviewPrompt(ctx context.Context, req mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
+	symbol, err := promptSymbol(req)
+	if err != nil {
+		return nil, err
+	}
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "Prepare an earnings preview for %s.\n\n", symbol)
+	sb.WriteString("Gather the data first:\n")
+	fmt.Fprintf(&sb, "1. get_quote for %s: price, valuation and how the stock has moved into the report.\n", symbol)
+	fmt.Fprintf(&sb, "2. get_financials for %s with period quarterly (income, then cashflow): the last four quarters of revenue, margins, EPS and free cash flow.\n", symbol)
+	fmt.Fprintf(&sb, "3. get_financial_ratios for %s with period quarterly: QoQ and YoY growth.\n", symbol)
+	fmt.Fprintf(&sb, "4. get_recommendations and get_analyst_actions for %s (days 30): consensus, price targets and revisions ahead of the print.\n", symbol)
+	fmt.Fprintf(&sb, "5. get_options for %s at the first expiration after the report date: the expected move from the ATM straddle and put/call positioning.\n", symbol)
+	fmt.Fprintf(&sb, "6. get_news_sentiment for %s (count 15): guidance, pre-announcements and the tone going in.\n\n", symbol)
+	sb.WriteString("Then write the preview with these sections: Setup (price action and sentiment into the report), Recent quarters (a table), Expectations, Options-implied move, Key metrics and questions to watch, and Bull and bear scenarios with the likely price reaction. ")
+	sb.WriteString("If the report date is not in the data, say so.")
+
+	return promptResult(fmt.Sprintf("Earnings preview for %s", symbol), sb.String()), nil
+}
+
+// OptionsTradeIdeaPrompt returns the MCP prompt definition for options_trade_idea.
+func OptionsTradeIdeaPrompt() mcp.Prompt {
+	return mcp.NewPrompt("options_trade_idea",
+		mcp.WithPromptDescription("Options trade idea on a stock: volatility regime, chain positioning and a defined-risk strategy analyzed leg by leg"),
+		mcp.WithArgument("symbol",
+			mcp.ArgumentDescription("Stock ticker symbol (e.g., AAPL, MSFT, GOOGL)"),
+			mcp.RequiredArgument(),
+		),
+		mcp.WithArgument("outlook",
+			mcp.ArgumentDescription("Directional view: bullish, bearish, neutral, or volatile (default: let the data decide)"),
+		),
+		horizonArg("2 weeks, 1 month, 3 months; default: 1 month"),
+	)
+}
+
+// HandleOptionsTradeIdeaPrompt renders options_trade_idea.
+func HandleOptionsTradeIdeaPrompt(ctx context.Context, req mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
+	symbol, err := promptSymbol(req)
+	if err != nil {
+		return nil, err
+	}
+	horizon := promptArg(req, "horizon", "1 month")
+	outlook := strings.ToLower(promptArg(req, "outlook", ""))
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "Find an options trade on %s for a %s horizon", symbol, horizon)
+	if outlook != "" {
+		fmt.Fprintf(&sb, " with a %s outlook", outlook)
+	}
+	sb.WriteString(".\n\nGather the data first:\n")
+	fmt.Fprintf(&sb, "1. get_quote and get_chart for %s (range 6mo, interval 1d): trend, support and resistance.\n", symbol)
+	fmt.Fprintf(&sb, "2. get_volatility_surface for %s: whether implied volatility is rich or cheap along the term structure, and the skew.\n", symbol)
+	fmt.Fprintf(&sb, "3. get_options for %s at the expiration closest to %s out: expected move, max pain, put/call ratios and unusual activity.\n", symbol, horizon)
+	fmt.Fprintf(&sb, "4. get_news for %s: events before expiration that could move the stock.\n", symbol)
+	sb.WriteString("5. Pick a defined-risk strategy that fits the ")
+	if outlook != "" {
+		sb.WriteString("outlook")
+	} else {
+		sb.WriteString("view the data supports")
+	}
+	fmt.Fprintf(&sb, " and the volatility regime (sell premium when IV is rich, buy it when cheap), and run analyze_option_strategy for %s with its legs at that expiration. Try one alternative and compare.\n\n", symbol)
+	sb.WriteString("Then present the idea: Thesis, Strategy and legs, Net debit or credit, Max profit and loss, Breakevens, Probability of profit, Greeks, Exit plan, and Risks. ")
+	sb.WriteString("Use only prices from the tool output. This is analysis, not a recommendation to trade.")
+
+	return promptResult(fmt.Sprintf("Options trade idea for %s", symbol), sb.String()), nil
+}
+
+// SectorRotationPrompt returns the MCP prompt definition for sector_rotation_check.
+func SectorRotationPrompt() mcp.Prompt {
+	return mcp.NewPrompt("sector_rotation_check",
+		mcp.WithPromptDescription("Sector rotation check: relative performance of the eleven US sectors over the horizon and where leadership is shifting"),
+		horizonArg("1 month, 3 months, 1 year; default: 3 months"),
+	)
+}
+
+// sectorETFs are the SPDR sector funds, one per sector key.
+var sectorETFs = []struct{ key, etf string }{
+	{"basic-materials", "XLB"},
+	{"communication-services", "XLC"},
+	{"consumer-cyclical", "XLY"},
+	{"consumer-defensive", "XLP"},
+	{"energy", "XLE"},
+	{"financial-services", "XLF"},
+	{"healthcare", "XLV"},
+	{"industrials", "XLI"},
+	{"real-estate", "XLRE"},
+	{"technology", "XLK"},
+	{"utilities", "XLU"},
+}
+
+// HandleSectorRotationPrompt renders sector_rotation_check.
+func HandleSectorRotationPrompt(ctx context.Context, req mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
+	horizon := promptArg(req, "horizon", "3 months")
+
+	etfs := make([]string, len(sectorETFs))
+	pairs := make([]string, len(sectorETFs))
+	for i, s := range sectorETFs {
+		etfs[i] = s.etf
+		pairs[i] = fmt.Sprintf("%s (%s)", s.key, s.etf)
+	}
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "Check for sector rotation in US equities over the last %s.\n\n", horizon)
+	fmt.Fprintf(&sb, "Sectors and their ETFs: %s.\n\n", strings.Join(pairs, ", "))
+	sb.WriteString("Gather the data first:\n")
+	fmt.Fprintf(&sb, "1. get_bulk_spark for %s,SPY with range %s and interval 1d: each sector's return and its return relative to SPY.\n", strings.Join(etfs, ","), horizonRange(horizon))
+	sb.WriteString("2. get_bulk_spark for the same symbols with range 1mo: whether recent momentum agrees with the longer trend.\n")
+	sb.WriteString("3. get_sector for the three strongest and the three weakest sectors: market cap, top companies and the industries driving the move.\n")
+	sb.WriteString("4. get_market_summary for US and RATES: the index and rate backdrop.\n\n")
+	sb.WriteString("Then report: a table ranking the sectors by relative return over both windows, which sectors are gaining and losing leadership, whether the pattern looks cyclical or defensive, and what it suggests about the market regime. ")
+	sb.WriteString("Name the industries and companies behind the leaders.")
+
+	return promptResult(fmt.Sprintf("Sector rotation check over %s", horizon), sb.String()), nil
+}
+
+// MorningBriefPrompt returns the MCP prompt definition for morning_market_brief.
+func MorningBriefPrompt() mcp.Prompt {
+	return mcp.NewPrompt("morning_market_brief",
+		mcp.WithPromptDescription("Morning market brief: market status, indices, rates, movers, and a watchlist check with overnight news"),
+		mcp.WithArgument("region",
+			mcp.ArgumentDescription("Market region: US, GB, ASIA, EUROPE (default: US)"),
+		),
+		mcp.WithArgument("symbols",
+			mcp.ArgumentDescription("Comma-separated watchlist symbols to cover (e.g., \"AAPL,MSFT,NVDA\")"),
+		),
+	)
+}
+
+// HandleMorningBriefPrompt renders morning_market_brief.
+func HandleMorningBriefPrompt(ctx context.Context, req mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
+	region := strings.ToUpper(promptArg(req, "region", "US"))
+	watchlist := strings.Join(splitList(strings.ToUpper(promptArg(req, "symbols", ""))), ",")
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "Write a morning market brief for the %s market.\n\n", region)
+	sb.WriteString("Gather the data first:\n")
+	fmt.Fprintf(&sb, "1. get_market_status for %s: whether the market is open and when it opens or closes.\n", region)
+	fmt.Fprintf(&sb, "2. get_market_summary for %s, then for RATES, CURRENCIES and COMMODITIES: indices, yields, the dollar, oil and gold.\n", region)
+	fmt.Fprintf(&sb, "3. get_movers with list day_gainers, day_losers and most_actives, and with list trending for region %s (count 10 each).\n", region)
+	n := 4
+	if watchlist != "" {
+		fmt.Fprintf(&sb, "%d. get_bulk_quotes for %s: the watchlist's moves, including pre-market where shown.\n", n, watchlist)
+		n++
+		fmt.Fprintf(&sb, "%d. get_news for symbols %s (count 15): overnight headlines for the watchlist.\n", n, watchlist)
+	} else {
+		fmt.Fprintf(&sb, "%d. get_news for the two or three biggest movers (count 5 each): why they are moving.\n", n)
+	}
+	sb.WriteString("\nThen write a brief that can be read in two minutes: Market status, Indices and macro (one table), Movers and why, ")
+	if watchlist != "" {
+		sb.WriteString("Watchlist, ")
+	}
+	sb.WriteString("and What to watch today. Lead with the single most important development.")
+
+	return promptResult(fmt.Sprintf("Morning market brief for %s", region), sb.String()), nil
+}
@@ -0,0 +1,305 @@
+package tools
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/emmanuelay/yahoo-finance-mcp/refresh"
+	"github.com/emmanuelay/yahoo-finance-mcp/stream"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// streamURIPrefix prefixes the resource URI of a streamed symbol.
+const streamURIPrefix = "yahoo://stream/"
+
+// minUpdateInterval throttles resource update notifications per symbol;
+// liquid symbols tick many times a second.
+const minUpdateInterval = time.Second
+
+// How long resource content is reused before it is fetched again.
+const (
+	quoteTTL    = 30 * time.Second
+	marketTTL   = time.Minute
+	intradayTTL = time.Minute
+	dailyTTL    = time.Hour
+	sectorTTL   = time.Hour
+	profileTTL  = 24 * time.Hour
+)
+
+// refreshInterval is how often watched resources are checked for expiry.
+const refreshInterval = 10 * time.Second
+
+// resourceServer publishes Yahoo data as MCP resources and notifies
+// subscribed clients when it changes.
+type resourceServer struct {
+	srv   *server.MCPServer
+	cache *refresh.Cache
+	subs  subscriptions
+
+	mu       sync.Mutex
+	notified map[string]time.Time // streamed symbol → last update notification
+}
+
+// RegisterResources registers the yahoo:// resource templates on s, publishes
+// stream_quotes subscriptions as resources, and starts refreshing resources
+// clients have read. Sessions subscribed to a resource receive
+// notifications/resources/updated when a refresh changes its content or,
+// for stream resources, as its price changes; subscription requests are
+// answered by ServeStdio.
+func (h *Handlers) RegisterResources(s *server.MCPServer) {
+	r := &resourceServer{srv: s, notified: make(map[string]time.Time)}
+	r.cache = refresh.New(r.updated)
+	h.resources = r
+
+	s.AddResourceTemplate(
+		mcp.NewResourceTemplate("yahoo://quote/{symbol}", "Quote",
+			mcp.WithTemplateDescription("Price, change, volume, market cap and valuation for a symbol, as get_quote returns. Percent-encode ^ and = in symbols (yahoo://quote/%5EGSPC, yahoo://quote/EURUSD%3DX)"),
+			mcp.WithTemplateMIMEType("text/plain"),
+		),
+		h.readQuoteResource,
+	)
+	s.AddResourceTemplate(
+		mcp.NewResourceTemplate("yahoo://profile/{symbol}", "Company profile",
+			mcp.WithTemplateDescription("Sector, industry, description, website and key executives for a symbol"),
+			mcp.WithTemplateMIMEType("text/plain"),
+		),
+		h.readProfileResource,
+	)
+	s.AddResourceTemplate(
+		mcp.NewResourceTemplate("yahoo://chart/{symbol}/{range}/{interval}", "Chart",
+			mcp.WithTemplateDescription("OHLCV history for a symbol, e.g. yahoo://chart/AAPL/6mo/1d (ranges: 1d, 5d, 1mo, 3mo, 6mo, 1y, 2y, 5y, 10y, ytd, max; intervals: 1m to 3mo)"),
+			mcp.WithTemplateMIMEType("text/plain"),
+		),
+		h.readChartResource,
+	)
+	s.AddResourceTemplate(
+		mcp.NewResourceTemplate("yahoo://sector/{key}", "Sector",
+			mcp.WithTemplateDescription("Sector overview by key (e.g. technology, healthcare): market cap, top companies, ETFs and industries"),
+			mcp.WithTemplateMIMEType("text/plain"),
+		),
+		h.readSectorResource,
+	)
+	s.AddResourceTemplate(
+		mcp.NewResourceTemplate("yahoo://market/{region}", "Market summary",
+			mcp.WithTemplateDescription("Index prices and changes for a market region (e.g. US, GB, DE, JP)"),
+			mcp.WithTemplateMIMEType("text/plain"),
+		),
+		h.readMarketResource,
+	)
+
+	go r.cache.Run(context.Background(), refreshInterval)
+}
+
+// subscribe records that session wants resources/updated for uri, and keeps
+// uri refreshing while anyone is subscribed.
+func (r *resourceServer) subscribe(session, uri string) {
+	if r.subs.add(session, uri) {
+		r.cache.Pin(uri)
+	}
+}
+
+// unsubscribe stops update notifications for uri to session.
+func (r *resourceServer) unsubscribe(session, uri string) {
+	if r.subs.remove(session, uri) {
+		r.cache.Unpin(uri)
+	}
+}
+
+// updated notifies the sessions subscribed to uri that it changed. Sessions
+// that have gone away lose their subscriptions.
+func (r *resourceServer) updated(uri string) {
+	for _, session := range r.subs.subscribers(uri) {
+		err := r.srv.SendNotificationToSpecificClient(session, mcp.MethodNotificationResourceUpdated, map[string]any{"uri": uri})
+		if errors.Is(err, server.ErrSessionNotFound) {
+			for _, orphaned := range r.subs.drop(session) {
+				r.cache.Unpin(orphaned)
+			}
+		}
+	}
+}
+
+// templateArg returns a URI template variable. The template matcher has
+// already percent-decoded it, so ^GSPC arrives from yahoo://quote/%5EGSPC.
+func templateArg(req mcp.ReadResourceRequest, name string) string {
+	var v string
+	switch a := req.Params.Arguments[name].(type) {
+	case string:
+		v = a
+	case []string:
+		if len(a) > 0 {
+			v = a[0]
+		}
+	}
+	return strings.TrimSpace(v)
+}
+
+// readCached serves a resource from the refresh cache.
+func (h *Handlers) readCached(req mcp.ReadResourceRequest, ttl time.Duration, load refresh.Loader) ([]mcp.ResourceContents, error) {
+	uri := req.Params.URI
+	text, err := h.resources.cache.Get(uri, ttl, load)
+	if err != nil {
+		return nil, err
+	}
+	return []mcp.ResourceContents{
+		mcp.TextResourceContents{URI: uri, MIMEType: "text/plain", Text: text},
+	}, nil
+}
+
+func (h *Handlers) readQuoteResource(ctx context.Context, req mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+	symbol := strings.ToUpper(templateArg(req, "symbol"))
+	if symbol == "" {
+		return nil, fmt.Errorf("symbol is required")
+	}
+	return h.readCached(req, quoteTTL, func() (string, error) {
+		price, detail, err := h.client.GetQuote(symbol)
+		if err != nil {
+			return "", fmt.Errorf("get quote for %s: %w", symbol, err)
+		}
+		note := ""
+		if tick, ok := h.stream.Latest(symbol); ok && price != nil {
+			applyTick(price, tick)
+			note = fmtTickNote(tick)
+		}
+		return formatQuote(price, detail) + note, nil
+	})
+}
+
+func (h *Handlers) readProfileResource(ctx context.Context, req mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+	symbol := strings.ToUpper(templateArg(req, "symbol"))
+	if symbol == "" {
+		return nil, fmt.Errorf("symbol is required")
+	}
+	return h.readCached(req, profileTTL, func() (string, error) {
+		profile, quoteType, err := h.client.GetProfile(symbol)
+		if err != nil {
+			return "", fmt.Errorf("get profile for %s: %w", symbol, err)
+		}
+		return formatProfile(symbol, profile, quoteType), nil
+	})
+}
+
+func (h *Handlers) readChartResource(ctx context.Context, req mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+	symbol := strings.ToUpper(templateArg(req, "symbol"))
+	rangeStr, interval := templateArg(req, "range"), templateArg(req, "interval")
+	if symbol == "" || rangeStr == "" || interval == "" {
+		return nil, fmt.Errorf("symbol, range and interval are required")
+	}
+	ttl := dailyTTL
+	if strings.HasSuffix(interval, "m") || strings.HasSuffix(interval, "h") {
+		ttl = intradayTTL
+	}
+	return h.readCached(req, ttl, func() (string, error) {
+		chart, err := h.client.GetChart(symbol, rangeStr, interval)
+		if err != nil {
+			return "", fmt.Errorf("get chart for %s: %w", symbol, err)
+		}
+		return formatChart(chart), nil
+	})
+}
+
+func (h *Handlers) readSectorResource(ctx context.Context, req mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+	key := strings.ToLower(templateArg(req, "key"))
+	if key == "" {
+		return nil, fmt.Errorf("key is required")
+	}
+	return h.readCached(req, sectorTTL, func() (string, error) {
+		data, err := h.client.GetSector(key)
+		if err != nil {
+			return "", fmt.Errorf("get sector %q: %w", key, err)
+		}
+		return formatSector(data), nil
+	})
+}
+
+func (h *Handlers) readMarketResource(ctx context.Context, req mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+	region := strings.ToUpper(templateArg(req, "region"))
+	if region == "" {
+		return nil, fmt.Errorf("region is required")
+	}
+	return h.readCached(req, marketTTL, func() (string, error) {
+		items, err := h.client.GetMarketSummary(region)
+		if err != nil {
+			return "", fmt.Errorf("get market summary for %s: %w", region, err)
+		}
+		return formatMarketSummary(region, items), nil
+	})
+}
+
+func streamURI(symbol string) string {
+	return streamURIPrefix + symbol
+}
+
+// onTick is the streamer's tick callback.
+func (h *Handlers) onTick(t stream.Tick) {
+	r := h.resources
+	if r == nil {
+		return
+	}
+	uri := streamURI(t.Symbol)
+	if len(r.subs.subscribers(uri)) == 0 {
+		return
+	}
+	now := time.Now()
+	r.mu.Lock()
+	if now.Sub(r.notified[t.Symbol]) < minUpdateInterval {
+		r.mu.Unlock()
+		return
+	}
+	r.notified[t.Symbol] = now
+	r.mu.Unlock()
+
+	r.updated(uri)
+}
+
+// addStreamResources registers a resource for each newly subscribed symbol.
+func (h *Handlers) addStreamResources(symbols []string) {
+	if h.resources == nil {
+		return
+	}
+	for _, sym := range symbols {
+		res := mcp.NewResource(streamURI(sym), sym+" live quote",
+			mcp.WithResourceDescription(fmt.Sprintf("Last streamed tick for %s; subscribe for updated notifications as prices change", sym)),
+			mcp.WithMIMEType("text/plain"),
+		)
+		h.resources.srv.AddResource(res, h.readStreamResource)
+	}
+}
+
+// removeStreamResources drops the resources of unsubscribed symbols.
+func (h *Handlers) removeStreamResources(symbols []string) {
+	if h.resources == nil || len(symbols) == 0 {
+		return
+	}
+	uris := make([]string, len(symbols))
+	for i, sym := range symbols {
+		uris[i] = streamURI(sym)
+	}
+	h.resources.srv.DeleteResources(uris...)
+
+	h.resources.mu.Lock()
+	for _, sym := range symbols {
+		delete(h.resources.notified, sym)
+	}
+	h.resources.mu.Unlock()
+}
+
+func (h *Handlers) readStreamResource(ctx context.Context, req mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+	uri := req.Params.URI
+	symbol, ok := strings.CutPrefix(uri, streamURIPrefix)
+	if !ok || !h.stream.Subscribed(symbol) {
+		return nil, fmt.Errorf("%s is not a streamed symbol", uri)
+	}
+
+	text := fmt.Sprintf("No ticks received yet for %s\n", symbol)
+	if t, ok := h.stream.Latest(symbol); ok {
+		text = formatTick(t)
+	}
+	return []mcp.ResourceContents{
+		mcp.TextResourceContents{URI: uri, MIMEType: "text/plain", Text: text},
+	}, nil
+}
@@ -0,0 +1,195 @@
+package tools
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"os"
+	"os/signal"
+	"sort"
+	"sync"
+	"syscall"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// Resource subscription methods. The server library advertises the
+// subscribe capability but does not route these, so ServeStdio answers them.
+const (
+	methodResourcesSubscribe   = "resources/subscribe"
+	methodResourcesUnsubscribe = "resources/unsubscribe"
+)
+
+// stdioSessionID is the session ID server.StdioServer registers for its
+// client.
+const stdioSessionID = "stdio"
+
+// subscriptions tracks the resource URIs each client session subscribed to.
+type subscriptions struct {
+	mu       sync.Mutex
+	sessions map[string]map[string]bool // session ID → subscribed URIs
+}
+
+// add subscribes session to uri and reports whether uri had no subscribers
+// before.
+func (s *subscriptions) add(session, uri string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	first := s.count(uri) == 0
+	if s.sessions == nil {
+		s.sessions = make(map[string]map[string]bool)
+	}
+	if s.sessions[session] == nil {
+		s.sessions[session] = make(map[string]bool)
+	}
+	s.sessions[session][uri] = true
+	return first
+}
+
+// remove unsubscribes session from uri and reports whether uri has no
+// subscribers left.
+func (s *subscriptions) remove(session, uri string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if uris := s.sessions[session]; uris[uri] {
+		delete(uris, uri)
+		if len(uris) == 0 {
+			delete(s.sessions, session)
+		}
+		return s.count(uri) == 0
+	}
+	return false
+}
+
+// drop removes every subscription of session and returns the URIs left
+// without subscribers.
+func (s *subscriptions) drop(session string) []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	uris := s.sessions[session]
+	delete(s.sessions, session)
+
+	var orphaned []string
+	for uri := range uris {
+		if s.count(uri) == 0 {
+			orphaned = append(orphaned, uri)
+		}
+	}
+	sort.Strings(orphaned)
+	return orphaned
+}
+
+// subscribers returns the sessions subscribed to uri, in order.
+func (s *subscriptions) subscribers(uri string) []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var out []string
+	for session, uris := range s.sessions {
+		if uris[uri] {
+			out = append(out, session)
+		}
+	}
+	sort.Strings(out)
+	return out
+}
+
+// count returns the number of sessions subscribed to uri. s.mu must be held.
+func (s *subscriptions) count(uri string) int {
+	n := 0
+	for _, uris := range s.sessions {
+		if uris[uri] {
+			n++
+		}
+	}
+	return n
+}
+
+// handleSubscription answers a resources/subscribe or resources/unsubscribe
+// request from session. It reports false for any other message, which the
+// MCP server handles.
+func (r *resourceServer) handleSubscription(session string, msg []byte) (mcp.JSONRPCMessage, bool) {
+	if r == nil {
+		return nil, false
+	}
+	var req struct {
+		ID     *mcp.RequestId `json:"id"`
+		Method string         `json:"method"`
+		Params struct {
+			URI string `json:"uri"`
+		} `json:"params"`
+	}
+	if err := json.Unmarshal(msg, &req); err != nil || req.ID == nil {
+		return nil, false
+	}
+	if req.Method != methodResourcesSubscribe && req.Method != methodResourcesUnsubscribe {
+		return nil, false
+	}
+	if req.Params.URI == "" {
+		return mcp.NewJSONRPCError(*req.ID, mcp.INVALID_PARAMS, "uri is required", nil), true
+	}
+
+	if req.Method == methodResourcesSubscribe {
+		r.subscribe(session, req.Params.URI)
+	} else {
+		r.unsubscribe(session, req.Params.URI)
+	}
+	return mcp.NewJSONRPCResultResponse(*req.ID, mcp.EmptyResult{}), true
+}
+
+// ServeStdio serves s over stdin and stdout as server.ServeStdio does, and
+// answers resource subscription requests, which the server library does not
+// route, so that resources/updated notifications reach subscribed clients.
+func (h *Handlers) ServeStdio(s *server.MCPServer) error {
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGTERM, syscall.SIGINT)
+	defer stop()
+
+	out := &lockedWriter{w: os.Stdout}
+	in, pw := io.Pipe()
+	go h.filterSubscriptions(os.Stdin, pw, out)
+
+	return server.NewStdioServer(s).Listen(ctx, in, out)
+}
+
+// filterSubscriptions copies JSON-RPC messages from src to dst, one per
+// line, answering subscription requests on out instead of forwarding them.
+func (h *Handlers) filterSubscriptions(src io.Reader, dst *io.PipeWriter, out io.Writer) {
+	reader := bufio.NewReader(src)
+	for {
+		line, err := reader.ReadBytes('\n')
+		if len(line) > 0 {
+			if resp, ok := h.resources.handleSubscription(stdioSessionID, line); ok {
+				data, merr := json.Marshal(resp)
+				if merr == nil {
+					_, err = out.Write(append(data, '\n'))
+				} else {
+					err = merr
+				}
+			} else if _, werr := dst.Write(line); werr != nil {
+				return
+			}
+		}
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				err = nil
+			}
+			dst.CloseWithError(err)
+			return
+		}
+	}
+}
+
+// lockedWriter serializes writes, so responses written here do not
+// interleave with the stdio server's, each of which is a single Write.
+type lockedWriter struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+func (l *lockedWriter) Write(p []byte) (int, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.w.Write(p)
+}
@@ -1,6 +1,18 @@
 package tools
 
-import "github.com/mark3labs/mcp-go/mcp"
+import (
+	"strings"
+
+	"github.com/emmanuelay/yahoo-finance-mcp/yahoo"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// resolveParam is the resolve option shared by every tool that takes symbols.
+func resolveParam() mcp.ToolOption {
+	return mcp.WithBoolean("resolve",
+		mcp.Description("Resolve symbols before use, accepting ISINs, CUSIPs, company names, and exchange-qualified tickers such as \"LON:VOD\" or \"Volvo B\" (default: false)"),
+	)
+}
 
 // GetQuoteTool returns the MCP tool definition for get_quote.
 func GetQuoteTool() mcp.Tool {
@@ -10,6 +22,65 @@ func GetQuoteTool() mcp.Tool {
 			mcp.Description("Stock ticker symbol (e.g., AAPL, MSFT, GOOGL)"),
 			mcp.Required(),
 		),
+		resolveParam(),
+		mcp.WithString("currency",
+			mcp.Description("Restate prices in this currency (e.g., USD, EUR); minor units such as GBp are handled"),
+		),
+	)
+}
+
+// StreamQuotesTool returns the MCP tool definition for stream_quotes.
+func StreamQuotesTool() mcp.Tool {
+	return mcp.NewTool("stream_quotes",
+		mcp.WithDescription("Stream real-time prices from Yahoo's websocket streamer. Subscribe symbols to receive every trade and quote change in the background; get_quote then uses the latest tick, and each symbol is published as a yahoo://stream/{symbol} resource that clients can subscribe to for update notifications. List shows the last tick of every subscription."),
+		mcp.WithString("action",
+			mcp.Description("subscribe, unsubscribe, or list (default: list)"),
+			mcp.Enum("subscribe", "unsubscribe", "list"),
+		),
+		mcp.WithString("symbols",
+			mcp.Description("Comma-separated ticker symbols to subscribe or unsubscribe (e.g., \"AAPL,BTC-USD\")"),
+		),
+		resolveParam(),
+	)
+}
+
+// GetCompanySnapshotTool returns the MCP tool definition for get_company_snapshot.
+func GetCompanySnapshotTool() mcp.Tool {
+	return mcp.NewTool("get_company_snapshot",
+		mcp.WithDescription("Get a compact research brief for one company in a single call: quote and valuation, profile, analyst consensus and price targets, headline financials, and recent news. Quote, profile and analyst data come from one combined request; financials and news are fetched concurrently. Use instead of calling get_quote, get_profile, get_recommendations, get_financials and get_news in turn."),
+		mcp.WithString("symbol",
+			mcp.Description("Stock ticker symbol (e.g., AAPL, MSFT, GOOGL)"),
+			mcp.Required(),
+		),
+		resolveParam(),
+		mcp.WithString("sections",
+			mcp.Description("Comma-separated sections to include: quote, profile, analysts, financials, news (default: all)"),
+		),
+		mcp.WithBoolean("quarterly",
+			mcp.Description("Show the last four quarters in the financials section instead of the last four years (default: false)"),
+		),
+		mcp.WithNumber("news_count",
+			mcp.Description("Number of headlines in the news section (default: 5, max: 20)"),
+		),
+	)
+}
+
+// GetQuoteSummaryTool returns the MCP tool definition for get_quote_summary.
+func GetQuoteSummaryTool() mcp.Tool {
+	return mcp.NewTool("get_quote_summary",
+		mcp.WithDescription("Fetch any combination of Yahoo quoteSummary modules for a symbol in one request and return every field they hold. Use when the dedicated tools do not cover the data, e.g. calendarEvents (next earnings and dividend dates), earningsHistory (EPS surprises), insiderTransactions, institutionOwnership, majorHoldersBreakdown, secFilings or esgScores. Known modules: "+strings.Join(yahoo.QuoteSummaryModules, ", ")+". Other module names are passed through to Yahoo."),
+		mcp.WithString("symbol",
+			mcp.Description("Stock ticker symbol (e.g., AAPL, MSFT, GOOGL)"),
+			mcp.Required(),
+		),
+		resolveParam(),
+		mcp.WithString("modules",
+			mcp.Description("Comma-separated module names (e.g., \"calendarEvents,earningsHistory,majorHoldersBreakdown\")"),
+			mcp.Required(),
+		),
+		mcp.WithBoolean("raw",
+			mcp.Description("Return the modules as Yahoo's JSON instead of text (default: false)"),
+		),
 	)
 }
 
@@ -21,6 +92,7 @@ func GetChartTool() mcp.Tool {
 			mcp.Description("Stock ticker symbol (e.g., AAPL, MSFT, GOOGL)"),
 			mcp.Required(),
 		),
+		resolveParam(),
 		mcp.WithString("range",
 			mcp.Description("Time range: 1d, 5d, 1mo, 3mo, 6mo, 1y, 2y, 5y, 10y, ytd, max (default: 1mo)"),
 		),
@@ -33,7 +105,7 @@ func GetChartTool() mcp.Tool {
 // SearchTool returns the MCP tool definition for search.
 func SearchTool() mcp.Tool {
 	return mcp.NewTool("search",
-		mcp.WithDescription("Search for stock symbols and companies by name or ticker"),
+		mcp.WithDescription("Search for stock symbols and companies by name or ticker, with quote type and exchange filters, optional news and curated lists, and optional live prices"),
 		mcp.WithString("query",
 			mcp.Description("Search query (company name or ticker symbol)"),
 			mcp.Required(),
@@ -41,22 +113,108 @@ func SearchTool() mcp.Tool {
 		mcp.WithNumber("limit",
 			mcp.Description("Maximum number of results to return (default: 10)"),
 		),
+		mcp.WithString("type",
+			mcp.Description("Only return this quote type"),
+			mcp.Enum(yahoo.SearchQuoteTypes...),
+		),
+		mcp.WithString("exchange",
+			mcp.Description("Comma-separated exchange codes or names to keep (e.g., \"NMS,NYQ\", \"LSE\", \"Stockholm\")"),
+		),
+		mcp.WithString("region",
+			mcp.Description("Rank results for a region (e.g., US, GB, SE)"),
+		),
+		mcp.WithBoolean("fuzzy",
+			mcp.Description("Tolerate misspellings in the query (default: false)"),
+		),
+		mcp.WithNumber("news",
+			mcp.Description("Number of related news articles to include (default: 0, max: 20)"),
+		),
+		mcp.WithBoolean("lists",
+			mcp.Description("Include Yahoo curated lists matching the query (default: false)"),
+		),
+		mcp.WithBoolean("enrich",
+			mcp.Description("Attach live price, daily change, and market cap to each result (default: false)"),
+		),
 	)
 }
 
 // GetFinancialsTool returns the MCP tool definition for get_financials.
 func GetFinancialsTool() mcp.Tool {
 	return mcp.NewTool("get_financials",
-		mcp.WithDescription("Get financial statements: income statement, balance sheet, or cash flow"),
+		mcp.WithDescription("Get financial statements (income statement, balance sheet, or cash flow) as a line item by period table. Returns a core set of line items by default; request specific items (e.g., ResearchAndDevelopment, SellingGeneralAndAdministration, Inventory, AccountsReceivable, RepurchaseOfCapitalStock, CashDividendsPaid, DilutedAverageShares) or \"all\" for the full catalog. Supports annual, quarterly, and trailing-twelve-month (TTM) periods."),
 		mcp.WithString("symbol",
 			mcp.Description("Stock ticker symbol (e.g., AAPL, MSFT, GOOGL)"),
 			mcp.Required(),
 		),
+		resolveParam(),
 		mcp.WithString("statement",
-			mcp.Description("Financial statement type: income, balance, or cashflow (default: income)"),
+			mcp.Description("Financial statement type: income, balance, or cashflow (default: income, or any statement when items are given)"),
+		),
+		mcp.WithString("period",
+			mcp.Description("Reporting period: annual, quarterly, or trailing (TTM; income and cashflow only) (default: annual)"),
 		),
 		mcp.WithBoolean("quarterly",
-			mcp.Description("If true, return quarterly data instead of annual (default: false)"),
+			mcp.Description("If true, return quarterly data instead of annual (default: false). Ignored when period is set."),
+		),
+		mcp.WithString("items",
+			mcp.Description("Comma-separated line items to return (e.g., \"TotalRevenue,ResearchAndDevelopment,NetIncome\"), or \"all\" for every line item of the statement (default: core items)"),
+		),
+	)
+}
+
+// GetFinancialRatiosTool returns the MCP tool definition for get_financial_ratios.
+func GetFinancialRatiosTool() mcp.Tool {
+	return mcp.NewTool("get_financial_ratios",
+		mcp.WithDescription("Get derived financial ratios per reporting period (gross/operating/net margin, ROE, ROIC, current and quick ratio, debt/equity, interest coverage, FCF conversion) plus YoY, QoQ, and CAGR growth for each line item. All figures for a period come from the same reporting date."),
+		mcp.WithString("symbol",
+			mcp.Description("Stock ticker symbol (e.g., AAPL, MSFT, GOOGL)"),
+			mcp.Required(),
+		),
+		resolveParam(),
+		mcp.WithString("period",
+			mcp.Description("Reporting period: annual or quarterly (default: annual). Quarterly returns are not annualized."),
+		),
+	)
+}
+
+// GetQualityScoresTool returns the MCP tool definition for get_quality_scores.
+func GetQualityScoresTool() mcp.Tool {
+	return mcp.NewTool("get_quality_scores",
+		mcp.WithDescription("Get fundamental quality scores from the latest two fiscal years: Piotroski F-score (0-9 financial strength), Altman Z-score (bankruptcy risk, using current market cap), and Beneish M-score (earnings manipulation risk). Every component is shown with its inputs."),
+		mcp.WithString("symbol",
+			mcp.Description("Stock ticker symbol (e.g., AAPL, MSFT, GOOGL)"),
+			mcp.Required(),
+		),
+		resolveParam(),
+	)
+}
+
+// GetDCFValuationTool returns the MCP tool definition for get_dcf_valuation.
+func GetDCFValuationTool() mcp.Tool {
+	return mcp.NewTool("get_dcf_valuation",
+		mcp.WithDescription("Estimate intrinsic value per share with a two-stage discounted cash flow model seeded from reported free cash flow, analyst growth estimates, net debt and shares outstanding. WACC defaults to CAPM using beta and a Treasury yield. Every input is listed, with a WACC by terminal growth sensitivity grid."),
+		mcp.WithString("symbol",
+			mcp.Description("Stock ticker symbol (e.g., AAPL, MSFT, GOOGL)"),
+			mcp.Required(),
+		),
+		resolveParam(),
+		mcp.WithNumber("growth",
+			mcp.Description("Annual FCF growth during the projection, in percent (default: analyst 5-year estimate, then next-year estimate, then historical FCF CAGR bounded to 0-20)"),
+		),
+		mcp.WithNumber("years",
+			mcp.Description("Projection length in years (default: 5)"),
+		),
+		mcp.WithNumber("terminal_growth",
+			mcp.Description("Perpetual growth after the projection, in percent (default: 2.5)"),
+		),
+		mcp.WithNumber("wacc",
+			mcp.Description("Discount rate in percent (default: derived from beta, the rates symbol and capital structure)"),
+		),
+		mcp.WithString("rates_symbol",
+			mcp.Description("Symbol quoting the risk-free yield in percent (default: ^TNX, the 10-year Treasury)"),
+		),
+		mcp.WithNumber("equity_risk_premium",
+			mcp.Description("Equity risk premium in percent used for the default WACC (default: 5.5)"),
 		),
 	)
 }
@@ -64,14 +222,85 @@ func GetFinancialsTool() mcp.Tool {
 // GetOptionsTool returns the MCP tool definition for get_options.
 func GetOptionsTool() mcp.Tool {
 	return mcp.NewTool("get_options",
-		mcp.WithDescription("Get options chain (calls and puts) with strike prices, volume, open interest, and implied volatility"),
+		mcp.WithDescription("Get options chain (calls and puts) with strike prices, volume, open interest, implied volatility, theoretical value, and Greeks (delta, gamma, theta, vega, rho) for strikes near the money, plus chain analytics: max pain, put/call volume and open interest ratios, expected move from the ATM straddle, and unusual volume"),
 		mcp.WithString("symbol",
 			mcp.Description("Stock ticker symbol (e.g., AAPL, MSFT, GOOGL)"),
 			mcp.Required(),
 		),
+		resolveParam(),
 		mcp.WithString("expiration",
 			mcp.Description("Expiration date as Unix timestamp (omit for nearest expiration)"),
 		),
+		mcp.WithString("model",
+			mcp.Description("Pricing model for theoretical value and Greeks: black_scholes (European, default) or binomial (American exercise)"),
+		),
+		mcp.WithNumber("rate",
+			mcp.Description("Risk-free rate in percent (default: 13-week Treasury bill yield from ^IRX)"),
+		),
+		mcp.WithNumber("dividend_yield",
+			mcp.Description("Continuous dividend yield in percent (default: the underlying's trailing dividend yield)"),
+		),
+		mcp.WithNumber("strikes",
+			mcp.Description("Number of strikes to list below and above the underlying price (default: 10, 0 for all)"),
+		),
+		mcp.WithNumber("min_moneyness",
+			mcp.Description("Lowest strike to list as a percent of the underlying price (e.g., 90)"),
+		),
+		mcp.WithNumber("max_moneyness",
+			mcp.Description("Highest strike to list as a percent of the underlying price (e.g., 110)"),
+		),
+		mcp.WithNumber("min_volume",
+			mcp.Description("Only list contracts with at least this volume"),
+		),
+		mcp.WithNumber("min_open_interest",
+			mcp.Description("Only list contracts with at least this open interest"),
+		),
+	)
+}
+
+// GetVolatilitySurfaceTool returns the MCP tool definition for get_volatility_surface.
+func GetVolatilitySurfaceTool() mcp.Tool {
+	return mcp.NewTool("get_volatility_surface",
+		mcp.WithDescription("Build an implied volatility surface across option expirations: IV by moneyness and tenor, ATM term structure (contango/backwardation), and put/call skew per expiration"),
+		mcp.WithString("symbol",
+			mcp.Description("Stock ticker symbol (e.g., AAPL, MSFT, GOOGL)"),
+			mcp.Required(),
+		),
+		resolveParam(),
+		mcp.WithString("from",
+			mcp.Description("Earliest expiration date to include (YYYY-MM-DD)"),
+		),
+		mcp.WithString("to",
+			mcp.Description("Latest expiration date to include (YYYY-MM-DD)"),
+		),
+		mcp.WithNumber("max_expirations",
+			mcp.Description("Maximum number of expirations, nearest first (default: 8, max: 24)"),
+		),
+	)
+}
+
+// AnalyzeOptionStrategyTool returns the MCP tool definition for analyze_option_strategy.
+func AnalyzeOptionStrategyTool() mcp.Tool {
+	return mcp.NewTool("analyze_option_strategy",
+		mcp.WithDescription("Analyze a multi-leg option position (verticals, straddles, strangles, iron condors, covered calls) priced from the live chain: net debit/credit, max profit/loss, breakevens, probability of profit, position Greeks, and a payoff table at expiration"),
+		mcp.WithString("symbol",
+			mcp.Description("Stock ticker symbol (e.g., AAPL, MSFT, GOOGL)"),
+			mcp.Required(),
+		),
+		resolveParam(),
+		mcp.WithString("legs",
+			mcp.Description("Comma-separated legs as <buy|sell> [quantity] <call|put|shares> [strike], e.g. \"buy call 190, sell call 200\" (bull call spread), \"sell put 170, buy put 165, sell call 210, buy call 215\" (iron condor), or \"buy 100 shares, sell call 200\" (covered call). Quantity is in contracts for options and shares for stock."),
+			mcp.Required(),
+		),
+		mcp.WithString("expiration",
+			mcp.Description("Expiration date as Unix timestamp (omit for nearest expiration); all legs share it"),
+		),
+		mcp.WithNumber("rate",
+			mcp.Description("Risk-free rate in percent (default: 13-week Treasury bill yield from ^IRX)"),
+		),
+		mcp.WithNumber("dividend_yield",
+			mcp.Description("Continuous dividend yield in percent (default: the underlying's trailing dividend yield)"),
+		),
 	)
 }
 
@@ -83,19 +312,218 @@ func GetRecommendationsTool() mcp.Tool {
 			mcp.Description("Stock ticker symbol (e.g., AAPL, MSFT, GOOGL)"),
 			mcp.Required(),
 		),
+		resolveParam(),
+	)
+}
+
+// GetAnalystActionsTool returns the MCP tool definition for get_analyst_actions.
+func GetAnalystActionsTool() mcp.Tool {
+	return mcp.NewTool("get_analyst_actions",
+		mcp.WithDescription("Get analyst upgrade/downgrade history and price targets (low, mean, median, high, number of analysts), with net rating-change momentum over the last N days"),
+		mcp.WithString("symbol",
+			mcp.Description("Stock ticker symbol (e.g., AAPL, MSFT, GOOGL)"),
+			mcp.Required(),
+		),
+		resolveParam(),
+		mcp.WithString("from",
+			mcp.Description("Only include actions on or after this date (YYYY-MM-DD)"),
+		),
+		mcp.WithString("to",
+			mcp.Description("Only include actions on or before this date (YYYY-MM-DD)"),
+		),
+		mcp.WithString("firm",
+			mcp.Description("Only include actions from firms whose name contains this text (e.g., \"Morgan Stanley\")"),
+		),
+		mcp.WithNumber("days",
+			mcp.Description("Window in days for the rating-change momentum summary (default: 90)"),
+		),
+		mcp.WithNumber("limit",
+			mcp.Description("Maximum number of actions to list (default: 25)"),
+		),
 	)
 }
 
 // GetNewsTool returns the MCP tool definition for get_news.
 func GetNewsTool() mcp.Tool {
 	return mcp.NewTool("get_news",
-		mcp.WithDescription("Get recent news articles for a stock symbol"),
+		mcp.WithDescription("Get recent news articles for one or more symbols as a single deduplicated feed, newest first, with related tickers and thumbnails"),
+		mcp.WithString("symbol",
+			mcp.Description("Stock ticker symbol (e.g., AAPL, MSFT, GOOGL)"),
+		),
+		mcp.WithString("symbols",
+			mcp.Description("Comma-separated ticker symbols for a combined watchlist feed (max 20); overrides symbol"),
+		),
+		resolveParam(),
+		mcp.WithNumber("count",
+			mcp.Description("Number of news articles to return (default: 5, max: 50)"),
+		),
+		mcp.WithNumber("offset",
+			mcp.Description("Articles to skip, for paging through the feed (default: 0)"),
+		),
+		mcp.WithString("from",
+			mcp.Description("Only articles published on or after this date (YYYY-MM-DD)"),
+		),
+		mcp.WithString("to",
+			mcp.Description("Only articles published on or before this date (YYYY-MM-DD)"),
+		),
+	)
+}
+
+// GetNewsSentimentTool returns the MCP tool definition for get_news_sentiment.
+func GetNewsSentimentTool() mcp.Tool {
+	return mcp.NewTool("get_news_sentiment",
+		mcp.WithDescription("Score the tone of recent news for one or more symbols with a finance-specific word list, per article and per symbol, listing the terms driving each score and flagging symbols with sharply negative coverage. Runs locally; no external NLP service."),
+		mcp.WithString("symbol",
+			mcp.Description("Stock ticker symbol (e.g., AAPL, MSFT, GOOGL)"),
+		),
+		mcp.WithString("symbols",
+			mcp.Description("Comma-separated ticker symbols to score side by side (max 20); overrides symbol"),
+		),
+		resolveParam(),
+		mcp.WithNumber("count",
+			mcp.Description("Most recent articles to score per symbol (default: 10, max: 50)"),
+		),
+		mcp.WithString("from",
+			mcp.Description("Only articles published on or after this date (YYYY-MM-DD)"),
+		),
+		mcp.WithString("to",
+			mcp.Description("Only articles published on or before this date (YYYY-MM-DD)"),
+		),
+		mcp.WithBoolean("bodies",
+			mcp.Description("Also fetch and score each article's text, not just its headline (slower; default: false)"),
+		),
+	)
+}
+
+// FetchArticleTool returns the MCP tool definition for fetch_article.
+func FetchArticleTool() mcp.Tool {
+	return mcp.NewTool("fetch_article",
+		mcp.WithDescription("Fetch a news article (e.g. a link from get_news) and return its readable text with publisher, author, and publish time. Ads, navigation, and related-story links are removed; paywalled articles are flagged and return only the free preview."),
+		mcp.WithString("url",
+			mcp.Description("Article URL"),
+			mcp.Required(),
+		),
+		mcp.WithNumber("max_chars",
+			mcp.Description("Maximum characters of article text to return (default: 8000, max: 50000)"),
+		),
+	)
+}
+
+// ScreenStocksTool returns the MCP tool definition for screen_stocks.
+func ScreenStocksTool() mcp.Tool {
+	return mcp.NewTool("screen_stocks",
+		mcp.WithDescription("Screen equities with Yahoo's screener, e.g. US technology stocks with market cap above $10B and P/E below 20. Filters are ANDed; set list_fields to see every field and accepted value."),
+		mcp.WithString("filters",
+			mcp.Description("Comma-separated conditions, e.g. \"region = us, sector = Technology, intradaymarketcap > 10B, peratio < 20\". Operators: =, >, >=, <, <=, \"between <low> and <high>\", and \"in a|b\""),
+		),
+		mcp.WithString("sort_field",
+			mcp.Description("Field to sort by (default: intradaymarketcap)"),
+		),
+		mcp.WithString("sort_order",
+			mcp.Description("Sort order: asc or desc (default: desc)"),
+			mcp.Enum("asc", "desc"),
+		),
+		mcp.WithNumber("offset",
+			mcp.Description("Number of results to skip, for pagination (default: 0)"),
+		),
+		mcp.WithNumber("count",
+			mcp.Description("Number of results to return (default: 25, max: 250)"),
+		),
+		mcp.WithBoolean("list_fields",
+			mcp.Description("List the available screener fields and values instead of screening"),
+		),
+	)
+}
+
+// GetMoversTool returns the MCP tool definition for get_movers.
+func GetMoversTool() mcp.Tool {
+	lists := append([]string{yahoo.Trending}, yahoo.PredefinedScreeners...)
+	return mcp.NewTool("get_movers",
+		mcp.WithDescription("Get market movers from Yahoo's predefined screeners (day gainers, day losers, most actives, undervalued growth stocks, and more) or the trending tickers for a region"),
+		mcp.WithString("list",
+			mcp.Description("Which list to return (default: day_gainers); trending returns the most searched-for tickers"),
+			mcp.Enum(lists...),
+		),
+		mcp.WithString("region",
+			mcp.Description("Region code (e.g., US, GB, DE, IN; default: US). Predefined screeners cover US listings; trending is available per region."),
+		),
+		mcp.WithNumber("count",
+			mcp.Description("Number of results (default: 25, max: 100; trending returns at most 50)"),
+		),
+	)
+}
+
+// GetSimilarSymbolsTool returns the MCP tool definition for get_similar_symbols.
+func GetSimilarSymbolsTool() mcp.Tool {
+	return mcp.NewTool("get_similar_symbols",
+		mcp.WithDescription("Get tickers Yahoo recommends as similar to a symbol, with similarity scores and an optional peer comparison table of price, market cap, and valuation multiples"),
 		mcp.WithString("symbol",
 			mcp.Description("Stock ticker symbol (e.g., AAPL, MSFT, GOOGL)"),
 			mcp.Required(),
 		),
+		resolveParam(),
 		mcp.WithNumber("count",
-			mcp.Description("Number of news articles to return (default: 5)"),
+			mcp.Description("Number of similar symbols to return (default: 10, max: 25)"),
+		),
+		mcp.WithBoolean("quotes",
+			mcp.Description("Include quotes and valuation columns for each peer (default: true)"),
+		),
+	)
+}
+
+// CompareFundamentalsTool returns the MCP tool definition for compare_fundamentals.
+func CompareFundamentalsTool() mcp.Tool {
+	return mcp.NewTool("compare_fundamentals",
+		mcp.WithDescription("Compare market cap, P/E, forward P/E, EV/EBITDA, margins, revenue growth, dividend yield, and 1-year return across peers, with the median and each company's percentile rank per column. Pass one symbol to compare it against the top companies in its industry."),
+		mcp.WithString("symbols",
+			mcp.Description("Comma-separated ticker symbols (e.g., \"KO,PEP,KDP\"), or a single symbol to compare against its industry (max 25)"),
+			mcp.Required(),
+		),
+		resolveParam(),
+		mcp.WithNumber("max_peers",
+			mcp.Description("Industry peers to add when a single symbol is given (default: 10, max: 20)"),
+		),
+		mcp.WithString("currency",
+			mcp.Description("Restate market caps in this currency (e.g., USD) so companies listed in different markets compare directly"),
+		),
+	)
+}
+
+// ResolveSymbolTool returns the MCP tool definition for resolve_symbol.
+func ResolveSymbolTool() mcp.Tool {
+	return mcp.NewTool("resolve_symbol",
+		mcp.WithDescription("Resolve an ISIN, CUSIP, company name, or exchange-qualified ticker (e.g., \"LON:VOD\", \"Volvo B\", \"7203 T\") to ranked Yahoo symbols with exchange, currency, and quote type"),
+		mcp.WithString("query",
+			mcp.Description("ISIN, CUSIP, company name, or ticker in any common notation"),
+			mcp.Required(),
+		),
+		mcp.WithString("type",
+			mcp.Description("Restrict results to a quote type (default: all)"),
+			mcp.Enum(yahoo.LookupTypes...),
+		),
+		mcp.WithNumber("limit",
+			mcp.Description("Maximum number of candidates (default: 5, max: 20)"),
+		),
+	)
+}
+
+// ConvertCurrencyTool returns the MCP tool definition for convert_currency.
+func ConvertCurrencyTool() mcp.Tool {
+	return mcp.NewTool("convert_currency",
+		mcp.WithDescription("Convert an amount between currencies at the latest or a historical exchange rate, including minor units such as GBp (pence), ILA and ZAc"),
+		mcp.WithString("from",
+			mcp.Description("Source currency code (e.g., EUR, GBp, JPY)"),
+			mcp.Required(),
+		),
+		mcp.WithString("to",
+			mcp.Description("Target currency code (e.g., USD)"),
+			mcp.Required(),
+		),
+		mcp.WithNumber("amount",
+			mcp.Description("Amount to convert (default: 1)"),
+		),
+		mcp.WithString("date",
+			mcp.Description("Use the closing rate on this date, YYYY-MM-DD (default: latest rate)"),
 		),
 	)
 }
@@ -108,6 +536,10 @@ func GetBulkQuotesTool() mcp.Tool {
 			mcp.Description("Comma-separated stock ticker symbols, max 50 (e.g., \"AAPL,MSFT,GOOGL,AMZN,TSLA\")"),
 			mcp.Required(),
 		),
+		resolveParam(),
+		mcp.WithString("currency",
+			mcp.Description("Restate every quote in this currency (e.g., USD) so prices and market caps are comparable across markets"),
+		),
 	)
 }
 
@@ -119,6 +551,7 @@ func GetBulkSparkTool() mcp.Tool {
 			mcp.Description("Comma-separated stock ticker symbols, max 50 (e.g., \"AAPL,MSFT,GOOGL,AMZN,TSLA\")"),
 			mcp.Required(),
 		),
+		resolveParam(),
 		mcp.WithString("range",
 			mcp.Description("Time range: 1d, 5d, 1mo, 3mo, 6mo, 1y, 2y, 5y, 10y, ytd, max (default: 1mo)"),
 		),
@@ -136,6 +569,19 @@ func GetProfileTool() mcp.Tool {
 			mcp.Description("Stock ticker symbol (e.g., AAPL, MSFT, GOOGL)"),
 			mcp.Required(),
 		),
+		resolveParam(),
+	)
+}
+
+// GetFundProfileTool returns the MCP tool definition for get_fund_profile.
+func GetFundProfileTool() mcp.Tool {
+	return mcp.NewTool("get_fund_profile",
+		mcp.WithDescription("Get ETF or mutual fund profile: expense ratio, assets under management, category, fund family, top holdings with weights, sector weightings, bond ratings, and trailing returns vs category average. Use this instead of get_profile for funds such as SPY or QQQ."),
+		mcp.WithString("symbol",
+			mcp.Description("Fund ticker symbol (e.g., SPY, QQQ, VTSAX)"),
+			mcp.Required(),
+		),
+		resolveParam(),
 	)
 }
 
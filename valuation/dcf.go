@@ -0,0 +1,282 @@
+// Package valuation implements a two-stage discounted cash flow model.
+//
+// The model is deterministic: every result is a pure function of its Inputs,
+// which are returned alongside the valuation so it can be reproduced and
+// audited by hand.
+package valuation
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/emmanuelay/yahoo-finance-mcp/yahoo"
+)
+
+// RequiredItems lists the annual line items Seed reads.
+var RequiredItems = []string{
+	// Income statement
+	"DilutedAverageShares", "TaxRateForCalcs",
+	// Balance sheet
+	"TotalDebt", "CashAndCashEquivalents", "OtherShortTermInvestments",
+	"CashCashEquivalentsAndShortTermInvestments", "OrdinarySharesNumber",
+	// Cash flow
+	"OperatingCashFlow", "CapitalExpenditure", "FreeCashFlow",
+}
+
+// Defaults used when a caller does not override them.
+const (
+	DefaultYears               = 5
+	DefaultTerminalGrowth      = 0.025
+	DefaultEquityRiskPremium   = 0.055
+	DefaultCreditSpread        = 0.015
+	DefaultTaxRate             = 0.21
+	DefaultRatesSymbol         = "^TNX"
+	defaultHistoricalGrowthCap = 0.20
+)
+
+// History is the financial data a DCF is seeded from.
+type History struct {
+	// FCF is free cash flow by fiscal year end, newest first.
+	FCF     []DatedValue
+	Cash    float64
+	Debt    float64
+	Shares  float64
+	TaxRate float64 // zero when not reported
+	// Date is the fiscal year end of the balance sheet figures.
+	Date     string
+	Currency string
+}
+
+// DatedValue is a value reported for a fiscal year end.
+type DatedValue struct {
+	Date  string
+	Value float64
+}
+
+// NetDebt returns total debt less cash and short-term investments.
+func (h History) NetDebt() float64 {
+	return h.Debt - h.Cash
+}
+
+// HistoricalGrowth returns the compound annual growth of free cash flow
+// between the oldest and newest years. It is unavailable when either end is
+// not positive.
+func (h History) HistoricalGrowth() (float64, bool) {
+	if len(h.FCF) < 2 {
+		return 0, false
+	}
+	first, last := h.FCF[len(h.FCF)-1].Value, h.FCF[0].Value
+	if first <= 0 || last <= 0 {
+		return 0, false
+	}
+	years := float64(len(h.FCF) - 1)
+	return math.Pow(last/first, 1/years) - 1, true
+}
+
+// Seed extracts free cash flow history, net debt and share count from an
+// annual statement table. Free cash flow falls back to operating cash flow
+// plus capital expenditure (reported as a negative) when not reported.
+func Seed(t *yahoo.StatementTable) (History, error) {
+	h := History{Currency: t.Currency}
+
+	for _, d := range t.Dates {
+		fcf, ok := t.Value("FreeCashFlow", d)
+		if !ok {
+			ocf, ok1 := t.Value("OperatingCashFlow", d)
+			capex, ok2 := t.Value("CapitalExpenditure", d)
+			if !ok1 || !ok2 {
+				continue
+			}
+			fcf = ocf + capex
+		}
+		h.FCF = append(h.FCF, DatedValue{Date: d, Value: fcf})
+	}
+	if len(h.FCF) == 0 {
+		return h, fmt.Errorf("no free cash flow history reported")
+	}
+
+	// Balance sheet figures come from the latest year that reports a share count.
+	for _, d := range t.Dates {
+		shares, ok := t.Value("OrdinarySharesNumber", d)
+		if !ok {
+			shares, ok = t.Value("DilutedAverageShares", d)
+		}
+		if !ok || shares <= 0 {
+			continue
+		}
+		h.Date, h.Shares = d, shares
+		h.Debt, _ = t.Value("TotalDebt", d)
+		if cash, ok := t.Value("CashCashEquivalentsAndShortTermInvestments", d); ok {
+			h.Cash = cash
+		} else {
+			cash, _ := t.Value("CashAndCashEquivalents", d)
+			sti, _ := t.Value("OtherShortTermInvestments", d)
+			h.Cash = cash + sti
+		}
+		if rate, ok := t.Value("TaxRateForCalcs", d); ok && rate > 0 && rate < 1 {
+			h.TaxRate = rate
+		}
+		break
+	}
+	if h.Shares == 0 {
+		return h, fmt.Errorf("no share count reported")
+	}
+
+	return h, nil
+}
+
+// ClampHistoricalGrowth bounds a historical growth rate to the range used
+// when it seeds a projection, since a few volatile years can imply rates no
+// business sustains for five years.
+func ClampHistoricalGrowth(g float64) float64 {
+	return math.Max(0, math.Min(g, defaultHistoricalGrowthCap))
+}
+
+// WACCInputs are the inputs to a CAPM-based weighted average cost of capital.
+// Rates are decimals (0.045 for 4.5%).
+type WACCInputs struct {
+	RiskFree          float64
+	Beta              float64
+	EquityRiskPremium float64
+	CreditSpread      float64
+	TaxRate           float64
+	MarketCap         float64
+	Debt              float64
+}
+
+// WACCResult is a cost of capital with its intermediate terms.
+type WACCResult struct {
+	CostOfEquity float64 // risk-free + beta × equity risk premium
+	CostOfDebt   float64 // risk-free + credit spread, after tax
+	EquityWeight float64
+	DebtWeight   float64
+	WACC         float64
+}
+
+// WACC computes the weighted average cost of capital using CAPM for the cost
+// of equity and the risk-free rate plus a credit spread for the pre-tax cost
+// of debt. Weights use market capitalization and book debt.
+func WACC(in WACCInputs) (WACCResult, error) {
+	if in.MarketCap <= 0 {
+		return WACCResult{}, fmt.Errorf("market cap must be positive")
+	}
+	debt := math.Max(in.Debt, 0)
+	total := in.MarketCap + debt
+
+	r := WACCResult{
+		CostOfEquity: in.RiskFree + in.Beta*in.EquityRiskPremium,
+		CostOfDebt:   (in.RiskFree + in.CreditSpread) * (1 - in.TaxRate),
+		EquityWeight: in.MarketCap / total,
+		DebtWeight:   debt / total,
+	}
+	r.WACC = r.EquityWeight*r.CostOfEquity + r.DebtWeight*r.CostOfDebt
+	return r, nil
+}
+
+// Inputs fully determine a DCF valuation. Rates are decimals.
+type Inputs struct {
+	BaseFCF        float64
+	Growth         float64 // annual free cash flow growth during the projection
+	Years          int
+	TerminalGrowth float64
+	WACC           float64
+	NetDebt        float64
+	Shares         float64
+}
+
+// Projection is one projected year.
+type Projection struct {
+	Year           int
+	FCF            float64
+	DiscountFactor float64
+	PresentValue   float64
+}
+
+// Result is a DCF valuation.
+type Result struct {
+	Inputs          Inputs
+	Projections     []Projection
+	SumPV           float64 // present value of projected free cash flows
+	TerminalValue   float64 // Gordon growth value at the end of the projection
+	PVTerminal      float64
+	EnterpriseValue float64
+	EquityValue     float64
+	PerShare        float64
+}
+
+// TerminalShare returns the fraction of enterprise value from the terminal value.
+func (r Result) TerminalShare() float64 {
+	if r.EnterpriseValue == 0 {
+		return 0
+	}
+	return r.PVTerminal / r.EnterpriseValue
+}
+
+// DCF values a company by growing BaseFCF at Growth for Years, discounting
+// each year at WACC, and adding a Gordon growth terminal value.
+func DCF(in Inputs) (Result, error) {
+	if in.BaseFCF <= 0 {
+		return Result{}, fmt.Errorf("base free cash flow must be positive, got %.0f", in.BaseFCF)
+	}
+	if in.Years < 1 {
+		return Result{}, fmt.Errorf("projection years must be at least 1")
+	}
+	if in.Shares <= 0 {
+		return Result{}, fmt.Errorf("shares outstanding must be positive")
+	}
+	if in.WACC <= in.TerminalGrowth {
+		return Result{}, fmt.Errorf("WACC (%.2f%%) must exceed terminal growth (%.2f%%)", in.WACC*100, in.TerminalGrowth*100)
+	}
+
+	r := Result{Inputs: in}
+	fcf := in.BaseFCF
+	for y := 1; y <= in.Years; y++ {
+		fcf *= 1 + in.Growth
+		df := 1 / math.Pow(1+in.WACC, float64(y))
+		p := Projection{Year: y, FCF: fcf, DiscountFactor: df, PresentValue: fcf * df}
+		r.Projections = append(r.Projections, p)
+		r.SumPV += p.PresentValue
+	}
+
+	last := r.Projections[len(r.Projections)-1]
+	r.TerminalValue = last.FCF * (1 + in.TerminalGrowth) / (in.WACC - in.TerminalGrowth)
+	r.PVTerminal = r.TerminalValue * last.DiscountFactor
+	r.EnterpriseValue = r.SumPV + r.PVTerminal
+	r.EquityValue = r.EnterpriseValue - in.NetDebt
+	r.PerShare = r.EquityValue / in.Shares
+	return r, nil
+}
+
+// Sensitivity is a grid of per-share values over WACC and terminal growth.
+type Sensitivity struct {
+	WACCs           []float64
+	TerminalGrowths []float64
+	// Values[i][j] is the per-share value at TerminalGrowths[i] and WACCs[j];
+	// NaN where WACC does not exceed terminal growth.
+	Values [][]float64
+}
+
+// SensitivityGrid revalues in at WACC and terminal growth offsets of
+// -2 to +2 steps around the base case.
+func SensitivityGrid(in Inputs, waccStep, growthStep float64) Sensitivity {
+	var s Sensitivity
+	for k := -2; k <= 2; k++ {
+		s.WACCs = append(s.WACCs, in.WACC+float64(k)*waccStep)
+		s.TerminalGrowths = append(s.TerminalGrowths, in.TerminalGrowth+float64(k)*growthStep)
+	}
+
+	for _, g := range s.TerminalGrowths {
+		row := make([]float64, len(s.WACCs))
+		for j, w := range s.WACCs {
+			c := in
+			c.WACC, c.TerminalGrowth = w, g
+			if r, err := DCF(c); err == nil {
+				row[j] = r.PerShare
+			} else {
+				row[j] = math.NaN()
+			}
+		}
+		s.Values = append(s.Values, row)
+	}
+	return s
+}
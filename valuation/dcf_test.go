@@ -0,0 +1,192 @@
+package valuation
+
+import (
+	"math"
+	"testing"
+
+	"github.com/emmanuelay/yahoo-finance-mcp/yahoo"
+)
+
+func table(values map[string]map[string]float64) *yahoo.StatementTable {
+	var results []yahoo.FinancialResult
+	for item, byDate := range values {
+		r := yahoo.FinancialResult{Type: "annual" + item}
+		for d, v := range byDate {
+			r.Items = append(r.Items, yahoo.FinancialItem{Date: d, ReportedValue: v, CurrencyCode: "USD"})
+		}
+		results = append(results, r)
+	}
+	return yahoo.NewStatementTable(results)
+}
+
+func near(a, b float64) bool {
+	return math.Abs(a-b) < 1e-9
+}
+
+func TestDCF(t *testing.T) {
+	in := Inputs{
+		BaseFCF:        100,
+		Growth:         0.10,
+		Years:          2,
+		TerminalGrowth: 0.02,
+		WACC:           0.10,
+		NetDebt:        50,
+		Shares:         10,
+	}
+
+	r, err := DCF(in)
+	if err != nil {
+		t.Fatalf("DCF() error: %v", err)
+	}
+
+	if len(r.Projections) != 2 {
+		t.Fatalf("projections = %d, want 2", len(r.Projections))
+	}
+	if !near(r.Projections[1].FCF, 121) || !near(r.Projections[1].PresentValue, 100) {
+		t.Errorf("year 2 = %+v, want FCF 121 and PV 100", r.Projections[1])
+	}
+	checks := []struct {
+		name      string
+		got, want float64
+	}{
+		{"SumPV", r.SumPV, 200},
+		{"TerminalValue", r.TerminalValue, 1542.75},
+		{"PVTerminal", r.PVTerminal, 1275},
+		{"EnterpriseValue", r.EnterpriseValue, 1475},
+		{"EquityValue", r.EquityValue, 1425},
+		{"PerShare", r.PerShare, 142.5},
+	}
+	for _, c := range checks {
+		if !near(c.got, c.want) {
+			t.Errorf("%s = %v, want %v", c.name, c.got, c.want)
+		}
+	}
+	if !near(r.TerminalShare(), 1275.0/1475.0) {
+		t.Errorf("TerminalShare = %v", r.TerminalShare())
+	}
+}
+
+func TestDCF_Invalid(t *testing.T) {
+	base := Inputs{BaseFCF: 100, Years: 5, TerminalGrowth: 0.02, WACC: 0.08, Shares: 10}
+
+	cases := map[string]func(*Inputs){
+		"negative fcf":      func(in *Inputs) { in.BaseFCF = -1 },
+		"no years":          func(in *Inputs) { in.Years = 0 },
+		"no shares":         func(in *Inputs) { in.Shares = 0 },
+		"wacc below growth": func(in *Inputs) { in.WACC = 0.02 },
+	}
+	for name, mod := range cases {
+		in := base
+		mod(&in)
+		if _, err := DCF(in); err == nil {
+			t.Errorf("%s: expected error", name)
+		}
+	}
+}
+
+func TestSensitivityGrid(t *testing.T) {
+	in := Inputs{BaseFCF: 100, Growth: 0.05, Years: 5, TerminalGrowth: 0.03, WACC: 0.04, Shares: 10}
+
+	s := SensitivityGrid(in, 0.01, 0.005)
+	if len(s.WACCs) != 5 || len(s.TerminalGrowths) != 5 || len(s.Values) != 5 {
+		t.Fatalf("grid dimensions = %d x %d", len(s.TerminalGrowths), len(s.WACCs))
+	}
+
+	base, err := DCF(in)
+	if err != nil {
+		t.Fatalf("DCF() error: %v", err)
+	}
+	if !near(s.Values[2][2], base.PerShare) {
+		t.Errorf("center = %v, want base case %v", s.Values[2][2], base.PerShare)
+	}
+	// WACC 2% against terminal growth 3% is not a valid model
+	if !math.IsNaN(s.Values[2][0]) {
+		t.Errorf("Values[2][0] = %v, want NaN", s.Values[2][0])
+	}
+	// higher WACC lowers value
+	if !(s.Values[0][3] > s.Values[0][4]) {
+		t.Errorf("value should fall as WACC rises: %v", s.Values[0])
+	}
+}
+
+func TestWACC(t *testing.T) {
+	r, err := WACC(WACCInputs{
+		RiskFree:          0.04,
+		Beta:              1.2,
+		EquityRiskPremium: 0.05,
+		CreditSpread:      0.01,
+		TaxRate:           0.25,
+		MarketCap:         800,
+		Debt:              200,
+	})
+	if err != nil {
+		t.Fatalf("WACC() error: %v", err)
+	}
+	if !near(r.CostOfEquity, 0.10) {
+		t.Errorf("CostOfEquity = %v, want 0.10", r.CostOfEquity)
+	}
+	if !near(r.CostOfDebt, 0.0375) {
+		t.Errorf("CostOfDebt = %v, want 0.0375", r.CostOfDebt)
+	}
+	if !near(r.WACC, 0.0875) {
+		t.Errorf("WACC = %v, want 0.0875", r.WACC)
+	}
+
+	if _, err := WACC(WACCInputs{RiskFree: 0.04}); err == nil {
+		t.Error("expected error without market cap")
+	}
+}
+
+func TestSeed(t *testing.T) {
+	tbl := table(map[string]map[string]float64{
+		"FreeCashFlow":              {"2023-12-31": 121, "2021-12-31": 100},
+		"OperatingCashFlow":         {"2022-12-31": 150},
+		"CapitalExpenditure":        {"2022-12-31": -40},
+		"TotalDebt":                 {"2023-12-31": 300},
+		"CashAndCashEquivalents":    {"2023-12-31": 80},
+		"OtherShortTermInvestments": {"2023-12-31": 20},
+		"OrdinarySharesNumber":      {"2023-12-31": 50},
+		"TaxRateForCalcs":           {"2023-12-31": 0.19},
+	})
+
+	h, err := Seed(tbl)
+	if err != nil {
+		t.Fatalf("Seed() error: %v", err)
+	}
+
+	if len(h.FCF) != 3 || h.FCF[1].Value != 110 {
+		t.Fatalf("FCF = %+v, want 3 years with 2022 derived as 110", h.FCF)
+	}
+	if h.NetDebt() != 200 {
+		t.Errorf("NetDebt = %v, want 200", h.NetDebt())
+	}
+	if h.Shares != 50 || h.Date != "2023-12-31" {
+		t.Errorf("shares = %v at %s, want 50 at 2023-12-31", h.Shares, h.Date)
+	}
+	if h.TaxRate != 0.19 {
+		t.Errorf("TaxRate = %v, want 0.19", h.TaxRate)
+	}
+	g, ok := h.HistoricalGrowth()
+	if !ok || !near(g, 0.1) {
+		t.Errorf("HistoricalGrowth = %v (%v), want 0.1", g, ok)
+	}
+}
+
+func TestSeed_Missing(t *testing.T) {
+	if _, err := Seed(table(map[string]map[string]float64{
+		"OrdinarySharesNumber": {"2023-12-31": 50},
+	})); err == nil {
+		t.Error("expected error without cash flow history")
+	}
+	if _, err := Seed(table(map[string]map[string]float64{
+		"FreeCashFlow": {"2023-12-31": 50},
+	})); err == nil {
+		t.Error("expected error without share count")
+	}
+}
+
+func TestClampHistoricalGrowth(t *testing.T) {
+	if ClampHistoricalGrowth(0.5) != 0.20 || ClampHistoricalGrowth(-0.1) != 0 || ClampHistoricalGrowth(0.07) != 0.07 {
+		t.Error("ClampHistoricalGrowth bounds not applied")
+	}
+}
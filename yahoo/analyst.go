@@ -0,0 +1,88 @@
+package yahoo
+
+import (
+	"strings"
+	"time"
+)
+
+// AnalystActionFilter narrows an upgrade/downgrade history.
+// Zero values disable the corresponding filter.
+type AnalystActionFilter struct {
+	From time.Time
+	To   time.Time
+	Firm string // case-insensitive substring match
+}
+
+// RatingMomentum summarizes rating changes over a trailing window.
+type RatingMomentum struct {
+	Days         int
+	Upgrades     int
+	Downgrades   int
+	Initiations  int
+	Reiterated   int
+	TargetRaises int
+	TargetCuts   int
+	Net          int // upgrades minus downgrades
+}
+
+// GetAnalystActions fetches the upgrade/downgrade history and analyst price targets for a symbol.
+func (c *Client) GetAnalystActions(symbol string) (*UpgradeDowngradeHistoryData, *FinancialDataModule, error) {
+	result, err := c.quoteSummary("get analyst actions", symbol, "upgradeDowngradeHistory,financialData")
+	if err != nil {
+		return nil, nil, err
+	}
+	return result.UpgradeDowngradeHistory, result.FinancialData, nil
+}
+
+// FilterAnalystActions returns the actions matching the filter, preserving order.
+func FilterAnalystActions(actions []AnalystAction, f AnalystActionFilter) []AnalystAction {
+	firm := strings.ToLower(strings.TrimSpace(f.Firm))
+
+	var out []AnalystAction
+	for _, a := range actions {
+		ts := time.Unix(a.EpochGradeDate, 0)
+		if !f.From.IsZero() && ts.Before(f.From) {
+			continue
+		}
+		// To is inclusive of the whole day when given as a date
+		if !f.To.IsZero() && !ts.Before(f.To.Add(24*time.Hour)) {
+			continue
+		}
+		if firm != "" && !strings.Contains(strings.ToLower(a.Firm), firm) {
+			continue
+		}
+		out = append(out, a)
+	}
+	return out
+}
+
+// ComputeRatingMomentum counts rating changes in the days before now.
+func ComputeRatingMomentum(actions []AnalystAction, days int, now time.Time) RatingMomentum {
+	m := RatingMomentum{Days: days}
+	cutoff := now.AddDate(0, 0, -days)
+
+	for _, a := range actions {
+		if time.Unix(a.EpochGradeDate, 0).Before(cutoff) {
+			continue
+		}
+		switch strings.ToLower(a.Action) {
+		case "up":
+			m.Upgrades++
+		case "down":
+			m.Downgrades++
+		case "init":
+			m.Initiations++
+		case "main", "reit":
+			m.Reiterated++
+		}
+		switch strings.ToLower(a.PriceTargetAction) {
+		case "raises":
+			m.TargetRaises++
+		case "lowers":
+			m.TargetCuts++
+		}
+	}
+
+	m.Net = m.Upgrades - m.Downgrades
+	return m
+}
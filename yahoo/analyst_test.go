@@ -0,0 +1,157 @@
+package yahoo
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestGetAnalystActions_Success(t *testing.T) {
+	client := newTestClient(func(req *http.Request) (*http.Response, error) {
+		if !strings.Contains(req.URL.Path, "/v10/finance/quoteSummary/AAPL") {
+			t.Errorf("unexpected path: %s", req.URL.Path)
+		}
+		modules := req.URL.Query().Get("modules")
+		if modules != "upgradeDowngradeHistory,financialData" {
+			t.Errorf("modules = %q, want %q", modules, "upgradeDowngradeHistory,financialData")
+		}
+		return jsonResponse(200, `{
+			"quoteSummary": {
+				"result": [{
+					"upgradeDowngradeHistory": {
+						"history": [
+							{
+								"epochGradeDate": 1700000000,
+								"firm": "Morgan Stanley",
+								"toGrade": "Overweight",
+								"fromGrade": "Equal-Weight",
+								"action": "up",
+								"priceTargetAction": "Raises",
+								"currentPriceTarget": 250.0,
+								"priorPriceTarget": 210.0
+							}
+						]
+					},
+					"financialData": {
+						"currentPrice": {"raw": 178.72, "fmt": "178.72"},
+						"targetLowPrice": {"raw": 150.0, "fmt": "150.00"},
+						"targetMeanPrice": {"raw": 205.5, "fmt": "205.50"},
+						"targetMedianPrice": {"raw": 210.0, "fmt": "210.00"},
+						"targetHighPrice": {"raw": 250.0, "fmt": "250.00"},
+						"numberOfAnalystOpinions": {"raw": 38, "fmt": "38"},
+						"recommendationKey": "buy"
+					}
+				}]
+			}
+		}`), nil
+	})
+
+	history, fin, err := client.GetAnalystActions("AAPL")
+	if err != nil {
+		t.Fatalf("GetAnalystActions() error: %v", err)
+	}
+
+	if len(history.History) != 1 {
+		t.Fatalf("history count = %d, want 1", len(history.History))
+	}
+	a := history.History[0]
+	if a.Firm != "Morgan Stanley" {
+		t.Errorf("Firm = %q, want %q", a.Firm, "Morgan Stanley")
+	}
+	if a.Action != "up" {
+		t.Errorf("Action = %q, want %q", a.Action, "up")
+	}
+	if a.CurrentPriceTarget != 250.0 {
+		t.Errorf("CurrentPriceTarget = %v, want 250", a.CurrentPriceTarget)
+	}
+
+	if fin.TargetMedianPrice.Raw != 210.0 {
+		t.Errorf("TargetMedianPrice = %v, want 210", fin.TargetMedianPrice.Raw)
+	}
+	if fin.NumberOfAnalystOpinions.Raw != 38 {
+		t.Errorf("NumberOfAnalystOpinions = %d, want 38", fin.NumberOfAnalystOpinions.Raw)
+	}
+}
+
+func TestGetAnalystActions_YahooError(t *testing.T) {
+	client := newTestClient(func(req *http.Request) (*http.Response, error) {
+		return jsonResponse(200, `{
+			"quoteSummary": {
+				"result": null,
+				"error": {"code": "Not Found", "description": "Symbol not found"}
+			}
+		}`), nil
+	})
+
+	_, _, err := client.GetAnalystActions("INVALID")
+	if err == nil {
+		t.Fatal("expected error for yahoo error response")
+	}
+	if !strings.Contains(err.Error(), "Symbol not found") {
+		t.Errorf("error should contain description, got: %v", err)
+	}
+}
+
+func testActions() []AnalystAction {
+	day := func(s string) int64 {
+		ts, _ := time.Parse("2006-01-02", s)
+		return ts.Unix()
+	}
+	return []AnalystAction{
+		{EpochGradeDate: day("2024-03-20"), Firm: "Morgan Stanley", Action: "up", PriceTargetAction: "Raises"},
+		{EpochGradeDate: day("2024-03-10"), Firm: "Goldman Sachs", Action: "down", PriceTargetAction: "Lowers"},
+		{EpochGradeDate: day("2024-02-15"), Firm: "Morgan Stanley", Action: "main", PriceTargetAction: "Raises"},
+		{EpochGradeDate: day("2024-01-05"), Firm: "JP Morgan", Action: "up"},
+		{EpochGradeDate: day("2023-11-01"), Firm: "Barclays", Action: "init"},
+	}
+}
+
+func TestFilterAnalystActions_DateRange(t *testing.T) {
+	from, _ := time.Parse("2006-01-02", "2024-01-01")
+	to, _ := time.Parse("2006-01-02", "2024-03-10")
+
+	got := FilterAnalystActions(testActions(), AnalystActionFilter{From: from, To: to})
+	if len(got) != 3 {
+		t.Fatalf("filtered count = %d, want 3", len(got))
+	}
+	if got[0].Firm != "Goldman Sachs" {
+		t.Errorf("got[0].Firm = %q, want %q (to date should be inclusive)", got[0].Firm, "Goldman Sachs")
+	}
+}
+
+func TestFilterAnalystActions_Firm(t *testing.T) {
+	got := FilterAnalystActions(testActions(), AnalystActionFilter{Firm: "morgan"})
+	if len(got) != 3 {
+		t.Errorf("filtered count = %d, want 3", len(got))
+	}
+}
+
+func TestComputeRatingMomentum(t *testing.T) {
+	now, _ := time.Parse("2006-01-02", "2024-03-31")
+
+	m := ComputeRatingMomentum(testActions(), 60, now)
+	if m.Upgrades != 1 {
+		t.Errorf("Upgrades = %d, want 1", m.Upgrades)
+	}
+	if m.Downgrades != 1 {
+		t.Errorf("Downgrades = %d, want 1", m.Downgrades)
+	}
+	if m.Reiterated != 1 {
+		t.Errorf("Reiterated = %d, want 1", m.Reiterated)
+	}
+	if m.TargetRaises != 2 || m.TargetCuts != 1 {
+		t.Errorf("target raises/cuts = %d/%d, want 2/1", m.TargetRaises, m.TargetCuts)
+	}
+	if m.Net != 0 {
+		t.Errorf("Net = %d, want 0", m.Net)
+	}
+
+	m = ComputeRatingMomentum(testActions(), 365, now)
+	if m.Net != 1 {
+		t.Errorf("Net over a year = %d, want 1", m.Net)
+	}
+	if m.Initiations != 1 {
+		t.Errorf("Initiations = %d, want 1", m.Initiations)
+	}
+}
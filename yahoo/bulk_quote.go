@@ -35,6 +35,8 @@ type BulkQuoteResult struct {
 	MarketCap                  int64   `json:"marketCap"`
 	TrailingPE                 float64 `json:"trailingPE"`
 	ForwardPE                  float64 `json:"forwardPE"`
+	PriceToBook                float64 `json:"priceToBook"`
+	EpsTrailingTwelveMonths    float64 `json:"epsTrailingTwelveMonths"`
 	FiftyTwoWeekLow            float64 `json:"fiftyTwoWeekLow"`
 	FiftyTwoWeekHigh           float64 `json:"fiftyTwoWeekHigh"`
 	FiftyDayAverage            float64 `json:"fiftyDayAverage"`
@@ -3,6 +3,8 @@ package yahoo
 import (
 	"fmt"
 	"net/url"
+	"strconv"
+	"time"
 )
 
 // GetChart fetches historical OHLCV chart data for a symbol.
@@ -35,3 +37,35 @@ func (c *Client) GetChart(symbol, rangeStr, interval string) (*ChartResult, erro
 
 	return &resp.Chart.Result[0], nil
 }
+
+// GetChartRange fetches OHLCV chart data for a symbol between start and end.
+func (c *Client) GetChartRange(symbol string, start, end time.Time, interval string) (*ChartResult, error) {
+	if !end.After(start) {
+		return nil, fmt.Errorf("end must be after start")
+	}
+	if interval == "" {
+		interval = "1d"
+	}
+
+	params := url.Values{
+		"period1":  {strconv.FormatInt(start.Unix(), 10)},
+		"period2":  {strconv.FormatInt(end.Unix(), 10)},
+		"interval": {interval},
+	}
+
+	var resp ChartResponse
+	path := fmt.Sprintf("/v8/finance/chart/%s", url.PathEscape(symbol))
+	if err := c.GetJSON(path, params, false, &resp); err != nil {
+		return nil, fmt.Errorf("get chart: %w", err)
+	}
+
+	if resp.Chart.Error != nil {
+		return nil, fmt.Errorf("yahoo error: %s", resp.Chart.Error.Description)
+	}
+
+	if len(resp.Chart.Result) == 0 {
+		return nil, fmt.Errorf("no chart data found for symbol %q", symbol)
+	}
+
+	return &resp.Chart.Result[0], nil
+}
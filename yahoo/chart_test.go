@@ -4,6 +4,7 @@ import (
 	"net/http"
 	"strings"
 	"testing"
+	"time"
 )
 
 func TestGetChart_Success(t *testing.T) {
@@ -141,3 +142,53 @@ func TestGetChart_EmptyResults(t *testing.T) {
 		t.Errorf("error should mention no chart data, got: %v", err)
 	}
 }
+
+func TestGetChartRange_Params(t *testing.T) {
+	start := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	end := start.AddDate(0, 0, 7)
+
+	client := newTestClient(func(req *http.Request) (*http.Response, error) {
+		if !strings.Contains(req.URL.Path, "/v8/finance/chart/EURUSD=X") {
+			t.Errorf("unexpected path: %s", req.URL.Path)
+		}
+		q := req.URL.Query()
+		if q.Get("period1") != "1709251200" || q.Get("period2") != "1709856000" {
+			t.Errorf("period1/period2 = %s/%s", q.Get("period1"), q.Get("period2"))
+		}
+		if q.Get("interval") != "1d" {
+			t.Errorf("interval = %q, want 1d", q.Get("interval"))
+		}
+		if q.Get("range") != "" {
+			t.Errorf("range should not be set, got %q", q.Get("range"))
+		}
+		return jsonResponse(200, `{
+			"chart": {
+				"result": [{
+					"meta": {"currency": "USD", "symbol": "EURUSD=X"},
+					"timestamp": [1709251200],
+					"indicators": {"quote": [{"close": [1.0805]}]}
+				}]
+			}
+		}`), nil
+	})
+
+	result, err := client.GetChartRange("EURUSD=X", start, end, "")
+	if err != nil {
+		t.Fatalf("GetChartRange() error: %v", err)
+	}
+	if *result.Indicators.Quote[0].Close[0] != 1.0805 {
+		t.Errorf("close = %v, want 1.0805", *result.Indicators.Quote[0].Close[0])
+	}
+}
+
+func TestGetChartRange_InvalidRange(t *testing.T) {
+	client := newTestClient(func(req *http.Request) (*http.Response, error) {
+		t.Fatal("should not make a request for an empty range")
+		return nil, nil
+	})
+
+	now := time.Now()
+	if _, err := client.GetChartRange("AAPL", now, now, "1d"); err == nil {
+		t.Fatal("expected error when end is not after start")
+	}
+}
@@ -1,6 +1,7 @@
 package yahoo
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -153,6 +154,19 @@ func (c *Client) doGet(url string) ([]byte, int, error) {
 	if err != nil {
 		return nil, 0, fmt.Errorf("creating request: %w", err)
 	}
+	return c.do(req)
+}
+
+func (c *Client) doPost(url string, payload []byte) ([]byte, int, error) {
+	req, err := http.NewRequest("POST", url, bytes.NewReader(payload))
+	if err != nil {
+		return nil, 0, fmt.Errorf("creating request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	return c.do(req)
+}
+
+func (c *Client) do(req *http.Request) ([]byte, int, error) {
 	req.Header.Set("User-Agent", randomUA())
 	req.Header.Set("Accept", "application/json")
 
@@ -182,6 +196,50 @@ func (c *Client) GetJSON(path string, params url.Values, needsCrumb bool, v inte
 	return nil
 }
 
+// PostJSON performs an authenticated POST of body as JSON to a Yahoo Finance
+// API endpoint and unmarshals the JSON response into v.
+func (c *Client) PostJSON(path string, params url.Values, body, v any) error {
+	payload, err := json.Marshal(body)
+	if err != nil {
+		return fmt.Errorf("encoding request body: %w", err)
+	}
+	if err := c.ensureAuth(); err != nil {
+		return err
+	}
+
+	if params == nil {
+		params = url.Values{}
+	}
+	params.Set("crumb", c.getCrumb())
+	fullURL := baseURL + path + "?" + params.Encode()
+
+	respBody, statusCode, err := c.doPost(fullURL, payload)
+	if err != nil {
+		return err
+	}
+
+	// Retry on 401/403
+	if statusCode == http.StatusUnauthorized || statusCode == http.StatusForbidden {
+		if err := c.authenticate(); err != nil {
+			return fmt.Errorf("re-authentication failed: %w", err)
+		}
+		params.Set("crumb", c.getCrumb())
+		fullURL = baseURL + path + "?" + params.Encode()
+		respBody, statusCode, err = c.doPost(fullURL, payload)
+		if err != nil {
+			return err
+		}
+	}
+
+	if statusCode != http.StatusOK {
+		return fmt.Errorf("API request failed with status %d: %s", statusCode, string(respBody))
+	}
+	if err := json.Unmarshal(respBody, v); err != nil {
+		return fmt.Errorf("parsing JSON response: %w", err)
+	}
+	return nil
+}
+
 // GetAbsoluteJSON fetches an absolute URL with crumb auth and unmarshals the JSON response into v.
 func (c *Client) GetAbsoluteJSON(absoluteURL string, params url.Values, v any) error {
 	if err := c.ensureAuth(); err != nil {
@@ -1,6 +1,7 @@
 package yahoo
 
 import (
+	"io"
 	"net/http"
 	"net/url"
 	"strings"
@@ -180,3 +181,65 @@ func TestGetCrumb_ThreadSafe(t *testing.T) {
 		t.Errorf("getCrumb() = %q, want %q", crumb, "test-crumb")
 	}
 }
+
+func TestPostJSON_SendsBodyAndCrumb(t *testing.T) {
+	client := newTestClient(func(req *http.Request) (*http.Response, error) {
+		if req.Method != http.MethodPost {
+			t.Errorf("method = %s, want POST", req.Method)
+		}
+		if crumb := req.URL.Query().Get("crumb"); crumb != "test-crumb" {
+			t.Errorf("crumb = %q, want %q", crumb, "test-crumb")
+		}
+		if ct := req.Header.Get("Content-Type"); ct != "application/json" {
+			t.Errorf("Content-Type = %q, want application/json", ct)
+		}
+		body, _ := io.ReadAll(req.Body)
+		if string(body) != `{"size":5}` {
+			t.Errorf("body = %s, want {\"size\":5}", body)
+		}
+		return jsonResponse(200, `{"data":"ok"}`), nil
+	})
+
+	var result struct {
+		Data string `json:"data"`
+	}
+	if err := client.PostJSON("/v1/finance/screener", nil, map[string]int{"size": 5}, &result); err != nil {
+		t.Fatalf("PostJSON() error: %v", err)
+	}
+	if result.Data != "ok" {
+		t.Errorf("Data = %q, want %q", result.Data, "ok")
+	}
+}
+
+func TestPostJSON_RetryOn403(t *testing.T) {
+	var callCount atomic.Int32
+
+	client := newTestClient(func(req *http.Request) (*http.Response, error) {
+		if req.URL.Host == "fc.yahoo.com" {
+			return textResponse(404, ""), nil
+		}
+		if strings.Contains(req.URL.Path, "/v1/test/getcrumb") {
+			return textResponse(200, "new-crumb"), nil
+		}
+
+		body, _ := io.ReadAll(req.Body)
+		if string(body) != `{"size":5}` {
+			t.Errorf("body = %s, want the payload on every attempt", body)
+		}
+		if callCount.Add(1) == 1 {
+			return jsonResponse(403, `{"error":"Forbidden"}`), nil
+		}
+		if crumb := req.URL.Query().Get("crumb"); crumb != "new-crumb" {
+			t.Errorf("retry crumb = %q, want %q", crumb, "new-crumb")
+		}
+		return jsonResponse(200, `{}`), nil
+	})
+
+	var result map[string]any
+	if err := client.PostJSON("/v1/finance/screener", nil, map[string]int{"size": 5}, &result); err != nil {
+		t.Fatalf("PostJSON() error: %v", err)
+	}
+	if callCount.Load() != 2 {
+		t.Errorf("expected 2 calls to API endpoint, got %d", callCount.Load())
+	}
+}
@@ -0,0 +1,23 @@
+package yahoo
+
+// GetEarningsTrend fetches consensus EPS and revenue estimates with growth rates for a symbol.
+func (c *Client) GetEarningsTrend(symbol string) (*EarningsTrendData, error) {
+	result, err := c.quoteSummary("get earnings trend", symbol, "earningsTrend")
+	if err != nil {
+		return nil, err
+	}
+	return result.EarningsTrend, nil
+}
+
+// Period returns the trend entry for a period key such as "+1y" or "+5y".
+func (d *EarningsTrendData) Period(period string) (EarningsTrend, bool) {
+	if d == nil {
+		return EarningsTrend{}, false
+	}
+	for _, t := range d.Trend {
+		if t.Period == period {
+			return t, true
+		}
+	}
+	return EarningsTrend{}, false
+}
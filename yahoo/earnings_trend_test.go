@@ -0,0 +1,71 @@
+package yahoo
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestGetEarningsTrend_Success(t *testing.T) {
+	client := newTestClient(func(req *http.Request) (*http.Response, error) {
+		if !strings.Contains(req.URL.Path, "/v10/finance/quoteSummary/AAPL") {
+			t.Errorf("unexpected path: %s", req.URL.Path)
+		}
+		if modules := req.URL.Query().Get("modules"); modules != "earningsTrend" {
+			t.Errorf("modules = %q, want %q", modules, "earningsTrend")
+		}
+		return jsonResponse(200, `{
+			"quoteSummary": {
+				"result": [{
+					"earningsTrend": {
+						"trend": [
+							{"period": "0y", "endDate": "2024-09-30", "growth": {"raw": 0.095},
+							 "earningsEstimate": {"avg": {"raw": 6.57}, "yearAgoEps": {"raw": 6.08}, "numberOfAnalysts": {"raw": 39}},
+							 "revenueEstimate": {"avg": {"raw": 415000000000}, "yearAgoRevenue": {"raw": 391035000000}}},
+							{"period": "+1y", "endDate": "2025-09-30", "growth": {"raw": 0.102}},
+							{"period": "+5y", "growth": {"raw": 0.11}}
+						]
+					}
+				}]
+			}
+		}`), nil
+	})
+
+	trend, err := client.GetEarningsTrend("AAPL")
+	if err != nil {
+		t.Fatalf("GetEarningsTrend() error: %v", err)
+	}
+
+	if len(trend.Trend) != 3 {
+		t.Fatalf("trend count = %d, want 3", len(trend.Trend))
+	}
+	cur, ok := trend.Period("0y")
+	if !ok {
+		t.Fatal("expected 0y period")
+	}
+	if cur.EarningsEstimate.Avg.Raw != 6.57 {
+		t.Errorf("0y EPS estimate = %v, want 6.57", cur.EarningsEstimate.Avg.Raw)
+	}
+	if cur.EarningsEstimate.NumberOfAnalysts.Raw != 39 {
+		t.Errorf("0y analysts = %d, want 39", cur.EarningsEstimate.NumberOfAnalysts.Raw)
+	}
+	if cur.EarningsEstimate.YearAgoEps.Raw != 6.08 {
+		t.Errorf("0y year-ago EPS = %v, want 6.08", cur.EarningsEstimate.YearAgoEps.Raw)
+	}
+	if cur.RevenueEstimate.YearAgoRevenue.Raw != 391035000000 {
+		t.Errorf("0y year-ago revenue = %v, want 391035000000", cur.RevenueEstimate.YearAgoRevenue.Raw)
+	}
+	if lt, ok := trend.Period("+5y"); !ok || lt.Growth.Raw != 0.11 {
+		t.Errorf("+5y growth = %v (found %v), want 0.11", lt.Growth.Raw, ok)
+	}
+	if _, ok := trend.Period("-5y"); ok {
+		t.Error("unexpected -5y period")
+	}
+}
+
+func TestEarningsTrendPeriod_Nil(t *testing.T) {
+	var trend *EarningsTrendData
+	if _, ok := trend.Period("+1y"); ok {
+		t.Error("expected no period on nil trend data")
+	}
+}
@@ -4,54 +4,185 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/url"
+	"sort"
 	"strings"
 	"time"
 )
 
-// Financial statement type names for the timeseries endpoint.
-var incomeStatementTypes = []string{
-	"annualTotalRevenue", "annualCostOfRevenue", "annualGrossProfit",
-	"annualOperatingExpense", "annualOperatingIncome",
-	"annualNetIncome", "annualEbitda", "annualBasicEPS", "annualDilutedEPS",
+// Financial statement periods. Each line item is requested from the
+// timeseries endpoint as <period><Item>, e.g. "annualTotalRevenue".
+const (
+	PeriodAnnual    = "annual"
+	PeriodQuarterly = "quarterly"
+	PeriodTrailing  = "trailing"
+)
+
+// Income statement line items, in presentation order.
+var incomeStatementItems = []string{
+	"TotalRevenue", "OperatingRevenue", "ExciseTaxes",
+	"CostOfRevenue", "ReconciledCostOfRevenue", "GrossProfit",
+	"OperatingExpense", "SellingGeneralAndAdministration", "SellingAndMarketingExpense",
+	"GeneralAndAdministrativeExpense", "OtherGandA", "SalariesAndWages",
+	"RentAndLandingFees", "InsuranceAndClaims", "ResearchAndDevelopment",
+	"DepreciationAmortizationDepletionIncomeStatement", "DepreciationAndAmortizationInIncomeStatement",
+	"DepreciationIncomeStatement", "Amortization", "AmortizationOfIntangiblesIncomeStatement",
+	"DepletionIncomeStatement", "ProvisionForDoubtfulAccounts", "OtherTaxes", "OtherOperatingExpenses",
+	"OperatingIncome", "TotalOperatingIncomeAsReported",
+	"NetNonOperatingInterestIncomeExpense", "InterestIncomeNonOperating", "InterestExpenseNonOperating",
+	"TotalOtherFinanceCost", "OtherIncomeExpense", "GainOnSaleOfSecurity", "EarningsFromEquityInterest",
+	"SecuritiesAmortization", "SpecialIncomeCharges", "RestructuringAndMergernAcquisition",
+	"ImpairmentOfCapitalAssets", "WriteOff", "OtherSpecialCharges", "GainOnSaleOfBusiness",
+	"GainOnSaleOfPPE", "OtherNonOperatingIncomeExpenses",
+	"PretaxIncome", "TaxProvision", "EarningsFromEquityInterestNetOfTax",
+	"NetIncomeContinuousOperations", "NetIncomeDiscontinuousOperations", "NetIncomeExtraordinary",
+	"NetIncomeFromTaxLossCarryforward", "NetIncomeIncludingNoncontrollingInterests", "MinorityInterests",
+	"NetIncome", "PreferredStockDividends", "OtherunderPreferredStockDividend",
+	"NetIncomeCommonStockholders", "AverageDilutionEarnings", "DilutedNIAvailtoComStockholders",
+	"BasicEPS", "BasicContinuousOperations", "BasicDiscontinuousOperations", "BasicExtraordinary",
+	"BasicAccountingChange", "TaxLossCarryforwardBasicEPS", "BasicEPSOtherGainsLosses",
+	"DilutedEPS", "DilutedContinuousOperations", "DilutedDiscontinuousOperations", "DilutedExtraordinary",
+	"DilutedAccountingChange", "TaxLossCarryforwardDilutedEPS", "DilutedEPSOtherGainsLosses",
+	"BasicAverageShares", "DilutedAverageShares", "DividendPerShare",
+	"ReportedNormalizedBasicEPS", "ReportedNormalizedDilutedEPS", "RentExpenseSupplemental",
+	"TotalExpenses", "NetIncomeFromContinuingAndDiscontinuedOperation", "NormalizedIncome",
+	"ContinuingAndDiscontinuedBasicEPS", "ContinuingAndDiscontinuedDilutedEPS",
+	"InterestIncome", "InterestExpense", "NetInterestIncome", "EBIT", "EBITDA",
+	"ReconciledDepreciation", "NetIncomeFromContinuingOperationNetMinorityInterest",
+	"TotalUnusualItemsExcludingGoodwill", "TotalUnusualItems",
+	"NormalizedBasicEPS", "NormalizedDilutedEPS", "NormalizedEBITDA",
+	"TaxRateForCalcs", "TaxEffectOfUnusualItems",
+	"LossAdjustmentExpense", "NetPolicyholderBenefitsAndClaims", "PolicyholderBenefitsGross",
+	"PolicyholderBenefitsCeded", "OccupancyAndEquipment",
+	"ProfessionalExpenseAndContractServicesExpense", "OtherNonInterestExpense",
 }
 
-var balanceSheetTypes = []string{
-	"annualTotalAssets", "annualTotalLiabilitiesNetMinorityInterest",
-	"annualStockholdersEquity", "annualCashAndCashEquivalents",
-	"annualCurrentAssets", "annualCurrentLiabilities",
-	"annualTotalDebt", "annualNetDebt",
+// Balance sheet line items, in presentation order.
+var balanceSheetItems = []string{
+	"TotalAssets",
+	"CurrentAssets", "CashCashEquivalentsAndShortTermInvestments", "CashAndCashEquivalents",
+	"CashFinancial", "CashEquivalents", "OtherShortTermInvestments",
+	"Receivables", "AccountsReceivable", "GrossAccountsReceivable", "AllowanceForDoubtfulAccountsReceivable",
+	"LoansReceivable", "NotesReceivable", "AccruedInterestReceivable", "TaxesReceivable",
+	"DuefromRelatedPartiesCurrent", "OtherReceivables", "ReceivablesAdjustmentsAllowances",
+	"Inventory", "RawMaterials", "WorkInProcess", "FinishedGoods", "OtherInventories",
+	"InventoriesAdjustmentsAllowances", "PrepaidAssets", "RestrictedCash",
+	"CurrentDeferredAssets", "CurrentDeferredTaxesAssets", "AssetsHeldForSaleCurrent",
+	"HedgingAssetsCurrent", "OtherCurrentAssets",
+	"TotalNonCurrentAssets", "NetPPE", "GrossPPE", "Properties", "LandAndImprovements",
+	"BuildingsAndImprovements", "MachineryFurnitureEquipment", "OtherProperties",
+	"ConstructionInProgress", "Leases", "AccumulatedDepreciation",
+	"GoodwillAndOtherIntangibleAssets", "Goodwill", "OtherIntangibleAssets",
+	"InvestmentProperties", "InvestmentsAndAdvances", "LongTermEquityInvestment",
+	"InvestmentsinSubsidiariesatCost", "InvestmentsinAssociatesatCost",
+	"InvestmentsInOtherVenturesUnderEquityMethod", "InvestmentsinJointVenturesatCost",
+	"InvestmentinFinancialAssets", "TradingSecurities",
+	"FinancialAssetsDesignatedasFairValueThroughProfitorLossTotal", "AvailableForSaleSecurities",
+	"HeldToMaturitySecurities", "OtherInvestments", "FinancialAssets",
+	"NonCurrentAccountsReceivable", "NonCurrentNoteReceivables", "DuefromRelatedPartiesNonCurrent",
+	"NonCurrentDeferredAssets", "NonCurrentDeferredTaxesAssets", "NonCurrentPrepaidAssets",
+	"DefinedPensionBenefit", "OtherNonCurrentAssets",
+	"TotalLiabilitiesNetMinorityInterest",
+	"CurrentLiabilities", "PayablesAndAccruedExpenses", "Payables", "AccountsPayable",
+	"TotalTaxPayable", "IncomeTaxPayable", "DividendsPayable", "DuetoRelatedPartiesCurrent",
+	"OtherPayable", "InterestPayable", "CurrentAccruedExpenses", "CurrentProvisions",
+	"PensionandOtherPostRetirementBenefitPlansCurrent",
+	"CurrentDebtAndCapitalLeaseObligation", "CurrentDebt", "CurrentNotesPayable", "CommercialPaper",
+	"LineOfCredit", "OtherCurrentBorrowings", "CurrentCapitalLeaseObligation",
+	"CurrentDeferredLiabilities", "CurrentDeferredRevenue", "CurrentDeferredTaxesLiabilities",
+	"OtherCurrentLiabilities",
+	"TotalNonCurrentLiabilitiesNetMinorityInterest", "LongTermProvisions",
+	"LongTermDebtAndCapitalLeaseObligation", "LongTermDebt", "LongTermCapitalLeaseObligation",
+	"NonCurrentDeferredLiabilities", "NonCurrentDeferredRevenue", "NonCurrentDeferredTaxesLiabilities",
+	"TradeandOtherPayablesNonCurrent", "DuetoRelatedPartiesNonCurrent", "NonCurrentAccruedExpenses",
+	"NonCurrentPensionAndOtherPostretirementBenefitPlans", "EmployeeBenefits",
+	"DerivativeProductLiabilities", "PreferredSecuritiesOutsideStockEquity", "RestrictedCommonStock",
+	"LiabilitiesHeldforSaleNonCurrent", "OtherNonCurrentLiabilities",
+	"TotalEquityGrossMinorityInterest", "StockholdersEquity", "CapitalStock", "PreferredStock",
+	"CommonStock", "OtherCapitalStock", "AdditionalPaidInCapital", "RetainedEarnings", "TreasuryStock",
+	"GainsLossesNotAffectingRetainedEarnings", "UnrealizedGainLoss", "MinimumPensionLiabilities",
+	"ForeignCurrencyTranslationAdjustments", "FixedAssetsRevaluationReserve", "OtherEquityAdjustments",
+	"OtherEquityInterest", "TotalPartnershipCapital", "GeneralPartnershipCapital",
+	"LimitedPartnershipCapital", "MinorityInterest",
+	"TotalCapitalization", "PreferredStockEquity", "CommonStockEquity", "CapitalLeaseObligations",
+	"NetTangibleAssets", "WorkingCapital", "InvestedCapital", "TangibleBookValue",
+	"TotalDebt", "NetDebt", "ShareIssued", "OrdinarySharesNumber", "PreferredSharesNumber",
+	"TreasurySharesNumber",
 }
 
-var cashFlowTypes = []string{
-	"annualOperatingCashFlow", "annualInvestingCashFlow",
-	"annualFinancingCashFlow", "annualFreeCashFlow",
-	"annualCapitalExpenditure",
+// Cash flow statement line items, in presentation order.
+var cashFlowItems = []string{
+	"OperatingCashFlow", "CashFlowFromContinuingOperatingActivities",
+	"NetIncomeFromContinuingOperations", "OperatingGainsLosses", "GainLossOnSaleOfBusiness",
+	"GainLossOnSaleOfPPE", "NetForeignCurrencyExchangeGainLoss", "GainLossOnInvestmentSecurities",
+	"EarningsLossesFromEquityInvestments", "PensionAndEmployeeBenefitExpense",
+	"DepreciationAmortizationDepletion", "DepreciationAndAmortization", "Depreciation",
+	"AmortizationCashFlow", "AmortizationOfIntangibles", "Depletion", "AmortizationOfSecurities",
+	"DeferredTax", "DeferredIncomeTax", "AssetImpairmentCharge", "ProvisionandWriteOffofAssets",
+	"UnrealizedGainLossOnInvestmentSecurities", "StockBasedCompensation",
+	"ExcessTaxBenefitFromStockBasedCompensation", "OtherNonCashItems",
+	"ChangeInWorkingCapital", "ChangeInReceivables", "ChangesInAccountReceivables",
+	"ChangeInInventory", "ChangeInPrepaidAssets", "ChangeInPayablesAndAccruedExpense",
+	"ChangeInPayable", "ChangeInAccountPayable", "ChangeInTaxPayable", "ChangeInIncomeTaxPayable",
+	"ChangeInDividendPayable", "ChangeInInterestPayable", "ChangeInAccruedExpense",
+	"ChangeInOtherCurrentAssets", "ChangeInOtherCurrentLiabilities", "ChangeInOtherWorkingCapital",
+	"DividendPaidCFO", "DividendReceivedCFO", "InterestPaidCFO", "InterestReceivedCFO",
+	"TaxesRefundPaid", "CashFromDiscontinuedOperatingActivities",
+	"CashFlowsfromusedinOperatingActivitiesDirect", "ClassesofCashReceiptsfromOperatingActivities",
+	"ReceiptsfromCustomers", "ReceiptsfromGovernmentGrants", "OtherCashReceiptsfromOperatingActivities",
+	"ClassesofCashPayments", "PaymentstoSuppliersforGoodsandServices", "PaymentsonBehalfofEmployees",
+	"OtherCashPaymentsfromOperatingActivities", "DividendsPaidDirect", "DividendsReceivedDirect",
+	"InterestPaidDirect", "InterestReceivedDirect", "TaxesRefundPaidDirect",
+	"InvestingCashFlow", "CashFlowFromContinuingInvestingActivities",
+	"CapitalExpenditureReported", "NetPPEPurchaseAndSale", "PurchaseOfPPE", "SaleOfPPE",
+	"NetIntangiblesPurchaseAndSale", "PurchaseOfIntangibles", "SaleOfIntangibles",
+	"NetBusinessPurchaseAndSale", "PurchaseOfBusiness", "SaleOfBusiness",
+	"NetInvestmentPropertiesPurchaseAndSale", "PurchaseOfInvestmentProperties",
+	"SaleOfInvestmentProperties", "NetInvestmentPurchaseAndSale", "PurchaseOfInvestment",
+	"SaleOfInvestment", "DividendsReceivedCFI", "InterestReceivedCFI", "NetOtherInvestingChanges",
+	"CashFromDiscontinuedInvestingActivities",
+	"FinancingCashFlow", "CashFlowFromContinuingFinancingActivities",
+	"NetIssuancePaymentsOfDebt", "NetLongTermDebtIssuance", "LongTermDebtIssuance",
+	"LongTermDebtPayments", "NetShortTermDebtIssuance", "ShortTermDebtIssuance",
+	"ShortTermDebtPayments", "NetCommonStockIssuance", "CommonStockIssuance", "CommonStockPayments",
+	"NetPreferredStockIssuance", "PreferredStockIssuance", "PreferredStockPayments",
+	"CashDividendsPaid", "CommonStockDividendPaid", "PreferredStockDividendPaid",
+	"ProceedsFromStockOptionExercised", "InterestPaidCFF", "NetOtherFinancingCharges",
+	"CashFromDiscontinuedFinancingActivities",
+	"CashFlowFromDiscontinuedOperation", "OtherCashAdjustmentInsideChangeinCash",
+	"ChangesInCash", "EffectOfExchangeRateChanges", "BeginningCashPosition",
+	"OtherCashAdjustmentOutsideChangeinCash", "EndCashPosition",
+	"IncomeTaxPaidSupplementalData", "InterestPaidSupplementalData",
+	"CapitalExpenditure", "IssuanceOfCapitalStock", "IssuanceOfDebt", "RepaymentOfDebt",
+	"RepurchaseOfCapitalStock", "FreeCashFlow",
+	"DomesticSales", "ForeignSales", "AdjustedGeographySegmentData",
 }
 
-var quarterlyIncomeStatementTypes = []string{
-	"quarterlyTotalRevenue", "quarterlyCostOfRevenue", "quarterlyGrossProfit",
-	"quarterlyOperatingExpense", "quarterlyOperatingIncome",
-	"quarterlyNetIncome", "quarterlyEbitda", "quarterlyBasicEPS", "quarterlyDilutedEPS",
+// Line items returned when the caller does not ask for specific ones.
+var defaultIncomeStatementItems = []string{
+	"TotalRevenue", "CostOfRevenue", "GrossProfit",
+	"OperatingExpense", "OperatingIncome",
+	"NetIncome", "EBITDA", "BasicEPS", "DilutedEPS",
 }
 
-var quarterlyBalanceSheetTypes = []string{
-	"quarterlyTotalAssets", "quarterlyTotalLiabilitiesNetMinorityInterest",
-	"quarterlyStockholdersEquity", "quarterlyCashAndCashEquivalents",
-	"quarterlyCurrentAssets", "quarterlyCurrentLiabilities",
-	"quarterlyTotalDebt", "quarterlyNetDebt",
+var defaultBalanceSheetItems = []string{
+	"TotalAssets", "TotalLiabilitiesNetMinorityInterest",
+	"StockholdersEquity", "CashAndCashEquivalents",
+	"CurrentAssets", "CurrentLiabilities",
+	"TotalDebt", "NetDebt",
 }
 
-var quarterlyCashFlowTypes = []string{
-	"quarterlyOperatingCashFlow", "quarterlyInvestingCashFlow",
-	"quarterlyFinancingCashFlow", "quarterlyFreeCashFlow",
-	"quarterlyCapitalExpenditure",
+var defaultCashFlowItems = []string{
+	"OperatingCashFlow", "InvestingCashFlow",
+	"FinancingCashFlow", "FreeCashFlow",
+	"CapitalExpenditure",
 }
 
 // FinancialItem represents a single financial data point.
 type FinancialItem struct {
-	Date           string  `json:"asOfDate"`
-	ReportedValue  float64 `json:"-"`
-	CurrencyCode   string  `json:"currencyCode"`
+	Date          string  `json:"asOfDate"`
+	ReportedValue float64 `json:"-"`
+	CurrencyCode  string  `json:"currencyCode"`
+	PeriodType    string  `json:"periodType"`
 }
 
 // FinancialResult holds parsed financial data for a metric.
@@ -60,13 +191,40 @@ type FinancialResult struct {
 	Items []FinancialItem
 }
 
-// GetFinancials fetches financial statement data for a symbol.
+// Item returns the line item name without its period prefix (e.g. "TotalRevenue").
+func (r FinancialResult) Item() string {
+	return LineItemName(r.Type)
+}
+
+// FinancialsRequest selects line items from the fundamentals-timeseries endpoint.
+type FinancialsRequest struct {
+	// Statement is income, balance or cashflow. It may be empty when Items are given.
+	Statement string
+	// Period is annual (default), quarterly or trailing (TTM).
+	Period string
+	// Items lists line items such as "TotalRevenue" or "ResearchAndDevelopment".
+	// Empty selects the statement's default items; "all" selects its full catalog.
+	// Items requested together share reporting dates, so request everything
+	// a calculation combines in one call.
+	Items []string
+}
+
+// GetFinancials fetches the default line items of a financial statement for a symbol.
 // statement can be "income", "balance", or "cashflow".
 // If quarterly is true, fetches quarterly data instead of annual.
 func (c *Client) GetFinancials(symbol, statement string, quarterly bool) ([]FinancialResult, error) {
-	types := getFinancialTypes(statement, quarterly)
-	if len(types) == 0 {
-		return nil, fmt.Errorf("invalid statement type %q (use income, balance, or cashflow)", statement)
+	period := PeriodAnnual
+	if quarterly {
+		period = PeriodQuarterly
+	}
+	return c.GetFinancialStatement(symbol, FinancialsRequest{Statement: statement, Period: period})
+}
+
+// GetFinancialStatement fetches the requested statement line items for a symbol.
+func (c *Client) GetFinancialStatement(symbol string, r FinancialsRequest) ([]FinancialResult, error) {
+	types, err := financialTypes(r)
+	if err != nil {
+		return nil, err
 	}
 
 	params := url.Values{
@@ -85,26 +243,140 @@ func (c *Client) GetFinancials(symbol, statement string, quarterly bool) ([]Fina
 	return parseFinancialsResponse(body, types)
 }
 
-func getFinancialTypes(statement string, quarterly bool) []string {
-	switch strings.ToLower(statement) {
+// FinancialLineItems returns the full line item catalog for a statement
+// (income, balance or cashflow), or nil for an unknown statement.
+func FinancialLineItems(statement string) []string {
+	switch normalizeStatement(statement) {
 	case "income":
-		if quarterly {
-			return quarterlyIncomeStatementTypes
-		}
-		return incomeStatementTypes
+		return incomeStatementItems
 	case "balance":
-		if quarterly {
-			return quarterlyBalanceSheetTypes
+		return balanceSheetItems
+	case "cashflow":
+		return cashFlowItems
+	default:
+		return nil
+	}
+}
+
+// LineItemName strips the period prefix from a timeseries type name.
+func LineItemName(typeName string) string {
+	for _, p := range []string{PeriodAnnual, PeriodQuarterly, PeriodTrailing} {
+		if strings.HasPrefix(typeName, p) {
+			return typeName[len(p):]
 		}
-		return balanceSheetTypes
+	}
+	return typeName
+}
+
+func normalizeStatement(statement string) string {
+	switch strings.ToLower(statement) {
+	case "income":
+		return "income"
+	case "balance":
+		return "balance"
 	case "cashflow", "cash_flow":
-		if quarterly {
-			return quarterlyCashFlowTypes
-		}
-		return cashFlowTypes
+		return "cashflow"
 	default:
+		return ""
+	}
+}
+
+func getFinancialTypes(statement string, quarterly bool) []string {
+	period := PeriodAnnual
+	if quarterly {
+		period = PeriodQuarterly
+	}
+	types, err := financialTypes(FinancialsRequest{Statement: statement, Period: period})
+	if err != nil {
 		return nil
 	}
+	return types
+}
+
+// financialTypes resolves a request to prefixed timeseries type names.
+func financialTypes(r FinancialsRequest) ([]string, error) {
+	period := strings.ToLower(r.Period)
+	switch period {
+	case "":
+		period = PeriodAnnual
+	case "ttm":
+		period = PeriodTrailing
+	case PeriodAnnual, PeriodQuarterly, PeriodTrailing:
+	default:
+		return nil, fmt.Errorf("invalid period %q (use annual, quarterly, or trailing)", r.Period)
+	}
+
+	statement := normalizeStatement(r.Statement)
+	if r.Statement != "" && statement == "" {
+		return nil, fmt.Errorf("invalid statement type %q (use income, balance, or cashflow)", r.Statement)
+	}
+
+	var items []string
+	switch {
+	case len(r.Items) == 1 && strings.EqualFold(r.Items[0], "all"):
+		if statement == "" {
+			return nil, fmt.Errorf("a statement is required when requesting all line items")
+		}
+		items = FinancialLineItems(statement)
+	case len(r.Items) > 0:
+		for _, name := range r.Items {
+			item, itemStatement := lookupLineItem(name)
+			if item == "" {
+				return nil, fmt.Errorf("unknown line item %q", name)
+			}
+			if statement != "" && itemStatement != statement {
+				return nil, fmt.Errorf("line item %q is not part of the %s statement", item, statement)
+			}
+			items = append(items, item)
+		}
+	default:
+		switch statement {
+		case "income":
+			items = defaultIncomeStatementItems
+		case "balance":
+			items = defaultBalanceSheetItems
+		case "cashflow":
+			items = defaultCashFlowItems
+		default:
+			return nil, fmt.Errorf("invalid statement type %q (use income, balance, or cashflow)", r.Statement)
+		}
+	}
+
+	types := make([]string, 0, len(items))
+	for _, item := range items {
+		// Balance sheet values are point-in-time; Yahoo publishes no TTM series for them.
+		if period == PeriodTrailing && lineItemIndex[strings.ToLower(item)].statement == "balance" {
+			return nil, fmt.Errorf("trailing (TTM) data is not available for balance sheet item %q", item)
+		}
+		types = append(types, period+item)
+	}
+	return types, nil
+}
+
+// lineItemIndex maps lowercased line item names to their canonical name and statement.
+var lineItemIndex = map[string]lineItemRef{}
+
+type lineItemRef struct {
+	name      string
+	statement string
+}
+
+func init() {
+	for _, statement := range []string{"income", "balance", "cashflow"} {
+		for _, item := range FinancialLineItems(statement) {
+			lineItemIndex[strings.ToLower(item)] = lineItemRef{name: item, statement: statement}
+		}
+	}
+}
+
+// lookupLineItem resolves a line item name case-insensitively, with or
+// without a period prefix, returning its canonical name and statement.
+func lookupLineItem(name string) (string, string) {
+	ref, ok := lineItemIndex[strings.ToLower(LineItemName(strings.TrimSpace(name)))]
+	if !ok {
+		return "", ""
+	}
+	return ref.name, ref.statement
 }
 
 func parseFinancialsResponse(body []byte, types []string) ([]FinancialResult, error) {
@@ -122,7 +394,7 @@ func parseFinancialsResponse(body []byte, types []string) ([]FinancialResult, er
 		return nil, fmt.Errorf("yahoo error: %s", raw.Timeseries.Error.Description)
 	}
 
-	var results []FinancialResult
+	byType := make(map[string]FinancialResult)
 
 	for _, rawResult := range raw.Timeseries.Result {
 		var resultMap map[string]json.RawMessage
@@ -137,8 +409,9 @@ func parseFinancialsResponse(body []byte, types []string) ([]FinancialResult, er
 				continue
 			}
 
-			var items []struct {
+			var items []*struct {
 				AsOfDate      string `json:"asOfDate"`
+				PeriodType    string `json:"periodType"`
 				ReportedValue struct {
 					Raw float64 `json:"raw"`
 					Fmt string  `json:"fmt"`
@@ -151,15 +424,84 @@ func parseFinancialsResponse(body []byte, types []string) ([]FinancialResult, er
 
 			fr := FinancialResult{Type: typeName}
 			for _, item := range items {
+				// Periods without a reported value come back as null entries
+				if item == nil || item.AsOfDate == "" {
+					continue
+				}
 				fr.Items = append(fr.Items, FinancialItem{
 					Date:          item.AsOfDate,
 					ReportedValue: item.ReportedValue.Raw,
 					CurrencyCode:  item.CurrencyCode,
+					PeriodType:    item.PeriodType,
 				})
 			}
+			if len(fr.Items) > 0 {
+				byType[typeName] = fr
+			}
+		}
+	}
+
+	// Return results in request order so statements read top to bottom
+	var results []FinancialResult
+	for _, typeName := range types {
+		if fr, ok := byType[typeName]; ok {
 			results = append(results, fr)
 		}
 	}
 
 	return results, nil
 }
+
+// StatementTable aligns financial results by reporting date so that each
+// line item can be read period by period. Results should share one period
+// (annual, quarterly or trailing).
+type StatementTable struct {
+	Dates    []string // newest first
+	Items    []string // line items in result order
+	Currency string
+	values   map[string]map[string]float64
+}
+
+// NewStatementTable builds a line item by date table from financial results.
+func NewStatementTable(results []FinancialResult) *StatementTable {
+	t := &StatementTable{values: make(map[string]map[string]float64)}
+	seen := make(map[string]bool)
+
+	for _, r := range results {
+		item := r.Item()
+		if _, ok := t.values[item]; !ok {
+			t.Items = append(t.Items, item)
+			t.values[item] = make(map[string]float64)
+		}
+		for _, fi := range r.Items {
+			t.values[item][fi.Date] = fi.ReportedValue
+			if !seen[fi.Date] {
+				seen[fi.Date] = true
+				t.Dates = append(t.Dates, fi.Date)
+			}
+			if t.Currency == "" {
+				t.Currency = fi.CurrencyCode
+			}
+		}
+	}
+
+	// asOfDate is YYYY-MM-DD, so string order is chronological
+	sort.Sort(sort.Reverse(sort.StringSlice(t.Dates)))
+	return t
+}
+
+// Value returns the value of a line item for a date, if reported.
+func (t *StatementTable) Value(item, date string) (float64, bool) {
+	v, ok := t.values[item][date]
+	return v, ok
+}
+
+// Latest returns the most recent reported value of a line item and its date.
+func (t *StatementTable) Latest(item string) (float64, string, bool) {
+	for _, d := range t.Dates {
+		if v, ok := t.values[item][d]; ok {
+			return v, d, true
+		}
+	}
+	return 0, "", false
+}
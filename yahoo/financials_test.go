@@ -233,3 +233,156 @@ func TestGetFinancials_Success(t *testing.T) {
 		t.Errorf("results[0].Type = %q, want %q", results[0].Type, "annualTotalRevenue")
 	}
 }
+
+func TestFinancialTypes_Trailing(t *testing.T) {
+	types, err := financialTypes(FinancialsRequest{Statement: "cashflow", Period: "trailing"})
+	if err != nil {
+		t.Fatalf("financialTypes() error: %v", err)
+	}
+	if types[0] != "trailingOperatingCashFlow" {
+		t.Errorf("first trailing cashflow type = %q, want %q", types[0], "trailingOperatingCashFlow")
+	}
+}
+
+func TestFinancialTypes_TrailingBalanceSheet(t *testing.T) {
+	_, err := financialTypes(FinancialsRequest{Statement: "balance", Period: "ttm"})
+	if err == nil {
+		t.Fatal("expected error for trailing balance sheet")
+	}
+	if !strings.Contains(err.Error(), "not available") {
+		t.Errorf("error should mention not available, got: %v", err)
+	}
+}
+
+func TestFinancialTypes_All(t *testing.T) {
+	types, err := financialTypes(FinancialsRequest{Statement: "income", Items: []string{"all"}})
+	if err != nil {
+		t.Fatalf("financialTypes() error: %v", err)
+	}
+	if len(types) != len(incomeStatementItems) {
+		t.Errorf("all income types = %d, want %d", len(types), len(incomeStatementItems))
+	}
+}
+
+func TestFinancialTypes_SpecificItems(t *testing.T) {
+	types, err := financialTypes(FinancialsRequest{
+		Period: "quarterly",
+		Items:  []string{"researchanddevelopment", "Inventory", "annualRepurchaseOfCapitalStock"},
+	})
+	if err != nil {
+		t.Fatalf("financialTypes() error: %v", err)
+	}
+	want := []string{"quarterlyResearchAndDevelopment", "quarterlyInventory", "quarterlyRepurchaseOfCapitalStock"}
+	if strings.Join(types, ",") != strings.Join(want, ",") {
+		t.Errorf("types = %v, want %v", types, want)
+	}
+}
+
+func TestFinancialTypes_ItemFromOtherStatement(t *testing.T) {
+	_, err := financialTypes(FinancialsRequest{Statement: "income", Items: []string{"Inventory"}})
+	if err == nil {
+		t.Fatal("expected error for balance sheet item on income statement")
+	}
+}
+
+func TestFinancialTypes_UnknownItem(t *testing.T) {
+	_, err := financialTypes(FinancialsRequest{Statement: "income", Items: []string{"MadeUpItem"}})
+	if err == nil {
+		t.Fatal("expected error for unknown line item")
+	}
+	if !strings.Contains(err.Error(), "unknown line item") {
+		t.Errorf("error should mention unknown line item, got: %v", err)
+	}
+}
+
+func TestFinancialTypes_InvalidPeriod(t *testing.T) {
+	_, err := financialTypes(FinancialsRequest{Statement: "income", Period: "weekly"})
+	if err == nil {
+		t.Fatal("expected error for invalid period")
+	}
+}
+
+func TestLineItemName(t *testing.T) {
+	for in, want := range map[string]string{
+		"annualTotalRevenue":   "TotalRevenue",
+		"quarterlyNetIncome":   "NetIncome",
+		"trailingFreeCashFlow": "FreeCashFlow",
+		"EBITDA":               "EBITDA",
+	} {
+		if got := LineItemName(in); got != want {
+			t.Errorf("LineItemName(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestParseFinancialsResponse_SkipsNullEntriesAndKeepsRequestOrder(t *testing.T) {
+	body := []byte(`{
+		"timeseries": {
+			"result": [
+				{
+					"meta": {"symbol": ["AAPL"], "type": ["annualNetIncome"]},
+					"annualNetIncome": [
+						null,
+						{"asOfDate": "2023-09-30", "periodType": "12M", "reportedValue": {"raw": 96995000000}, "currencyCode": "USD"}
+					]
+				},
+				{
+					"meta": {"symbol": ["AAPL"], "type": ["annualTotalRevenue"]},
+					"annualTotalRevenue": [
+						{"asOfDate": "2023-09-30", "periodType": "12M", "reportedValue": {"raw": 383285000000}, "currencyCode": "USD"}
+					]
+				}
+			]
+		}
+	}`)
+
+	results, err := parseFinancialsResponse(body, []string{"annualTotalRevenue", "annualNetIncome"})
+	if err != nil {
+		t.Fatalf("parseFinancialsResponse() error: %v", err)
+	}
+	if len(results) != 2 {
+		t.Fatalf("results count = %d, want 2", len(results))
+	}
+	if results[0].Type != "annualTotalRevenue" {
+		t.Errorf("results[0].Type = %q, want request order", results[0].Type)
+	}
+	if len(results[1].Items) != 1 {
+		t.Errorf("null entries should be skipped, got %d items", len(results[1].Items))
+	}
+	if results[1].Items[0].PeriodType != "12M" {
+		t.Errorf("PeriodType = %q, want %q", results[1].Items[0].PeriodType, "12M")
+	}
+}
+
+func TestStatementTable(t *testing.T) {
+	results := []FinancialResult{
+		{Type: "annualTotalRevenue", Items: []FinancialItem{
+			{Date: "2022-09-30", ReportedValue: 394e9, CurrencyCode: "USD"},
+			{Date: "2023-09-30", ReportedValue: 383e9, CurrencyCode: "USD"},
+		}},
+		{Type: "annualNetIncome", Items: []FinancialItem{
+			{Date: "2021-09-30", ReportedValue: 94e9, CurrencyCode: "USD"},
+			{Date: "2022-09-30", ReportedValue: 99e9, CurrencyCode: "USD"},
+		}},
+	}
+
+	table := NewStatementTable(results)
+	if strings.Join(table.Dates, ",") != "2023-09-30,2022-09-30,2021-09-30" {
+		t.Errorf("Dates = %v, want newest first", table.Dates)
+	}
+	if strings.Join(table.Items, ",") != "TotalRevenue,NetIncome" {
+		t.Errorf("Items = %v", table.Items)
+	}
+	if table.Currency != "USD" {
+		t.Errorf("Currency = %q, want USD", table.Currency)
+	}
+	if v, ok := table.Value("NetIncome", "2022-09-30"); !ok || v != 99e9 {
+		t.Errorf("Value(NetIncome, 2022-09-30) = %v, %v", v, ok)
+	}
+	if _, ok := table.Value("NetIncome", "2023-09-30"); ok {
+		t.Error("expected missing value for unreported period")
+	}
+	if v, d, ok := table.Latest("NetIncome"); !ok || d != "2022-09-30" || v != 99e9 {
+		t.Errorf("Latest(NetIncome) = %v, %q, %v", v, d, ok)
+	}
+}
@@ -0,0 +1,8 @@
+package yahoo
+
+// GetFundProfile fetches ETF/mutual fund profile, holdings and performance for a symbol.
+// The returned result has QuoteType, SummaryDetail, FundProfile, TopHoldings and
+// FundPerformance populated when Yahoo provides them.
+func (c *Client) GetFundProfile(symbol string) (*QuoteSummaryResult, error) {
+	return c.quoteSummary("get fund profile", symbol, "quoteType,summaryDetail,fundProfile,topHoldings,fundPerformance")
+}
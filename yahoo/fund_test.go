@@ -0,0 +1,90 @@
+package yahoo
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestGetFundProfile_Success(t *testing.T) {
+	client := newTestClient(func(req *http.Request) (*http.Response, error) {
+		if !strings.Contains(req.URL.Path, "/v10/finance/quoteSummary/SPY") {
+			t.Errorf("unexpected path: %s", req.URL.Path)
+		}
+		modules := req.URL.Query().Get("modules")
+		if !strings.Contains(modules, "fundProfile") || !strings.Contains(modules, "topHoldings") || !strings.Contains(modules, "fundPerformance") {
+			t.Errorf("modules = %q, want fundProfile, topHoldings and fundPerformance", modules)
+		}
+		return jsonResponse(200, `{
+			"quoteSummary": {
+				"result": [{
+					"quoteType": {"symbol": "SPY", "longName": "SPDR S&P 500 ETF Trust", "quoteType": "ETF"},
+					"summaryDetail": {"totalAssets": {"raw": 500000000000, "fmt": "500B"}},
+					"fundProfile": {
+						"family": "SPDR State Street Global Advisors",
+						"categoryName": "Large Blend",
+						"legalType": "Exchange Traded Fund",
+						"feesExpensesInvestment": {"annualReportExpenseRatio": {"raw": 0.000945, "fmt": "0.09%"}}
+					},
+					"topHoldings": {
+						"stockPosition": {"raw": 0.9968},
+						"holdings": [
+							{"symbol": "MSFT", "holdingName": "Microsoft Corp", "holdingPercent": {"raw": 0.071}},
+							{"symbol": "AAPL", "holdingName": "Apple Inc", "holdingPercent": {"raw": 0.065}}
+						],
+						"sectorWeightings": [
+							{"technology": {"raw": 0.29}},
+							{"healthcare": {"raw": 0.13}}
+						],
+						"bondRatings": [{"us_government": {"raw": 0}}]
+					},
+					"fundPerformance": {
+						"trailingReturns": {"ytd": {"raw": 0.12}, "oneYear": {"raw": 0.25}},
+						"trailingReturnsCat": {"ytd": {"raw": 0.10}, "oneYear": {"raw": 0.22}}
+					}
+				}]
+			}
+		}`), nil
+	})
+
+	result, err := client.GetFundProfile("SPY")
+	if err != nil {
+		t.Fatalf("GetFundProfile() error: %v", err)
+	}
+
+	if result.FundProfile.CategoryName != "Large Blend" {
+		t.Errorf("CategoryName = %q, want %q", result.FundProfile.CategoryName, "Large Blend")
+	}
+	if result.FundProfile.FeesExpensesInvestment.AnnualReportExpenseRatio.Raw != 0.000945 {
+		t.Errorf("expense ratio = %v, want 0.000945", result.FundProfile.FeesExpensesInvestment.AnnualReportExpenseRatio.Raw)
+	}
+	if result.SummaryDetail.TotalAssets.Raw != 500000000000 {
+		t.Errorf("TotalAssets = %v, want 500000000000", result.SummaryDetail.TotalAssets.Raw)
+	}
+	if len(result.TopHoldings.Holdings) != 2 {
+		t.Fatalf("holdings count = %d, want 2", len(result.TopHoldings.Holdings))
+	}
+	if result.TopHoldings.Holdings[0].Symbol != "MSFT" {
+		t.Errorf("Holdings[0].Symbol = %q, want %q", result.TopHoldings.Holdings[0].Symbol, "MSFT")
+	}
+	if w := result.TopHoldings.SectorWeightings[0]["technology"].Raw; w != 0.29 {
+		t.Errorf("technology weighting = %v, want 0.29", w)
+	}
+	if result.FundPerformance.TrailingReturnsCat.OneYear.Raw != 0.22 {
+		t.Errorf("category 1y return = %v, want 0.22", result.FundPerformance.TrailingReturnsCat.OneYear.Raw)
+	}
+}
+
+func TestGetFundProfile_EmptyResults(t *testing.T) {
+	client := newTestClient(func(req *http.Request) (*http.Response, error) {
+		return jsonResponse(200, `{"quoteSummary": {"result": []}}`), nil
+	})
+
+	_, err := client.GetFundProfile("UNKNOWN")
+	if err == nil {
+		t.Fatal("expected error for empty results")
+	}
+	if !strings.Contains(err.Error(), "no data found") {
+		t.Errorf("error should mention no data found, got: %v", err)
+	}
+}
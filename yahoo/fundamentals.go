@@ -0,0 +1,39 @@
+package yahoo
+
+import "sync"
+
+// maxFundamentalsConcurrency bounds parallel requests in GetFundamentalsBatch.
+const maxFundamentalsConcurrency = 4
+
+// FundamentalsModules are the quoteSummary modules GetFundamentals requests.
+const FundamentalsModules = "price,summaryDetail,financialData,defaultKeyStatistics"
+
+// GetFundamentals fetches price, valuation, profitability and growth data for
+// a symbol in one quoteSummary call.
+func (c *Client) GetFundamentals(symbol string) (*QuoteSummaryResult, error) {
+	return c.quoteSummary("get fundamentals", symbol, FundamentalsModules)
+}
+
+// GetFundamentalsBatch fetches fundamentals for several symbols in parallel.
+// Results and errors are indexed like symbols; one symbol failing does not
+// fail the others.
+func (c *Client) GetFundamentalsBatch(symbols []string) ([]*QuoteSummaryResult, []error) {
+	results := make([]*QuoteSummaryResult, len(symbols))
+	errs := make([]error, len(symbols))
+	sem := make(chan struct{}, maxFundamentalsConcurrency)
+	var wg sync.WaitGroup
+
+	for i, s := range symbols {
+		wg.Add(1)
+		go func(i int, s string) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			results[i], errs[i] = c.GetFundamentals(s)
+		}(i, s)
+	}
+	wg.Wait()
+
+	return results, errs
+}
@@ -0,0 +1,98 @@
+package yahoo
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestGetFundamentals_Success(t *testing.T) {
+	client := newTestClient(func(req *http.Request) (*http.Response, error) {
+		if !strings.Contains(req.URL.Path, "/v10/finance/quoteSummary/MSFT") {
+			t.Errorf("unexpected path: %s", req.URL.Path)
+		}
+		if modules := req.URL.Query().Get("modules"); modules != FundamentalsModules {
+			t.Errorf("modules = %q, want %q", modules, FundamentalsModules)
+		}
+		return jsonResponse(200, `{
+			"quoteSummary": {
+				"result": [{
+					"price": {"symbol": "MSFT", "marketCap": {"raw": 3100000000000, "fmt": "3.1T"}},
+					"summaryDetail": {"trailingPE": {"raw": 35.2, "fmt": "35.20"}},
+					"financialData": {"operatingMargins": {"raw": 0.45, "fmt": "45.00%"}},
+					"defaultKeyStatistics": {
+						"enterpriseToEbitda": {"raw": 24.1, "fmt": "24.10"},
+						"52WeekChange": {"raw": 0.12, "fmt": "12.00%"}
+					}
+				}]
+			}
+		}`), nil
+	})
+
+	result, err := client.GetFundamentals("MSFT")
+	if err != nil {
+		t.Fatalf("GetFundamentals() error: %v", err)
+	}
+	if result.Price.MarketCap.Raw != 3100000000000 {
+		t.Errorf("MarketCap = %d, want 3100000000000", result.Price.MarketCap.Raw)
+	}
+	if result.FinancialData.OperatingMargins.Raw != 0.45 {
+		t.Errorf("OperatingMargins = %v, want 0.45", result.FinancialData.OperatingMargins.Raw)
+	}
+	if result.DefaultKeyStatistics.EnterpriseToEbitda.Raw != 24.1 {
+		t.Errorf("EnterpriseToEbitda = %v, want 24.1", result.DefaultKeyStatistics.EnterpriseToEbitda.Raw)
+	}
+	if result.DefaultKeyStatistics.FiftyTwoWeekChange.Raw != 0.12 {
+		t.Errorf("FiftyTwoWeekChange = %v, want 0.12", result.DefaultKeyStatistics.FiftyTwoWeekChange.Raw)
+	}
+}
+
+func TestGetFundamentals_YahooError(t *testing.T) {
+	client := newTestClient(func(req *http.Request) (*http.Response, error) {
+		return jsonResponse(200, `{
+			"quoteSummary": {
+				"result": null,
+				"error": {"code": "Not Found", "description": "Quote not found for symbol: XXXX"}
+			}
+		}`), nil
+	})
+
+	_, err := client.GetFundamentals("XXXX")
+	if err == nil {
+		t.Fatal("expected error for Yahoo error response")
+	}
+	if !strings.Contains(err.Error(), "Quote not found") {
+		t.Errorf("error should contain Yahoo error description, got: %v", err)
+	}
+}
+
+func TestGetFundamentalsBatch_PartialFailure(t *testing.T) {
+	client := newTestClient(func(req *http.Request) (*http.Response, error) {
+		if strings.Contains(req.URL.Path, "/BAD") {
+			return jsonResponse(404, `{"quoteSummary": {"result": null, "error": {"code": "Not Found", "description": "not found"}}}`), nil
+		}
+		sym := req.URL.Path[strings.LastIndex(req.URL.Path, "/")+1:]
+		return jsonResponse(200, `{"quoteSummary": {"result": [{"price": {"symbol": "`+sym+`"}}]}}`), nil
+	})
+
+	symbols := []string{"AAPL", "BAD", "MSFT", "GOOG", "AMZN", "META"}
+	results, errs := client.GetFundamentalsBatch(symbols)
+	if len(results) != len(symbols) || len(errs) != len(symbols) {
+		t.Fatalf("got %d results and %d errors, want %d of each", len(results), len(errs), len(symbols))
+	}
+	for i, s := range symbols {
+		if s == "BAD" {
+			if errs[i] == nil {
+				t.Errorf("expected error for %s", s)
+			}
+			continue
+		}
+		if errs[i] != nil {
+			t.Errorf("%s: unexpected error %v", s, errs[i])
+			continue
+		}
+		if results[i].Price.Symbol != s {
+			t.Errorf("results[%d].Price.Symbol = %q, want %q", i, results[i].Price.Symbol, s)
+		}
+	}
+}
@@ -0,0 +1,101 @@
+package yahoo
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// Trending is the pseudo screener ID that selects the trending tickers
+// endpoint rather than a predefined screener.
+const Trending = "trending"
+
+// PredefinedScreeners lists Yahoo's saved screener IDs.
+var PredefinedScreeners = []string{
+	"day_gainers", "day_losers", "most_actives", "most_shorted_stocks",
+	"small_cap_gainers", "aggressive_small_caps", "undervalued_growth_stocks",
+	"undervalued_large_caps", "growth_technology_stocks",
+	"conservative_foreign_funds", "high_yield_bond", "portfolio_anchors",
+	"solid_large_growth_funds", "solid_midcap_growth_funds", "top_mutual_funds",
+}
+
+// TrendingResponse from v1/finance/trending.
+type TrendingResponse struct {
+	Finance struct {
+		Result []struct {
+			Count  int `json:"count"`
+			Quotes []struct {
+				Symbol string `json:"symbol"`
+			} `json:"quotes"`
+		} `json:"result"`
+		Error *YahooError `json:"error"`
+	} `json:"finance"`
+}
+
+// GetPredefinedScreener runs one of Yahoo's saved screeners.
+func (c *Client) GetPredefinedScreener(id string, count int, region string) (*ScreenerResult, error) {
+	id = strings.ToLower(strings.TrimSpace(id))
+	if !contains(PredefinedScreeners, id) {
+		return nil, fmt.Errorf("unknown screener %q (valid: %s)", id, strings.Join(PredefinedScreeners, ", "))
+	}
+	if count <= 0 || count > maxScreenerResults {
+		return nil, fmt.Errorf("count must be between 1 and %d", maxScreenerResults)
+	}
+
+	params := url.Values{
+		"scrIds":    {id},
+		"count":     {strconv.Itoa(count)},
+		"formatted": {"false"},
+		"lang":      {"en-US"},
+		"region":    {strings.ToUpper(region)},
+	}
+
+	var resp ScreenerResponse
+	if err := c.GetJSON("/v1/finance/screener/predefined/saved", params, true, &resp); err != nil {
+		return nil, fmt.Errorf("get screener %s: %w", id, err)
+	}
+
+	if resp.Finance.Error != nil {
+		return nil, fmt.Errorf("yahoo error: %s", resp.Finance.Error.Description)
+	}
+
+	if len(resp.Finance.Result) == 0 {
+		return &ScreenerResult{ID: id}, nil
+	}
+
+	return &resp.Finance.Result[0], nil
+}
+
+// GetTrending fetches the symbols trending on Yahoo Finance in a region.
+func (c *Client) GetTrending(region string, count int) ([]string, error) {
+	region = strings.ToUpper(strings.TrimSpace(region))
+	if region == "" {
+		return nil, fmt.Errorf("region is required")
+	}
+	params := url.Values{
+		"count": {strconv.Itoa(count)},
+		"lang":  {"en-US"},
+	}
+
+	var resp TrendingResponse
+	path := fmt.Sprintf("/v1/finance/trending/%s", url.PathEscape(region))
+	if err := c.GetJSON(path, params, true, &resp); err != nil {
+		return nil, fmt.Errorf("get trending %s: %w", region, err)
+	}
+
+	if resp.Finance.Error != nil {
+		return nil, fmt.Errorf("yahoo error: %s", resp.Finance.Error.Description)
+	}
+
+	var symbols []string
+	for _, r := range resp.Finance.Result {
+		for _, q := range r.Quotes {
+			symbols = append(symbols, q.Symbol)
+		}
+	}
+	if count > 0 && len(symbols) > count {
+		symbols = symbols[:count]
+	}
+	return symbols, nil
+}
@@ -0,0 +1,92 @@
+package yahoo
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestGetPredefinedScreener_Success(t *testing.T) {
+	client := newTestClient(func(req *http.Request) (*http.Response, error) {
+		if req.URL.Path != "/v1/finance/screener/predefined/saved" {
+			t.Errorf("unexpected path: %s", req.URL.Path)
+		}
+		q := req.URL.Query()
+		if q.Get("scrIds") != "day_gainers" || q.Get("count") != "10" || q.Get("region") != "US" {
+			t.Errorf("query = %v", q)
+		}
+		return jsonResponse(200, `{
+			"finance": {
+				"result": [{
+					"id": "day_gainers",
+					"title": "Day Gainers",
+					"description": "Stocks ordered in descending order by price percent change",
+					"count": 1,
+					"total": 120,
+					"quotes": [{"symbol": "XYZ", "shortName": "XYZ Corp", "regularMarketChangePercent": 14.2}]
+				}],
+				"error": null
+			}
+		}`), nil
+	})
+
+	result, err := client.GetPredefinedScreener("Day_Gainers", 10, "us")
+	if err != nil {
+		t.Fatalf("GetPredefinedScreener() error: %v", err)
+	}
+	if result.Title != "Day Gainers" || result.Total != 120 {
+		t.Errorf("result = %q total %d", result.Title, result.Total)
+	}
+	if len(result.Quotes) != 1 || result.Quotes[0].RegularMarketChangePercent != 14.2 {
+		t.Errorf("quotes = %+v", result.Quotes)
+	}
+}
+
+func TestGetPredefinedScreener_Invalid(t *testing.T) {
+	client := newTestClient(func(req *http.Request) (*http.Response, error) {
+		t.Fatal("no request expected")
+		return nil, nil
+	})
+
+	if _, err := client.GetPredefinedScreener("best_stocks_ever", 10, "US"); err == nil || !strings.Contains(err.Error(), "day_gainers") {
+		t.Errorf("error = %v, want unknown screener listing valid IDs", err)
+	}
+	if _, err := client.GetPredefinedScreener("day_losers", 0, "US"); err == nil {
+		t.Error("expected error for zero count")
+	}
+}
+
+func TestGetTrending_Success(t *testing.T) {
+	client := newTestClient(func(req *http.Request) (*http.Response, error) {
+		if req.URL.Path != "/v1/finance/trending/GB" {
+			t.Errorf("unexpected path: %s", req.URL.Path)
+		}
+		return jsonResponse(200, `{
+			"finance": {
+				"result": [{
+					"count": 3,
+					"quotes": [{"symbol": "BARC.L"}, {"symbol": "RR.L"}, {"symbol": "VOD.L"}]
+				}],
+				"error": null
+			}
+		}`), nil
+	})
+
+	symbols, err := client.GetTrending("gb", 2)
+	if err != nil {
+		t.Fatalf("GetTrending() error: %v", err)
+	}
+	if len(symbols) != 2 || symbols[0] != "BARC.L" || symbols[1] != "RR.L" {
+		t.Errorf("symbols = %v, want first two", symbols)
+	}
+}
+
+func TestGetTrending_YahooError(t *testing.T) {
+	client := newTestClient(func(req *http.Request) (*http.Response, error) {
+		return jsonResponse(200, `{"finance": {"result": null, "error": {"code": "Not Found", "description": "Invalid region"}}}`), nil
+	})
+
+	if _, err := client.GetTrending("ZZ", 10); err == nil || !strings.Contains(err.Error(), "Invalid region") {
+		t.Errorf("error = %v, want yahoo error description", err)
+	}
+}
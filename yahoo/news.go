@@ -1,9 +1,13 @@
 package yahoo
 
 import (
+	"errors"
 	"fmt"
 	"net/url"
+	"sort"
 	"strconv"
+	"sync"
+	"time"
 )
 
 // GetNews fetches recent news articles for a symbol.
@@ -25,3 +29,118 @@ func (c *Client) GetNews(symbol string, count int) ([]SearchNews, error) {
 
 	return resp.News, nil
 }
+
+// maxNewsPerSymbol is the most articles requested per symbol, which bounds
+// how deep a news feed can be paged.
+const maxNewsPerSymbol = 50
+
+// maxNewsConcurrency bounds parallel requests in GetNewsFeed.
+const maxNewsConcurrency = 4
+
+// NewsQuery selects a page of a news feed for one or more symbols.
+type NewsQuery struct {
+	Symbols []string
+	Count   int // articles per page (default 10)
+	Offset  int // articles to skip
+	// After and Before bound publish times; zero values are unbounded.
+	After  time.Time
+	Before time.Time
+}
+
+// NewsFeed is a page of articles, newest first.
+type NewsFeed struct {
+	Articles []SearchNews
+	Offset   int
+	// Total counts the articles fetched that match the query, deduplicated,
+	// before paging. Only one article past the page is fetched unless time
+	// filters are set, so when HasMore is true there may be more than Total.
+	// Yahoo returns at most maxNewsPerSymbol per symbol.
+	Total   int
+	HasMore bool
+	// Failed holds an error, naming the symbol, for each symbol whose news
+	// could not be fetched.
+	Failed []error
+}
+
+// GetNewsFeed fetches news for every symbol, removes articles repeated
+// across symbols, applies the time filters and returns the requested page.
+// One symbol failing does not fail the others; an error is returned only
+// when every symbol failed.
+func (c *Client) GetNewsFeed(q NewsQuery) (*NewsFeed, error) {
+	if len(q.Symbols) == 0 {
+		return nil, fmt.Errorf("at least one symbol is required")
+	}
+	if q.Count <= 0 {
+		q.Count = 10
+	}
+	if q.Offset < 0 {
+		q.Offset = 0
+	}
+	// One article past the page tells whether another page follows.
+	perSymbol := min(q.Offset+q.Count+1, maxNewsPerSymbol)
+	if !q.After.IsZero() || !q.Before.IsZero() {
+		// Filtering discards articles, so fetch as deep as allowed.
+		perSymbol = maxNewsPerSymbol
+	}
+
+	results := make([][]SearchNews, len(q.Symbols))
+	errs := make([]error, len(q.Symbols))
+	sem := make(chan struct{}, maxNewsConcurrency)
+	var wg sync.WaitGroup
+	for i, s := range q.Symbols {
+		wg.Add(1)
+		go func(i int, s string) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			results[i], errs[i] = c.GetNews(s, perSymbol)
+		}(i, s)
+	}
+	wg.Wait()
+
+	var failed []error
+	for i, err := range errs {
+		if err != nil {
+			failed = append(failed, fmt.Errorf("%s: %w", q.Symbols[i], err))
+		}
+	}
+	if len(failed) == len(q.Symbols) {
+		return nil, errors.Join(failed...)
+	}
+
+	seen := make(map[string]bool)
+	var all []SearchNews
+	for _, news := range results {
+		for _, n := range news {
+			key := n.UUID
+			if key == "" {
+				key = n.Link
+			}
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+
+			published := time.Unix(n.ProviderPublishTime, 0)
+			if !q.After.IsZero() && published.Before(q.After) {
+				continue
+			}
+			if !q.Before.IsZero() && !published.Before(q.Before) {
+				continue
+			}
+			all = append(all, n)
+		}
+	}
+	sort.SliceStable(all, func(i, j int) bool {
+		return all[i].ProviderPublishTime > all[j].ProviderPublishTime
+	})
+
+	feed := &NewsFeed{Offset: q.Offset, Total: len(all), Failed: failed}
+	if q.Offset < len(all) {
+		end := min(q.Offset+q.Count, len(all))
+		feed.Articles = all[q.Offset:end]
+		feed.HasMore = end < len(all)
+	}
+	return feed, nil
+}
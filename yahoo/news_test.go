@@ -4,6 +4,7 @@ import (
 	"net/http"
 	"strings"
 	"testing"
+	"time"
 )
 
 func TestGetNews_Success(t *testing.T) {
@@ -103,3 +104,148 @@ func TestGetNews_EmptyResults(t *testing.T) {
 		t.Errorf("expected empty news, got %d items", len(news))
 	}
 }
+
+func TestSearchNews_RelatedTickersAndThumbnail(t *testing.T) {
+	client := newTestClient(func(req *http.Request) (*http.Response, error) {
+		return jsonResponse(200, `{
+			"news": [{
+				"uuid": "abc-123",
+				"title": "Chipmakers rally",
+				"type": "STORY",
+				"relatedTickers": ["NVDA", "AMD"],
+				"thumbnail": {"resolutions": [
+					{"url": "https://example.com/small.jpg", "width": 140, "height": 140, "tag": "140x140"},
+					{"url": "https://example.com/original.jpg", "width": 1200, "height": 800, "tag": "original"}
+				]}
+			}]
+		}`), nil
+	})
+
+	news, err := client.GetNews("NVDA", 1)
+	if err != nil {
+		t.Fatalf("GetNews() error: %v", err)
+	}
+	n := news[0]
+	if n.Type != "STORY" || len(n.RelatedTickers) != 2 || n.RelatedTickers[1] != "AMD" {
+		t.Errorf("article = %+v", n)
+	}
+	if got := n.Thumbnail.URL(); got != "https://example.com/original.jpg" {
+		t.Errorf("Thumbnail.URL() = %q, want the largest rendition", got)
+	}
+
+	var none *NewsThumbnail
+	if none.URL() != "" {
+		t.Error("nil thumbnail should have no URL")
+	}
+}
+
+// newsFeedClient serves per-symbol news from articles keyed by symbol.
+func newsFeedClient(t *testing.T, articles map[string]string) *Client {
+	return newTestClient(func(req *http.Request) (*http.Response, error) {
+		body, ok := articles[req.URL.Query().Get("q")]
+		if !ok {
+			t.Errorf("unexpected symbol: %s", req.URL.Query().Get("q"))
+			body = ""
+		}
+		return jsonResponse(200, `{"news": [`+body+`]}`), nil
+	})
+}
+
+func TestGetNewsFeed_DedupesAndSorts(t *testing.T) {
+	client := newsFeedClient(t, map[string]string{
+		"AAPL": `{"uuid": "a", "title": "A", "providerPublishTime": 300},
+		         {"uuid": "shared", "title": "Shared", "providerPublishTime": 200}`,
+		"MSFT": `{"uuid": "shared", "title": "Shared", "providerPublishTime": 200},
+		         {"uuid": "m", "title": "M", "providerPublishTime": 400}`,
+	})
+
+	feed, err := client.GetNewsFeed(NewsQuery{Symbols: []string{"AAPL", "MSFT"}})
+	if err != nil {
+		t.Fatalf("GetNewsFeed() error: %v", err)
+	}
+	if feed.Total != 3 || len(feed.Articles) != 3 || feed.HasMore {
+		t.Fatalf("feed = %+v, want 3 articles and no more", feed)
+	}
+	for i, want := range []string{"m", "a", "shared"} {
+		if feed.Articles[i].UUID != want {
+			t.Errorf("Articles[%d].UUID = %q, want %q", i, feed.Articles[i].UUID, want)
+		}
+	}
+}
+
+func TestGetNewsFeed_PagingAndTimeFilters(t *testing.T) {
+	client := newsFeedClient(t, map[string]string{
+		"AAPL": `{"uuid": "1", "providerPublishTime": 100},
+		         {"uuid": "2", "providerPublishTime": 200},
+		         {"uuid": "3", "providerPublishTime": 300},
+		         {"uuid": "4", "providerPublishTime": 400},
+		         {"uuid": "5", "providerPublishTime": 500}`,
+	})
+
+	feed, err := client.GetNewsFeed(NewsQuery{Symbols: []string{"AAPL"}, Count: 2, Offset: 2})
+	if err != nil {
+		t.Fatalf("GetNewsFeed() error: %v", err)
+	}
+	if len(feed.Articles) != 2 || feed.Articles[0].UUID != "3" || feed.Articles[1].UUID != "2" || !feed.HasMore {
+		t.Errorf("page = %+v, want articles 3 and 2 with more", feed)
+	}
+
+	feed, err = client.GetNewsFeed(NewsQuery{Symbols: []string{"AAPL"}, Count: 2})
+	if err != nil {
+		t.Fatalf("GetNewsFeed() error: %v", err)
+	}
+	if len(feed.Articles) != 2 || !feed.HasMore {
+		t.Errorf("first page = %+v, want 2 articles with more", feed)
+	}
+
+	feed, err = client.GetNewsFeed(NewsQuery{
+		Symbols: []string{"AAPL"},
+		After:   time.Unix(200, 0),
+		Before:  time.Unix(400, 0),
+	})
+	if err != nil {
+		t.Fatalf("GetNewsFeed() error: %v", err)
+	}
+	if feed.Total != 2 || feed.Articles[0].UUID != "3" || feed.Articles[1].UUID != "2" {
+		t.Errorf("filtered = %+v, want articles 3 and 2", feed)
+	}
+
+	feed, err = client.GetNewsFeed(NewsQuery{Symbols: []string{"AAPL"}, Offset: 10})
+	if err != nil {
+		t.Fatalf("GetNewsFeed() error: %v", err)
+	}
+	if len(feed.Articles) != 0 || feed.HasMore {
+		t.Errorf("past the end = %+v, want empty", feed)
+	}
+}
+
+func TestGetNewsFeed_SkipsFailedSymbols(t *testing.T) {
+	client := newTestClient(func(req *http.Request) (*http.Response, error) {
+		if req.URL.Query().Get("q") == "NOPE" {
+			return jsonResponse(500, `{}`), nil
+		}
+		return jsonResponse(200, `{"news": [{"uuid": "a", "providerPublishTime": 100}]}`), nil
+	})
+
+	feed, err := client.GetNewsFeed(NewsQuery{Symbols: []string{"AAPL", "NOPE"}})
+	if err != nil {
+		t.Fatalf("GetNewsFeed() error: %v", err)
+	}
+	if len(feed.Articles) != 1 {
+		t.Errorf("articles = %d, want 1", len(feed.Articles))
+	}
+	if len(feed.Failed) != 1 || !strings.Contains(feed.Failed[0].Error(), "NOPE") {
+		t.Errorf("Failed = %v, want one error naming NOPE", feed.Failed)
+	}
+
+	if _, err := client.GetNewsFeed(NewsQuery{Symbols: []string{"NOPE"}}); err == nil {
+		t.Fatal("expected error when every symbol fails")
+	}
+}
+
+func TestGetNewsFeed_NoSymbols(t *testing.T) {
+	client := newTestClient(nil)
+	if _, err := client.GetNewsFeed(NewsQuery{}); err == nil {
+		t.Fatal("expected error without symbols")
+	}
+}
@@ -1,10 +1,25 @@
 package yahoo
 
 import (
+	"errors"
 	"fmt"
 	"net/url"
+	"strconv"
+	"sync"
+	"time"
 )
 
+// maxOptionsConcurrency bounds parallel requests when fetching several expirations.
+const maxOptionsConcurrency = 4
+
+// ExpirationFilter selects expirations for GetOptionChains.
+// Zero values disable the corresponding filter.
+type ExpirationFilter struct {
+	From time.Time
+	To   time.Time // inclusive of the whole day
+	Max  int       // nearest expirations to keep after date filtering
+}
+
 // GetOptions fetches the options chain for a symbol.
 // If expiration is empty, returns the nearest expiration.
 func (c *Client) GetOptions(symbol, expiration string) (*OptionsResult, error) {
@@ -29,3 +44,88 @@ func (c *Client) GetOptions(symbol, expiration string) (*OptionsResult, error) {
 
 	return &resp.OptionChain.Result[0], nil
 }
+
+// GetOptionChains fetches the chains for every expiration matching the
+// filter, nearest first. The nearest chain comes with the expiration list;
+// the rest are fetched with bounded concurrency. The result's Options hold
+// one chain per expiration that loaded; one expiration failing does not fail
+// the others, and each failure is returned in errs. An error is returned
+// only when no chain could be loaded.
+func (c *Client) GetOptionChains(symbol string, f ExpirationFilter) (*OptionsResult, []error, error) {
+	first, err := c.GetOptions(symbol, "")
+	if err != nil {
+		return nil, nil, err
+	}
+
+	dates := FilterExpirations(first.ExpirationDates, f)
+	if len(dates) == 0 {
+		return nil, nil, fmt.Errorf("no expirations for %q match the requested range", symbol)
+	}
+
+	chains := make([]OptionsChain, len(dates))
+	failed := make([]error, len(dates))
+	sem := make(chan struct{}, maxOptionsConcurrency)
+	var wg sync.WaitGroup
+
+	for i, d := range dates {
+		if len(first.Options) > 0 && first.Options[0].ExpirationDate == d {
+			chains[i] = first.Options[0]
+			continue
+		}
+		wg.Add(1)
+		go func(i int, d int64) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			r, err := c.GetOptions(symbol, strconv.FormatInt(d, 10))
+			if err != nil {
+				failed[i] = fmt.Errorf("expiration %s: %w", time.Unix(d, 0).UTC().Format("2006-01-02"), err)
+				return
+			}
+			if len(r.Options) == 0 {
+				chains[i] = OptionsChain{ExpirationDate: d}
+				return
+			}
+			chains[i] = r.Options[0]
+		}(i, d)
+	}
+	wg.Wait()
+
+	var loaded []OptionsChain
+	var errs []error
+	for i, err := range failed {
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		loaded = append(loaded, chains[i])
+	}
+	if len(loaded) == 0 {
+		return nil, errs, errors.Join(errs...)
+	}
+
+	result := *first
+	result.Options = loaded
+	return &result, errs, nil
+}
+
+// FilterExpirations returns the expirations within the filter's date range,
+// nearest first, capped at f.Max.
+func FilterExpirations(dates []int64, f ExpirationFilter) []int64 {
+	var out []int64
+	for _, d := range dates {
+		ts := time.Unix(d, 0)
+		if !f.From.IsZero() && ts.Before(f.From) {
+			continue
+		}
+		if !f.To.IsZero() && !ts.Before(f.To.Add(24*time.Hour)) {
+			continue
+		}
+		out = append(out, d)
+	}
+	if f.Max > 0 && len(out) > f.Max {
+		out = out[:f.Max]
+	}
+	return out
+}
@@ -3,7 +3,9 @@ package yahoo
 import (
 	"net/http"
 	"strings"
+	"sync"
 	"testing"
+	"time"
 )
 
 func TestGetOptions_Success(t *testing.T) {
@@ -148,3 +150,136 @@ func TestGetOptions_EmptyResults(t *testing.T) {
 		t.Errorf("error should mention no options data, got: %v", err)
 	}
 }
+
+func TestGetOptionChains_FetchesEachExpiration(t *testing.T) {
+	var mu sync.Mutex
+	var requested []string
+
+	client := newTestClient(func(req *http.Request) (*http.Response, error) {
+		date := req.URL.Query().Get("date")
+		mu.Lock()
+		requested = append(requested, date)
+		mu.Unlock()
+
+		exp := date
+		if exp == "" {
+			exp = "1700000000"
+		}
+		return jsonResponse(200, `{
+			"optionChain": {
+				"result": [{
+					"underlyingSymbol": "AAPL",
+					"expirationDates": [1700000000, 1700604800, 1701209600, 1701814400],
+					"quote": {"symbol": "AAPL", "regularMarketPrice": 178.72},
+					"options": [{
+						"expirationDate": `+exp+`,
+						"calls": [{"strike": 180.0, "impliedVolatility": 0.3}],
+						"puts": []
+					}]
+				}]
+			}
+		}`), nil
+	})
+
+	result, errs, err := client.GetOptionChains("AAPL", ExpirationFilter{Max: 3})
+	if err != nil {
+		t.Fatalf("GetOptionChains() error: %v", err)
+	}
+	if len(errs) != 0 {
+		t.Errorf("errs = %v, want none", errs)
+	}
+
+	if len(result.Options) != 3 {
+		t.Fatalf("chain count = %d, want 3", len(result.Options))
+	}
+	want := []int64{1700000000, 1700604800, 1701209600}
+	for i, c := range result.Options {
+		if c.ExpirationDate != want[i] {
+			t.Errorf("chain[%d] expiration = %d, want %d", i, c.ExpirationDate, want[i])
+		}
+	}
+	// the nearest chain is reused from the initial request
+	if len(requested) != 3 {
+		t.Errorf("requests = %d (%v), want 3", len(requested), requested)
+	}
+}
+
+func TestGetOptionChains_KeepsLoadedChains(t *testing.T) {
+	client := newTestClient(func(req *http.Request) (*http.Response, error) {
+		switch req.URL.Query().Get("date") {
+		case "":
+		case "1700604800":
+			return jsonResponse(500, `{}`), nil
+		default:
+			return jsonResponse(200, `{
+				"optionChain": {
+					"result": [{"options": [{"expirationDate": 1701209600}]}]
+				}
+			}`), nil
+		}
+		return jsonResponse(200, `{
+			"optionChain": {
+				"result": [{
+					"underlyingSymbol": "AAPL",
+					"expirationDates": [1700000000, 1700604800, 1701209600],
+					"options": [{"expirationDate": 1700000000}]
+				}]
+			}
+		}`), nil
+	})
+
+	result, errs, err := client.GetOptionChains("AAPL", ExpirationFilter{})
+	if err != nil {
+		t.Fatalf("GetOptionChains() error: %v", err)
+	}
+	if len(result.Options) != 2 || result.Options[0].ExpirationDate != 1700000000 || result.Options[1].ExpirationDate != 1701209600 {
+		t.Errorf("chains = %+v, want 1700000000 and 1701209600", result.Options)
+	}
+	if len(errs) != 1 || !strings.Contains(errs[0].Error(), "2023-11-21") {
+		t.Errorf("errs = %v, want one error for 2023-11-21", errs)
+	}
+}
+
+func TestGetOptionChains_AllFailed(t *testing.T) {
+	client := newTestClient(func(req *http.Request) (*http.Response, error) {
+		if req.URL.Query().Get("date") != "" {
+			return jsonResponse(500, `{}`), nil
+		}
+		return jsonResponse(200, `{
+			"optionChain": {
+				"result": [{
+					"underlyingSymbol": "AAPL",
+					"expirationDates": [1700604800, 1701209600],
+					"options": [{"expirationDate": 1700000000}]
+				}]
+			}
+		}`), nil
+	})
+
+	_, errs, err := client.GetOptionChains("AAPL", ExpirationFilter{})
+	if err == nil {
+		t.Fatal("expected error when every expiration fails")
+	}
+	if len(errs) != 2 {
+		t.Errorf("errs = %v, want 2", errs)
+	}
+}
+
+func TestFilterExpirations(t *testing.T) {
+	day := func(s string) int64 {
+		ts, _ := time.Parse("2006-01-02", s)
+		return ts.Unix()
+	}
+	dates := []int64{day("2024-01-19"), day("2024-02-16"), day("2024-03-15"), day("2024-06-21")}
+	from, _ := time.Parse("2006-01-02", "2024-02-01")
+	to, _ := time.Parse("2006-01-02", "2024-03-15")
+
+	got := FilterExpirations(dates, ExpirationFilter{From: from, To: to})
+	if len(got) != 2 || got[0] != dates[1] || got[1] != dates[2] {
+		t.Errorf("FilterExpirations() = %v, want the February and March dates", got)
+	}
+
+	if got := FilterExpirations(dates, ExpirationFilter{Max: 1}); len(got) != 1 || got[0] != dates[0] {
+		t.Errorf("FilterExpirations(Max: 1) = %v, want nearest only", got)
+	}
+}
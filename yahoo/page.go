@@ -0,0 +1,129 @@
+package yahoo
+
+import (
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/netip"
+	"net/url"
+	"strings"
+	"syscall"
+	"time"
+)
+
+// maxPageBytes bounds how much of a web page FetchPage reads.
+const maxPageBytes = 4 << 20
+
+// maxPageRedirects bounds the redirects FetchPage follows.
+const maxPageRedirects = 10
+
+// cgnat is the carrier-grade NAT range, which is not publicly routable.
+var cgnat = netip.MustParsePrefix("100.64.0.0/10")
+
+// pageTransport dials only public addresses, so page URLs, which come from
+// the model, cannot reach loopback, private or link-local hosts such as
+// cloud metadata services. The check runs on the resolved address of every
+// connection, redirects included. Proxies are not used, as they would dial
+// on our behalf.
+var pageTransport = func() *http.Transport {
+	t := http.DefaultTransport.(*http.Transport).Clone()
+	t.Proxy = nil
+	t.DialContext = (&net.Dialer{
+		Timeout:   30 * time.Second,
+		KeepAlive: 30 * time.Second,
+		Control:   publicOnly,
+	}).DialContext
+	return t
+}()
+
+// FetchPage retrieves a web page, such as a news article, with a browser
+// user agent and the client's cookies. It returns the body, truncated at
+// maxPageBytes, and the final URL after redirects. Pages on loopback,
+// private, link-local and other non-public addresses are refused.
+func (c *Client) FetchPage(pageURL string) ([]byte, string, error) {
+	u, err := url.Parse(pageURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return nil, "", fmt.Errorf("invalid page URL %q", pageURL)
+	}
+	if err := checkPageHost(u); err != nil {
+		return nil, "", err
+	}
+
+	req, err := http.NewRequest("GET", u.String(), nil)
+	if err != nil {
+		return nil, "", fmt.Errorf("creating request: %w", err)
+	}
+	req.Header.Set("User-Agent", randomUA())
+	req.Header.Set("Accept", "text/html,application/xhtml+xml")
+	req.Header.Set("Accept-Language", "en-US,en;q=0.9")
+
+	// Share the cookie jar but not the transport of API requests.
+	hc := *c.httpClient
+	if hc.Transport == nil {
+		hc.Transport = pageTransport
+	}
+	hc.CheckRedirect = func(req *http.Request, via []*http.Request) error {
+		if len(via) >= maxPageRedirects {
+			return fmt.Errorf("stopped after %d redirects", maxPageRedirects)
+		}
+		return checkPageHost(req.URL)
+	}
+
+	resp, err := hc.Do(req)
+	if err != nil {
+		return nil, "", fmt.Errorf("executing request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, "", fmt.Errorf("page request failed with status %d", resp.StatusCode)
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxPageBytes))
+	if err != nil {
+		return nil, "", fmt.Errorf("reading page: %w", err)
+	}
+
+	final := u.String()
+	if resp.Request != nil && resp.Request.URL != nil {
+		final = resp.Request.URL.String()
+	}
+	return body, final, nil
+}
+
+// checkPageHost refuses URLs naming localhost or a non-public IP address.
+// Host names are checked again once resolved, by publicOnly.
+func checkPageHost(u *url.URL) error {
+	host := strings.ToLower(strings.TrimSuffix(u.Hostname(), "."))
+	if host == "localhost" || strings.HasSuffix(host, ".localhost") {
+		return fmt.Errorf("refusing to fetch %s: not a public host", u.Host)
+	}
+	if addr, err := netip.ParseAddr(host); err == nil && !isPublicAddr(addr) {
+		return fmt.Errorf("refusing to fetch %s: not a public address", u.Host)
+	}
+	return nil
+}
+
+// publicOnly is a net.Dialer Control function that refuses connections to
+// non-public addresses.
+func publicOnly(network, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+	addr, err := netip.ParseAddr(host)
+	if err != nil {
+		return fmt.Errorf("refusing to dial %s: %w", address, err)
+	}
+	if !isPublicAddr(addr) {
+		return fmt.Errorf("refusing to dial %s: not a public address", address)
+	}
+	return nil
+}
+
+// isPublicAddr reports whether addr is a globally routable unicast address.
+func isPublicAddr(addr netip.Addr) bool {
+	addr = addr.Unmap().WithZone("")
+	return addr.IsGlobalUnicast() && !addr.IsPrivate() && !cgnat.Contains(addr)
+}
@@ -0,0 +1,119 @@
+package yahoo
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestFetchPage_Success(t *testing.T) {
+	client := newTestClient(func(req *http.Request) (*http.Response, error) {
+		if req.URL.String() != "https://finance.yahoo.com/news/story-123.html" {
+			t.Errorf("unexpected URL: %s", req.URL)
+		}
+		if req.Header.Get("User-Agent") == "" {
+			t.Error("User-Agent should be set")
+		}
+		if req.URL.Query().Get("crumb") != "" {
+			t.Error("page requests should not carry a crumb")
+		}
+		resp := textResponse(200, "<html><body><p>Hello</p></body></html>")
+		resp.Request = req
+		return resp, nil
+	})
+
+	body, final, err := client.FetchPage("https://finance.yahoo.com/news/story-123.html")
+	if err != nil {
+		t.Fatalf("FetchPage() error: %v", err)
+	}
+	if !strings.Contains(string(body), "<p>Hello</p>") {
+		t.Errorf("body = %s", body)
+	}
+	if final != "https://finance.yahoo.com/news/story-123.html" {
+		t.Errorf("final URL = %q", final)
+	}
+}
+
+func TestFetchPage_RejectsNonHTTP(t *testing.T) {
+	client := newTestClient(func(req *http.Request) (*http.Response, error) {
+		t.Fatal("should not make a request")
+		return nil, nil
+	})
+
+	for _, u := range []string{"file:///etc/passwd", "ftp://example.com/a", "not a url", ""} {
+		if _, _, err := client.FetchPage(u); err == nil {
+			t.Errorf("FetchPage(%q) should fail", u)
+		}
+	}
+}
+
+func TestFetchPage_NonOKStatus(t *testing.T) {
+	client := newTestClient(func(req *http.Request) (*http.Response, error) {
+		return textResponse(404, "not found"), nil
+	})
+
+	_, _, err := client.FetchPage("https://finance.yahoo.com/news/missing.html")
+	if err == nil || !strings.Contains(err.Error(), "404") {
+		t.Errorf("expected 404 error, got %v", err)
+	}
+}
+
+func TestFetchPage_RejectsNonPublicHosts(t *testing.T) {
+	client := newTestClient(func(req *http.Request) (*http.Response, error) {
+		t.Fatalf("should not request %s", req.URL)
+		return nil, nil
+	})
+
+	for _, u := range []string{
+		"http://127.0.0.1/",
+		"http://localhost:8080/admin",
+		"http://169.254.169.254/latest/meta-data/",
+		"http://10.0.0.5/",
+		"https://192.168.1.1/",
+		"http://[::1]/",
+		"http://[fe80::1]/",
+		"http://0.0.0.0/",
+	} {
+		if _, _, err := client.FetchPage(u); err == nil || !strings.Contains(err.Error(), "refusing") {
+			t.Errorf("FetchPage(%q) error = %v, want refusal", u, err)
+		}
+	}
+}
+
+func TestFetchPage_RejectsRedirectToPrivateHost(t *testing.T) {
+	client := newTestClient(func(req *http.Request) (*http.Response, error) {
+		if req.URL.Host != "finance.yahoo.com" {
+			t.Fatalf("should not follow redirect to %s", req.URL)
+		}
+		resp := textResponse(302, "")
+		resp.Header.Set("Location", "http://169.254.169.254/latest/meta-data/")
+		resp.Request = req
+		return resp, nil
+	})
+
+	_, _, err := client.FetchPage("https://finance.yahoo.com/news/story-123.html")
+	if err == nil || !strings.Contains(err.Error(), "refusing") {
+		t.Errorf("expected redirect refusal, got %v", err)
+	}
+}
+
+func TestPublicOnly(t *testing.T) {
+	for addr, ok := range map[string]bool{
+		"127.0.0.1:80":          false,
+		"10.1.2.3:443":          false,
+		"172.16.0.1:443":        false,
+		"192.168.0.10:80":       false,
+		"169.254.169.254:80":    false,
+		"100.64.0.1:80":         false,
+		"0.0.0.0:80":            false,
+		"[::1]:443":             false,
+		"[fd00::1]:443":         false,
+		"[::ffff:127.0.0.1]:80": false,
+		"93.184.216.34:443":     true,
+		"[2606:4700::1111]:443": true,
+	} {
+		if err := publicOnly("tcp", addr, nil); (err == nil) != ok {
+			t.Errorf("publicOnly(%s) = %v, want allowed=%v", addr, err, ok)
+		}
+	}
+}
@@ -0,0 +1,127 @@
+package yahoo
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+)
+
+// QuoteSummaryModules lists the quoteSummary modules QuoteSummaryResult
+// decodes into typed fields. Other modules Yahoo returns are kept raw.
+var QuoteSummaryModules = []string{
+	"assetProfile", "summaryProfile", "price", "quoteType", "summaryDetail",
+	"defaultKeyStatistics", "financialData", "calendarEvents",
+	"earnings", "earningsHistory", "earningsTrend",
+	"recommendationTrend", "upgradeDowngradeHistory",
+	"incomeStatementHistory", "incomeStatementHistoryQuarterly",
+	"balanceSheetHistory", "balanceSheetHistoryQuarterly",
+	"cashflowStatementHistory", "cashflowStatementHistoryQuarterly",
+	"majorHoldersBreakdown", "institutionOwnership", "fundOwnership",
+	"majorDirectHolders", "insiderHolders", "insiderTransactions",
+	"netSharePurchaseActivity", "secFilings", "esgScores",
+	"indexTrend", "sectorTrend", "industryTrend", "pageViews",
+	"fundProfile", "topHoldings", "fundPerformance",
+}
+
+// knownModules maps lowercased module names to their spelling in
+// QuoteSummaryModules.
+var knownModules = func() map[string]string {
+	m := make(map[string]string, len(QuoteSummaryModules))
+	for _, name := range QuoteSummaryModules {
+		m[strings.ToLower(name)] = name
+	}
+	return m
+}()
+
+// GetQuoteSummary fetches any set of quoteSummary modules for a symbol in
+// one request. Known module names are matched case-insensitively; unknown
+// names are passed through, and whatever Yahoo returns for them is available
+// from the result's Raw map.
+func (c *Client) GetQuoteSummary(symbol string, modules ...string) (*QuoteSummaryResult, error) {
+	var names []string
+	seen := make(map[string]bool)
+	for _, m := range modules {
+		m = strings.TrimSpace(m)
+		if known, ok := knownModules[strings.ToLower(m)]; ok {
+			m = known
+		}
+		if m != "" && !seen[m] {
+			seen[m] = true
+			names = append(names, m)
+		}
+	}
+	if len(names) == 0 {
+		return nil, errors.New("no modules requested")
+	}
+	return c.quoteSummary("get quote summary", symbol, strings.Join(names, ","))
+}
+
+// quoteSummary requests a comma-separated list of modules for a symbol,
+// prefixing request errors with op.
+func (c *Client) quoteSummary(op, symbol, modules string) (*QuoteSummaryResult, error) {
+	params := url.Values{
+		"modules": {modules},
+	}
+
+	var resp QuoteSummaryResponse
+	path := fmt.Sprintf("/v10/finance/quoteSummary/%s", url.PathEscape(symbol))
+	if err := c.GetJSON(path, params, true, &resp); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if resp.QuoteSummary.Error != nil {
+		return nil, fmt.Errorf("yahoo error: %s", resp.QuoteSummary.Error.Description)
+	}
+
+	if len(resp.QuoteSummary.Result) == 0 {
+		return nil, fmt.Errorf("no data found for symbol %q", symbol)
+	}
+
+	return &resp.QuoteSummary.Result[0], nil
+}
+
+// UnmarshalJSON decodes the typed modules and keeps the JSON of every
+// module, so unknown ones remain available in Raw.
+func (r *QuoteSummaryResult) UnmarshalJSON(data []byte) error {
+	type typed QuoteSummaryResult // without this method
+	var t typed
+	if err := json.Unmarshal(data, &t); err != nil {
+		return err
+	}
+	var modules map[string]json.RawMessage
+	if err := json.Unmarshal(data, &modules); err != nil {
+		return err
+	}
+
+	*r = QuoteSummaryResult(t)
+	r.modules = modules
+	for name, raw := range modules {
+		if _, ok := knownModules[strings.ToLower(name)]; ok {
+			continue
+		}
+		if r.Raw == nil {
+			r.Raw = make(map[string]json.RawMessage)
+		}
+		r.Raw[name] = raw
+	}
+	return nil
+}
+
+// Module returns the JSON Yahoo sent for a module, typed or not.
+func (r *QuoteSummaryResult) Module(name string) (json.RawMessage, bool) {
+	raw, ok := r.modules[name]
+	return raw, ok
+}
+
+// Modules returns the names of the modules in the response, sorted by name.
+func (r *QuoteSummaryResult) Modules() []string {
+	names := make([]string, 0, len(r.modules))
+	for name := range r.modules {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
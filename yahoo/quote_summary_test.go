@@ -0,0 +1,144 @@
+package yahoo
+
+import (
+	"net/http"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestGetQuoteSummary_Modules(t *testing.T) {
+	client := newTestClient(func(req *http.Request) (*http.Response, error) {
+		if !strings.Contains(req.URL.Path, "/v10/finance/quoteSummary/AAPL") {
+			t.Errorf("unexpected path: %s", req.URL.Path)
+		}
+		want := "calendarEvents,earningsHistory,incomeStatementHistory,insiderTransactions,customModule"
+		if modules := req.URL.Query().Get("modules"); modules != want {
+			t.Errorf("modules = %q, want %q", modules, want)
+		}
+		return jsonResponse(200, `{
+			"quoteSummary": {
+				"result": [{
+					"calendarEvents": {
+						"maxAge": 1,
+						"earnings": {
+							"earningsDate": [{"raw": 1761854400, "fmt": "2025-10-30"}],
+							"earningsAverage": {"raw": 1.76, "fmt": "1.76"},
+							"revenueAverage": {"raw": 101790000000, "fmt": "101.79B"}
+						},
+						"exDividendDate": {"raw": 1754870400, "fmt": "2025-08-11"}
+					},
+					"earningsHistory": {"history": [
+						{"maxAge": 1, "period": "-1q", "epsActual": {"raw": 1.57, "fmt": "1.57"}, "epsEstimate": {"raw": 1.43, "fmt": "1.43"}, "surprisePercent": {"raw": 0.098, "fmt": "9.80%"}}
+					]},
+					"incomeStatementHistory": {"maxAge": 86400, "incomeStatementHistory": [
+						{"maxAge": 1, "endDate": {"raw": 1727481600, "fmt": "2024-09-28"}, "totalRevenue": {"raw": 391035000000, "fmt": "391.04B"}, "netIncome": {"raw": 93736000000, "fmt": "93.74B"}}
+					]},
+					"insiderTransactions": {"transactions": [
+						{"filerName": "COOK TIMOTHY D", "filerRelation": "Chief Executive Officer", "transactionText": "Sale at price 223.00 per share.", "shares": {"raw": 108136, "longFmt": "108,136"}, "value": {"raw": 24184658, "longFmt": "24,184,658"}, "ownership": "D"}
+					]},
+					"customModule": {"answer": {"raw": 42, "fmt": "42"}}
+				}]
+			}
+		}`), nil
+	})
+
+	result, err := client.GetQuoteSummary("AAPL", "CalendarEvents", " earningsHistory", "incomeStatementHistory", "earningsHistory", "", "insiderTransactions", "customModule")
+	if err != nil {
+		t.Fatalf("GetQuoteSummary() error: %v", err)
+	}
+
+	cal := result.CalendarEvents
+	if cal == nil || len(cal.Earnings.EarningsDate) != 1 || cal.Earnings.EarningsDate[0].Fmt != "2025-10-30" {
+		t.Fatalf("CalendarEvents = %+v", cal)
+	}
+	if cal.Earnings.RevenueAverage.Raw != 101790000000 {
+		t.Errorf("RevenueAverage = %v, want 101790000000", cal.Earnings.RevenueAverage.Raw)
+	}
+	if h := result.EarningsHistory; h == nil || len(h.History) != 1 || h.History[0].EpsActual.Raw != 1.57 {
+		t.Errorf("EarningsHistory = %+v", h)
+	}
+
+	statements := result.IncomeStatementHistory.Statements()
+	if len(statements) != 1 {
+		t.Fatalf("Statements() = %d entries, want 1", len(statements))
+	}
+	if statements[0].EndDate.Fmt != "2024-09-28" {
+		t.Errorf("EndDate = %q, want 2024-09-28", statements[0].EndDate.Fmt)
+	}
+	if statements[0].Items["totalRevenue"].Raw != 391035000000 {
+		t.Errorf("totalRevenue = %v", statements[0].Items["totalRevenue"].Raw)
+	}
+	if _, ok := statements[0].Items["maxAge"]; ok {
+		t.Error("maxAge should not be a line item")
+	}
+
+	tx := result.InsiderTransactions
+	if tx == nil || len(tx.Transactions) != 1 || tx.Transactions[0].Shares.Raw != 108136 {
+		t.Errorf("InsiderTransactions = %+v", tx)
+	}
+
+	if _, ok := result.Raw["customModule"]; !ok || len(result.Raw) != 1 {
+		t.Errorf("Raw = %v, want only customModule", result.Raw)
+	}
+	if raw, ok := result.Module("calendarEvents"); !ok || !strings.Contains(string(raw), "earningsAverage") {
+		t.Errorf("Module(calendarEvents) = %s, %v", raw, ok)
+	}
+	want := []string{"calendarEvents", "customModule", "earningsHistory", "incomeStatementHistory", "insiderTransactions"}
+	if got := result.Modules(); !slices.Equal(got, want) {
+		t.Errorf("Modules() = %v, want %v", got, want)
+	}
+}
+
+func TestGetQuoteSummary_NoModules(t *testing.T) {
+	client := newTestClient(func(req *http.Request) (*http.Response, error) {
+		t.Error("no request expected")
+		return jsonResponse(200, `{}`), nil
+	})
+
+	if _, err := client.GetQuoteSummary("AAPL", " ", ""); err == nil {
+		t.Fatal("expected error when no modules are requested")
+	}
+}
+
+func TestGetQuoteSummary_YahooError(t *testing.T) {
+	client := newTestClient(func(req *http.Request) (*http.Response, error) {
+		return jsonResponse(200, `{
+			"quoteSummary": {
+				"result": null,
+				"error": {"code": "Not Found", "description": "Quote not found for symbol: XXXX"}
+			}
+		}`), nil
+	})
+
+	_, err := client.GetQuoteSummary("XXXX", "price")
+	if err == nil {
+		t.Fatal("expected error for Yahoo error response")
+	}
+	if !strings.Contains(err.Error(), "Quote not found") {
+		t.Errorf("error should contain Yahoo error description, got: %v", err)
+	}
+}
+
+func TestQuoteSummaryModules_MatchFields(t *testing.T) {
+	var tags []string
+	typ := reflect.TypeOf(QuoteSummaryResult{})
+	for i := 0; i < typ.NumField(); i++ {
+		tag := strings.Split(typ.Field(i).Tag.Get("json"), ",")[0]
+		if tag != "" && tag != "-" {
+			tags = append(tags, tag)
+		}
+	}
+
+	for _, tag := range tags {
+		if !slices.Contains(QuoteSummaryModules, tag) {
+			t.Errorf("field %q is missing from QuoteSummaryModules", tag)
+		}
+	}
+	for _, name := range QuoteSummaryModules {
+		if !slices.Contains(tags, name) {
+			t.Errorf("module %q has no typed field", name)
+		}
+	}
+}
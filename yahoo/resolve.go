@@ -0,0 +1,403 @@
+package yahoo
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// LookupTypes are the quote types accepted by Lookup.
+var LookupTypes = []string{"all", "equity", "etf", "mutualfund", "index", "currency", "cryptocurrency", "future"}
+
+// LookupResponse from v1/finance/lookup.
+type LookupResponse struct {
+	Finance struct {
+		Result []struct {
+			Start     int              `json:"start"`
+			Count     int              `json:"count"`
+			Total     int              `json:"total"`
+			Documents []LookupDocument `json:"documents"`
+		} `json:"result"`
+		Error *YahooError `json:"error"`
+	} `json:"finance"`
+}
+
+// LookupDocument is one symbol matched by Lookup.
+type LookupDocument struct {
+	Symbol       string  `json:"symbol"`
+	ShortName    string  `json:"shortName"`
+	Exchange     string  `json:"exchange"`
+	QuoteType    string  `json:"quoteType"`
+	IndustryName string  `json:"industryName"`
+	Rank         float64 `json:"rank"`
+}
+
+// Lookup finds symbols by ticker or name, optionally restricted to a quote
+// type from LookupTypes. Unlike Search it matches partial names and covers
+// foreign listings.
+func (c *Client) Lookup(query, quoteType string, count int) ([]LookupDocument, error) {
+	quoteType = strings.ToLower(strings.TrimSpace(quoteType))
+	if quoteType == "" {
+		quoteType = "all"
+	}
+	if !contains(LookupTypes, quoteType) {
+		return nil, fmt.Errorf("unknown quote type %q (valid: %s)", quoteType, strings.Join(LookupTypes, ", "))
+	}
+	if count <= 0 {
+		count = 10
+	}
+
+	params := url.Values{
+		"query":     {query},
+		"type":      {quoteType},
+		"start":     {"0"},
+		"count":     {strconv.Itoa(count)},
+		"formatted": {"false"},
+		"lang":      {"en-US"},
+	}
+
+	var resp LookupResponse
+	if err := c.GetJSON("/v1/finance/lookup", params, true, &resp); err != nil {
+		return nil, fmt.Errorf("lookup: %w", err)
+	}
+
+	if resp.Finance.Error != nil {
+		return nil, fmt.Errorf("yahoo error: %s", resp.Finance.Error.Description)
+	}
+
+	if len(resp.Finance.Result) == 0 {
+		return nil, nil
+	}
+	return resp.Finance.Result[0].Documents, nil
+}
+
+// exchangeSuffixes maps exchange codes as written by brokers and other data
+// vendors ("LON:VOD", "STO:VOLV-B") to Yahoo's ticker suffixes.
+var exchangeSuffixes = map[string]string{
+	"NASDAQ": "", "NYSE": "", "NYSEARCA": "", "NYSEAMERICAN": "", "AMEX": "", "NMS": "", "NYQ": "",
+	"LON": ".L", "LSE": ".L",
+	"STO": ".ST", "XSTO": ".ST",
+	"ETR": ".DE", "XETRA": ".DE", "XETR": ".DE", "FRA": ".F",
+	"TYO": ".T", "JPX": ".T",
+	"EPA": ".PA", "AMS": ".AS", "EBR": ".BR", "ELI": ".LS",
+	"HKG": ".HK", "HKEX": ".HK",
+	"TSX": ".TO", "TSXV": ".V", "CVE": ".V",
+	"ASX": ".AX", "SWX": ".SW", "SIX": ".SW", "VTX": ".SW",
+	"BIT": ".MI", "BME": ".MC", "VIE": ".VI",
+	"CPH": ".CO", "HEL": ".HE", "OSL": ".OL", "ICE": ".IC",
+	"KRX": ".KS", "KOSDAQ": ".KQ", "NSE": ".NS", "BOM": ".BO",
+	"SGX": ".SI", "SHA": ".SS", "SHE": ".SZ", "NZE": ".NZ", "TPE": ".TW",
+}
+
+// isYahooSuffix reports whether s (without the dot) is a Yahoo ticker suffix.
+func isYahooSuffix(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, suffix := range exchangeSuffixes {
+		if suffix == "."+s {
+			return true
+		}
+	}
+	return false
+}
+
+// SymbolCandidates returns the Yahoo symbols a user-typed ticker may refer
+// to, most likely first. It maps exchange prefixes ("LON:VOD" → "VOD.L"),
+// trailing exchange codes ("7203 T" → "7203.T") and share classes
+// ("VOLV B" → "VOLV-B", "BRK.B" → "BRK-B") to Yahoo's conventions. The
+// candidates are not verified to exist.
+func SymbolCandidates(input string) []string {
+	s := strings.ToUpper(strings.Join(strings.Fields(input), " "))
+	if s == "" {
+		return nil
+	}
+
+	var out []string
+	add := func(ticker, suffix string) {
+		ticker = normalizeTicker(ticker)
+		if ticker == "" {
+			return
+		}
+		if sym := ticker + suffix; !contains(out, sym) {
+			out = append(out, sym)
+		}
+	}
+
+	if a, b, ok := strings.Cut(s, ":"); ok {
+		a, b = strings.TrimSpace(a), strings.TrimSpace(b)
+		if suffix, ok := exchangeSuffixes[a]; ok {
+			add(b, suffix)
+		}
+		if suffix, ok := exchangeSuffixes[b]; ok {
+			add(a, suffix)
+		}
+		return out
+	}
+
+	// A trailing token may name the exchange: "VOLV B ST", "VOD LON".
+	if i := strings.LastIndex(s, " "); i > 0 {
+		ticker, last := s[:i], s[i+1:]
+		if suffix, ok := exchangeSuffixes[last]; ok {
+			add(ticker, suffix)
+		} else if isYahooSuffix(last) {
+			add(ticker, "."+last)
+		}
+	}
+
+	add(s, "")
+	return out
+}
+
+// normalizeTicker applies Yahoo's share class convention, a dash, to a
+// ticker that may carry a known Yahoo suffix.
+func normalizeTicker(t string) string {
+	suffix := ""
+	if i := strings.LastIndex(t, "."); i > 0 && isYahooSuffix(t[i+1:]) {
+		t, suffix = t[:i], t[i:]
+	}
+	t = strings.NewReplacer(" ", "-", "/", "-", ".", "-").Replace(t)
+	return t + suffix
+}
+
+// IsISIN reports whether s is a well-formed ISIN with a valid check digit.
+func IsISIN(s string) bool {
+	if len(s) != 12 || !isUpperAlpha(s[0]) || !isUpperAlpha(s[1]) || !isDigit(s[11]) {
+		return false
+	}
+	// Letters expand to two digits (A=10 … Z=35), then the Luhn check runs
+	// over the expanded digit string.
+	var digits []int
+	for i := 0; i < len(s); i++ {
+		switch ch := s[i]; {
+		case isDigit(ch):
+			digits = append(digits, int(ch-'0'))
+		case isUpperAlpha(ch):
+			v := int(ch-'A') + 10
+			digits = append(digits, v/10, v%10)
+		default:
+			return false
+		}
+	}
+	sum := 0
+	for i := len(digits) - 1; i >= 0; i-- {
+		d := digits[i]
+		if (len(digits)-1-i)%2 == 1 {
+			d *= 2
+			if d > 9 {
+				d -= 9
+			}
+		}
+		sum += d
+	}
+	return sum%10 == 0
+}
+
+// CUSIPToISIN converts a 9-character CUSIP with a valid check digit to the
+// equivalent US ISIN.
+func CUSIPToISIN(cusip string) (string, bool) {
+	if len(cusip) != 9 || !isDigit(cusip[8]) {
+		return "", false
+	}
+	sum := 0
+	for i := 0; i < 8; i++ {
+		var v int
+		switch ch := cusip[i]; {
+		case isDigit(ch):
+			v = int(ch - '0')
+		case isUpperAlpha(ch):
+			v = int(ch-'A') + 10
+		case ch == '*':
+			v = 36
+		case ch == '@':
+			v = 37
+		case ch == '#':
+			v = 38
+		default:
+			return "", false
+		}
+		if i%2 == 1 {
+			v *= 2
+		}
+		sum += v/10 + v%10
+	}
+	if (10-sum%10)%10 != int(cusip[8]-'0') {
+		return "", false
+	}
+
+	for check := '0'; check <= '9'; check++ {
+		if isin := "US" + cusip + string(check); IsISIN(isin) {
+			return isin, true
+		}
+	}
+	return "", false
+}
+
+func isDigit(ch byte) bool      { return ch >= '0' && ch <= '9' }
+func isUpperAlpha(ch byte) bool { return ch >= 'A' && ch <= 'Z' }
+
+// ResolvedSymbol is a candidate Yahoo symbol for a free-form query.
+type ResolvedSymbol struct {
+	Symbol    string
+	Name      string
+	Exchange  string
+	Currency  string
+	QuoteType string
+	// Method records how the symbol was found: "isin", "symbol" (the query
+	// is, after normalization, a listed ticker), "lookup" or "search".
+	Method string
+}
+
+// ResolveSymbol maps a ticker, exchange-qualified ticker, ISIN, CUSIP or
+// company name to ranked Yahoo symbols. Identifier matches rank first, then
+// tickers that exist as typed or normalized, then lookup and search results.
+// quoteType optionally restricts results to one of LookupTypes.
+func (c *Client) ResolveSymbol(query, quoteType string, limit int) ([]ResolvedSymbol, error) {
+	query = strings.TrimSpace(query)
+	if query == "" {
+		return nil, fmt.Errorf("query is required")
+	}
+	if limit <= 0 {
+		limit = 5
+	}
+	quoteType = strings.ToLower(strings.TrimSpace(quoteType))
+	if quoteType == "all" {
+		quoteType = ""
+	}
+
+	var found []ResolvedSymbol
+	var firstErr error
+	add := func(r ResolvedSymbol) {
+		if r.Symbol == "" || (quoteType != "" && !strings.EqualFold(r.QuoteType, quoteType)) {
+			return
+		}
+		for _, f := range found {
+			if f.Symbol == r.Symbol {
+				return
+			}
+		}
+		found = append(found, r)
+	}
+	keep := func(err error) {
+		if firstErr == nil {
+			firstErr = err
+		}
+	}
+	addSearch := func(q, method string) {
+		resp, err := c.Search(q, limit)
+		if err != nil {
+			keep(err)
+			return
+		}
+		for _, s := range resp.Quotes {
+			name := s.LongName
+			if name == "" {
+				name = s.ShortName
+			}
+			add(ResolvedSymbol{Symbol: s.Symbol, Name: name, Exchange: s.Exchange, QuoteType: s.QuoteType, Method: method})
+		}
+	}
+
+	upper := strings.ToUpper(query)
+	isin := ""
+	if IsISIN(upper) {
+		isin = upper
+	} else if s, ok := CUSIPToISIN(upper); ok {
+		isin = s
+	}
+	if isin != "" {
+		addSearch(isin, "isin")
+	}
+
+	if candidates := SymbolCandidates(query); len(candidates) > 0 && len(candidates) <= maxBulkSymbols {
+		quotes, err := c.GetBulkQuotes(candidates)
+		if err != nil {
+			keep(err)
+		}
+		// Keep candidate order: the first candidate is the likeliest reading.
+		for _, cand := range candidates {
+			for _, q := range quotes {
+				if q.Symbol == cand {
+					add(resolvedFromQuote(q, "symbol"))
+				}
+			}
+		}
+	}
+
+	if len(found) < limit {
+		docs, err := c.Lookup(query, quoteType, limit)
+		if err != nil {
+			keep(err)
+		}
+		for _, d := range docs {
+			add(ResolvedSymbol{Symbol: d.Symbol, Name: d.ShortName, Exchange: d.Exchange, QuoteType: d.QuoteType, Method: "lookup"})
+		}
+	}
+
+	if len(found) < limit {
+		addSearch(query, "search")
+	}
+
+	if len(found) == 0 {
+		if firstErr != nil {
+			return nil, firstErr
+		}
+		return nil, fmt.Errorf("no symbol found for %q", query)
+	}
+	if len(found) > limit {
+		found = found[:limit]
+	}
+
+	c.enrichResolved(found)
+	return found, nil
+}
+
+// enrichResolved fills in currency, exchange and quote type from a bulk
+// quote for results found by lookup or search. Failures leave results as is.
+func (c *Client) enrichResolved(found []ResolvedSymbol) {
+	var symbols []string
+	for _, f := range found {
+		if f.Currency == "" {
+			symbols = append(symbols, f.Symbol)
+		}
+	}
+	if len(symbols) == 0 {
+		return
+	}
+	quotes, err := c.GetBulkQuotes(symbols)
+	if err != nil {
+		return
+	}
+	for i := range found {
+		for _, q := range quotes {
+			if q.Symbol != found[i].Symbol {
+				continue
+			}
+			r := resolvedFromQuote(q, found[i].Method)
+			if found[i].Name != "" {
+				r.Name = found[i].Name
+			}
+			found[i] = r
+		}
+	}
+}
+
+func resolvedFromQuote(q BulkQuoteResult, method string) ResolvedSymbol {
+	name := q.LongName
+	if name == "" {
+		name = q.ShortName
+	}
+	exchange := q.FullExchangeName
+	if exchange == "" {
+		exchange = q.Exchange
+	}
+	return ResolvedSymbol{
+		Symbol:    q.Symbol,
+		Name:      name,
+		Exchange:  exchange,
+		Currency:  q.Currency,
+		QuoteType: q.QuoteType,
+		Method:    method,
+	}
+}
@@ -0,0 +1,198 @@
+package yahoo
+
+import (
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestIsISIN(t *testing.T) {
+	tests := []struct {
+		in   string
+		want bool
+	}{
+		{"US0378331005", true},  // Apple
+		{"SE0000115446", true},  // Volvo B
+		{"GB00BH4HKS39", true},  // Vodafone
+		{"US0378331006", false}, // bad check digit
+		{"US037833100", false},
+		{"AAPL", false},
+		{"120378331005", false},
+	}
+	for _, tt := range tests {
+		if got := IsISIN(tt.in); got != tt.want {
+			t.Errorf("IsISIN(%q) = %v, want %v", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestCUSIPToISIN(t *testing.T) {
+	isin, ok := CUSIPToISIN("037833100")
+	if !ok || isin != "US0378331005" {
+		t.Errorf("CUSIPToISIN(037833100) = %q, %v; want US0378331005, true", isin, ok)
+	}
+	if _, ok := CUSIPToISIN("037833101"); ok {
+		t.Error("expected failure for bad CUSIP check digit")
+	}
+	if _, ok := CUSIPToISIN("AAPL"); ok {
+		t.Error("expected failure for non-CUSIP input")
+	}
+}
+
+func TestSymbolCandidates(t *testing.T) {
+	tests := []struct {
+		in   string
+		want []string
+	}{
+		{"aapl", []string{"AAPL"}},
+		{"Volvo B", []string{"VOLVO-B"}},
+		{"VOLV B ST", []string{"VOLV-B.ST", "VOLV-B-ST"}},
+		{"VOLV.B.ST", []string{"VOLV-B.ST"}},
+		{"BRK.B", []string{"BRK-B"}},
+		{"VOD.L", []string{"VOD.L"}},
+		{"LON:VOD", []string{"VOD.L"}},
+		{"STO:VOLV-B", []string{"VOLV-B.ST"}},
+		{"BMW:XETRA", []string{"BMW.DE"}},
+		{"NASDAQ:MSFT", []string{"MSFT"}},
+		{"7203 T", []string{"7203.T", "7203-T"}},
+		{"VOD LON", []string{"VOD.L", "VOD-LON"}},
+		{"  ", nil},
+	}
+	for _, tt := range tests {
+		if got := SymbolCandidates(tt.in); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("SymbolCandidates(%q) = %v, want %v", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestLookup_Success(t *testing.T) {
+	client := newTestClient(func(req *http.Request) (*http.Response, error) {
+		if req.URL.Path != "/v1/finance/lookup" {
+			t.Errorf("unexpected path: %s", req.URL.Path)
+		}
+		q := req.URL.Query()
+		if q.Get("query") != "volvo" || q.Get("type") != "equity" || q.Get("count") != "3" {
+			t.Errorf("unexpected params: %s", req.URL.RawQuery)
+		}
+		return jsonResponse(200, `{
+			"finance": {
+				"result": [{
+					"start": 0, "count": 2, "total": 2,
+					"documents": [
+						{"symbol": "VOLV-B.ST", "shortName": "AB Volvo (publ)", "exchange": "STO", "quoteType": "equity", "rank": 120},
+						{"symbol": "VOLV-A.ST", "shortName": "AB Volvo (publ)", "exchange": "STO", "quoteType": "equity", "rank": 20}
+					]
+				}],
+				"error": null
+			}
+		}`), nil
+	})
+
+	docs, err := client.Lookup("volvo", "Equity", 3)
+	if err != nil {
+		t.Fatalf("Lookup() error: %v", err)
+	}
+	if len(docs) != 2 {
+		t.Fatalf("len = %d, want 2", len(docs))
+	}
+	if docs[0].Symbol != "VOLV-B.ST" || docs[0].Exchange != "STO" {
+		t.Errorf("docs[0] = %+v", docs[0])
+	}
+}
+
+func TestLookup_InvalidType(t *testing.T) {
+	client := newTestClient(func(req *http.Request) (*http.Response, error) {
+		t.Fatal("should not make a request for an invalid type")
+		return nil, nil
+	})
+
+	if _, err := client.Lookup("volvo", "bonds", 5); err == nil {
+		t.Fatal("expected error for invalid quote type")
+	}
+}
+
+func TestResolveSymbol_ISIN(t *testing.T) {
+	client := newTestClient(func(req *http.Request) (*http.Response, error) {
+		switch req.URL.Path {
+		case "/v1/finance/search":
+			if q := req.URL.Query().Get("q"); q != "US0378331005" && q != "037833100" {
+				return jsonResponse(200, `{"quotes": []}`), nil
+			}
+			return jsonResponse(200, `{"quotes": [{"symbol": "AAPL", "shortname": "Apple Inc.", "exchange": "NMS", "quoteType": "EQUITY"}]}`), nil
+		case "/v7/finance/quote":
+			if !strings.Contains(req.URL.Query().Get("symbols"), "AAPL") {
+				return jsonResponse(200, `{"quoteResponse": {"result": []}}`), nil
+			}
+			return jsonResponse(200, `{"quoteResponse": {"result": [{"symbol": "AAPL", "longName": "Apple Inc.", "fullExchangeName": "NasdaqGS", "currency": "USD", "quoteType": "EQUITY"}]}}`), nil
+		case "/v1/finance/lookup":
+			return jsonResponse(200, `{"finance": {"result": [{"documents": []}]}}`), nil
+		}
+		t.Errorf("unexpected path: %s", req.URL.Path)
+		return jsonResponse(404, ``), nil
+	})
+
+	for _, q := range []string{"US0378331005", "037833100"} {
+		results, err := client.ResolveSymbol(q, "", 5)
+		if err != nil {
+			t.Fatalf("ResolveSymbol(%q) error: %v", q, err)
+		}
+		want := ResolvedSymbol{Symbol: "AAPL", Name: "Apple Inc.", Exchange: "NasdaqGS", Currency: "USD", QuoteType: "EQUITY", Method: "isin"}
+		if len(results) != 1 || results[0] != want {
+			t.Errorf("ResolveSymbol(%q) = %+v, want [%+v]", q, results, want)
+		}
+	}
+}
+
+func TestResolveSymbol_RanksVerifiedTickerFirst(t *testing.T) {
+	client := newTestClient(func(req *http.Request) (*http.Response, error) {
+		switch req.URL.Path {
+		case "/v7/finance/quote":
+			symbols := req.URL.Query().Get("symbols")
+			if symbols == "VOD.L,VOD-LON" {
+				return jsonResponse(200, `{"quoteResponse": {"result": [{"symbol": "VOD.L", "longName": "Vodafone Group Plc", "fullExchangeName": "LSE", "currency": "GBp", "quoteType": "EQUITY"}]}}`), nil
+			}
+			return jsonResponse(200, `{"quoteResponse": {"result": [{"symbol": "VOD", "longName": "Vodafone Group Plc", "fullExchangeName": "NasdaqGS", "currency": "USD", "quoteType": "EQUITY"}]}}`), nil
+		case "/v1/finance/lookup":
+			return jsonResponse(200, `{"finance": {"result": [{"documents": [
+				{"symbol": "VOD", "shortName": "Vodafone Group Plc", "exchange": "NMS", "quoteType": "equity"},
+				{"symbol": "VOD.L", "shortName": "Vodafone Group Plc", "exchange": "LSE", "quoteType": "equity"}
+			]}]}}`), nil
+		case "/v1/finance/search":
+			return jsonResponse(200, `{"quotes": []}`), nil
+		}
+		t.Errorf("unexpected path: %s", req.URL.Path)
+		return jsonResponse(404, ``), nil
+	})
+
+	results, err := client.ResolveSymbol("VOD LON", "equity", 5)
+	if err != nil {
+		t.Fatalf("ResolveSymbol() error: %v", err)
+	}
+	if len(results) != 2 {
+		t.Fatalf("len = %d, want 2: %+v", len(results), results)
+	}
+	if results[0].Symbol != "VOD.L" || results[0].Method != "symbol" || results[0].Currency != "GBp" {
+		t.Errorf("results[0] = %+v, want VOD.L via symbol in GBp", results[0])
+	}
+	if results[1].Symbol != "VOD" || results[1].Method != "lookup" || results[1].Currency != "USD" {
+		t.Errorf("results[1] = %+v, want enriched VOD via lookup", results[1])
+	}
+}
+
+func TestResolveSymbol_NotFound(t *testing.T) {
+	client := newTestClient(func(req *http.Request) (*http.Response, error) {
+		switch req.URL.Path {
+		case "/v7/finance/quote":
+			return jsonResponse(200, `{"quoteResponse": {"result": []}}`), nil
+		case "/v1/finance/lookup":
+			return jsonResponse(200, `{"finance": {"result": []}}`), nil
+		}
+		return jsonResponse(200, `{"quotes": []}`), nil
+	})
+
+	_, err := client.ResolveSymbol("zzzz nothing", "", 5)
+	if err == nil || !strings.Contains(err.Error(), "no symbol found") {
+		t.Errorf("expected not-found error, got %v", err)
+	}
+}
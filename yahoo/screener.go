@@ -0,0 +1,509 @@
+package yahoo
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// maxScreenerResults is the largest page the screener endpoint returns.
+const maxScreenerResults = 250
+
+// ScreenerQuery is a node in a screener query tree: a comparison of a field
+// against values, or an AND/OR of nested queries.
+type ScreenerQuery struct {
+	Operator string
+	Operands []any // nested ScreenerQuery values, or a field name followed by values
+}
+
+// MarshalJSON encodes the query in the shape the screener endpoint expects.
+func (q ScreenerQuery) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Operator string `json:"operator"`
+		Operands []any  `json:"operands"`
+	}{q.Operator, q.Operands})
+}
+
+// And matches when every query matches.
+func And(qs ...ScreenerQuery) ScreenerQuery { return logical("AND", qs) }
+
+// Or matches when any query matches.
+func Or(qs ...ScreenerQuery) ScreenerQuery { return logical("OR", qs) }
+
+func logical(op string, qs []ScreenerQuery) ScreenerQuery {
+	operands := make([]any, len(qs))
+	for i, q := range qs {
+		operands[i] = q
+	}
+	return ScreenerQuery{Operator: op, Operands: operands}
+}
+
+// Eq matches a field equal to value.
+func Eq(field string, value any) ScreenerQuery {
+	return ScreenerQuery{Operator: "EQ", Operands: []any{field, value}}
+}
+
+// Gt matches a field greater than value.
+func Gt(field string, value float64) ScreenerQuery {
+	return ScreenerQuery{Operator: "GT", Operands: []any{field, value}}
+}
+
+// Gte matches a field greater than or equal to value.
+func Gte(field string, value float64) ScreenerQuery {
+	return ScreenerQuery{Operator: "GTE", Operands: []any{field, value}}
+}
+
+// Lt matches a field less than value.
+func Lt(field string, value float64) ScreenerQuery {
+	return ScreenerQuery{Operator: "LT", Operands: []any{field, value}}
+}
+
+// Lte matches a field less than or equal to value.
+func Lte(field string, value float64) ScreenerQuery {
+	return ScreenerQuery{Operator: "LTE", Operands: []any{field, value}}
+}
+
+// Btwn matches a field between lo and hi inclusive.
+func Btwn(field string, lo, hi float64) ScreenerQuery {
+	return ScreenerQuery{Operator: "BTWN", Operands: []any{field, lo, hi}}
+}
+
+// IsIn matches a field equal to any of values.
+func IsIn(field string, values ...any) ScreenerQuery {
+	qs := make([]ScreenerQuery, len(values))
+	for i, v := range values {
+		qs[i] = Eq(field, v)
+	}
+	return Or(qs...)
+}
+
+// ScreenerField describes a field the equity screener accepts.
+type ScreenerField struct {
+	Name     string
+	Category string
+	// Values lists the accepted values of categorical fields; empty for
+	// numeric fields.
+	Values []string
+}
+
+// Numeric reports whether the field compares against numbers.
+func (f ScreenerField) Numeric() bool {
+	return len(f.Values) == 0
+}
+
+var screenerSectors = []string{
+	"Basic Materials", "Communication Services", "Consumer Cyclical", "Consumer Defensive",
+	"Energy", "Financial Services", "Healthcare", "Industrials", "Real Estate",
+	"Technology", "Utilities",
+}
+
+var screenerRegions = []string{
+	"ar", "at", "au", "be", "br", "ca", "ch", "cl", "cn", "cz", "de", "dk", "ee", "eg",
+	"es", "fi", "fr", "gb", "gr", "hk", "hu", "id", "ie", "il", "in", "is", "it", "jp",
+	"kr", "kw", "lk", "lt", "lv", "mx", "my", "nl", "no", "nz", "pe", "ph", "pk", "pl",
+	"pt", "qa", "ro", "ru", "sa", "se", "sg", "sr", "th", "tr", "tw", "us", "ve", "vn", "za",
+}
+
+var screenerExchanges = []string{
+	"NMS", "NYQ", "NGM", "NCM", "ASE", "PCX", "BTS", "LSE", "GER", "FRA", "PAR", "AMS",
+	"MIL", "MCE", "STO", "CPH", "HEL", "OSL", "EBS", "VIE", "BRU", "LIS", "TOR", "VAN",
+	"JPX", "HKG", "SHH", "SHZ", "KSC", "KOE", "TAI", "TWO", "NSI", "BSE", "ASX", "NZE",
+	"SES", "SAO", "MEX", "JNB", "TLV", "SAU",
+}
+
+// screenerFields lists the equity screener fields by category.
+var screenerFields = func() []ScreenerField {
+	numeric := map[string][]string{
+		"price": {
+			"intradaymarketcap", "intradayprice", "intradaypricechange", "percentchange",
+			"eodprice", "lastclosemarketcap.lasttwelvemonths", "fiftytwowkpercentchange",
+			"lastclose52weekhigh.lasttwelvemonths", "lastclose52weeklow.lasttwelvemonths",
+		},
+		"trading": {
+			"beta", "avgdailyvol3m", "dayvolume", "eodvolume", "pctheldinsider", "pctheldinst",
+		},
+		"short interest": {
+			"short_percentage_of_shares_outstanding.value", "short_percentage_of_float.value",
+			"short_interest.value", "days_to_cover_short.value", "short_interest_percentage_change.value",
+		},
+		"valuation": {
+			"peratio.lasttwelvemonths", "pegratio_5y", "pricebookratio.quarterly",
+			"bookvalueshare.lasttwelvemonths", "lastclosemarketcaptotalrevenue.lasttwelvemonths",
+			"lastclosetevtotalrevenue.lasttwelvemonths", "lastclosepriceearnings.lasttwelvemonths",
+			"lastclosepricetangiblebookvalue.lasttwelvemonths",
+		},
+		"profitability": {
+			"returnonassets.lasttwelvemonths", "returnonequity.lasttwelvemonths",
+			"returnontotalcapital.lasttwelvemonths", "forward_dividend_yield",
+			"forward_dividend_per_share", "consecutive_years_of_dividend_growth_count",
+		},
+		"leverage": {
+			"totaldebtequity.lasttwelvemonths", "ltdebtequity.lasttwelvemonths",
+			"netdebtebitda.lasttwelvemonths", "totaldebtebitda.lasttwelvemonths",
+			"ebitinterestexpense.lasttwelvemonths", "ebitdainterestexpense.lasttwelvemonths",
+			"lastclosetevebit.lasttwelvemonths", "lastclosetevebitda.lasttwelvemonths",
+		},
+		"liquidity": {
+			"currentratio.lasttwelvemonths", "quickratio.lasttwelvemonths",
+			"operatingcashflowtocurrentliabilities.lasttwelvemonths",
+			"altmanzscoreusingtheaveragestockinformationforaperiod.lasttwelvemonths",
+		},
+		"income statement": {
+			"totalrevenues.lasttwelvemonths", "totalrevenues1yrgrowth.lasttwelvemonths",
+			"quarterlyrevenuegrowth.quarterly", "grossprofit.lasttwelvemonths",
+			"grossprofitmargin.lasttwelvemonths", "ebitda.lasttwelvemonths", "ebitdamargin.lasttwelvemonths",
+			"ebitda1yrgrowth.lasttwelvemonths", "ebit.lasttwelvemonths", "operatingincome.lasttwelvemonths",
+			"netincomeis.lasttwelvemonths", "netincomemargin.lasttwelvemonths",
+			"netincome1yrgrowth.lasttwelvemonths", "epsgrowth.lasttwelvemonths",
+			"dilutedeps1yrgrowth.lasttwelvemonths", "netepsbasic.lasttwelvemonths",
+			"netepsdiluted.lasttwelvemonths", "basicepscontinuingoperations.lasttwelvemonths",
+			"dilutedepscontinuingoperations.lasttwelvemonths",
+		},
+		"balance sheet": {
+			"totalassets.lasttwelvemonths", "totaldebt.lasttwelvemonths", "totalequity.lasttwelvemonths",
+			"totalcommonequity.lasttwelvemonths", "totalcurrentassets.lasttwelvemonths",
+			"totalcurrentliabilities.lasttwelvemonths", "totalcashandshortterminvestments.lasttwelvemonths",
+			"totalcommonsharesoutstanding.lasttwelvemonths", "totalsharesoutstanding",
+		},
+		"cash flow": {
+			"cashfromoperations.lasttwelvemonths", "cashfromoperations1yrgrowth.lasttwelvemonths",
+			"capitalexpenditure.lasttwelvemonths", "leveredfreecashflow.lasttwelvemonths",
+			"leveredfreecashflow1yrgrowth.lasttwelvemonths", "unleveredfreecashflow.lasttwelvemonths",
+		},
+		"esg": {
+			"esg_score", "environmental_score", "social_score", "governance_score", "highest_controversy",
+		},
+	}
+
+	fields := []ScreenerField{
+		{Name: "region", Category: "classification", Values: screenerRegions},
+		{Name: "sector", Category: "classification", Values: screenerSectors},
+		{Name: "exchange", Category: "classification", Values: screenerExchanges},
+	}
+	var categories []string
+	for c := range numeric {
+		categories = append(categories, c)
+	}
+	sort.Strings(categories)
+	for _, c := range categories {
+		for _, name := range numeric[c] {
+			fields = append(fields, ScreenerField{Name: name, Category: c})
+		}
+	}
+	return fields
+}()
+
+// ScreenerFields returns the fields the equity screener accepts.
+func ScreenerFields() []ScreenerField {
+	return screenerFields
+}
+
+// LookupScreenerField resolves a field name case-insensitively. A name may
+// omit its period suffix (e.g., "peratio" for "peratio.lasttwelvemonths")
+// when that is unambiguous.
+func LookupScreenerField(name string) (ScreenerField, error) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	var matches []ScreenerField
+	for _, f := range screenerFields {
+		if f.Name == name {
+			return f, nil
+		}
+		if base, _, ok := strings.Cut(f.Name, "."); ok && base == name {
+			matches = append(matches, f)
+		}
+	}
+	switch len(matches) {
+	case 0:
+		return ScreenerField{}, fmt.Errorf("unknown screener field %q", name)
+	case 1:
+		return matches[0], nil
+	}
+	return ScreenerField{}, fmt.Errorf("ambiguous screener field %q", name)
+}
+
+// Validate checks that every comparison uses a known field with values of
+// the right kind.
+func (q ScreenerQuery) Validate() error {
+	switch q.Operator {
+	case "AND", "OR":
+		if len(q.Operands) == 0 {
+			return fmt.Errorf("%s needs at least one operand", q.Operator)
+		}
+		for _, o := range q.Operands {
+			sub, ok := o.(ScreenerQuery)
+			if !ok {
+				return fmt.Errorf("%s operands must be queries", q.Operator)
+			}
+			if err := sub.Validate(); err != nil {
+				return err
+			}
+		}
+		return nil
+	case "EQ", "GT", "GTE", "LT", "LTE", "BTWN":
+	default:
+		return fmt.Errorf("unknown operator %q", q.Operator)
+	}
+
+	want := 2
+	if q.Operator == "BTWN" {
+		want = 3
+	}
+	if len(q.Operands) != want {
+		return fmt.Errorf("%s needs a field and %d value(s)", q.Operator, want-1)
+	}
+	name, ok := q.Operands[0].(string)
+	if !ok {
+		return fmt.Errorf("%s operand must start with a field name", q.Operator)
+	}
+	f, err := LookupScreenerField(name)
+	if err != nil {
+		return err
+	}
+	if f.Name != name {
+		return fmt.Errorf("use the full field name %q", f.Name)
+	}
+
+	if !f.Numeric() {
+		if q.Operator != "EQ" {
+			return fmt.Errorf("field %q only supports equality", f.Name)
+		}
+		v, ok := q.Operands[1].(string)
+		if !ok || !contains(f.Values, v) {
+			return fmt.Errorf("invalid %s %v", f.Name, q.Operands[1])
+		}
+		return nil
+	}
+	for _, v := range q.Operands[1:] {
+		if _, ok := v.(float64); !ok {
+			return fmt.Errorf("field %q needs numeric values, got %v", f.Name, v)
+		}
+	}
+	return nil
+}
+
+func contains(values []string, v string) bool {
+	for _, s := range values {
+		if s == v {
+			return true
+		}
+	}
+	return false
+}
+
+// ParseScreenerFilters builds an AND query from comma or semicolon separated
+// conditions such as "region = us, sector = Technology, intradaymarketcap > 10e9,
+// peratio between 5 and 20, exchange in NMS|NYQ". Operators are =, >, >=, <,
+// <=, between and in. Field names resolve with LookupScreenerField and
+// categorical values are matched case-insensitively.
+func ParseScreenerFilters(s string) (ScreenerQuery, error) {
+	var qs []ScreenerQuery
+	for _, raw := range strings.FieldsFunc(s, func(r rune) bool { return r == ',' || r == ';' }) {
+		raw = strings.TrimSpace(raw)
+		if raw == "" {
+			continue
+		}
+		q, err := parseScreenerCondition(raw)
+		if err != nil {
+			return ScreenerQuery{}, fmt.Errorf("condition %q: %w", raw, err)
+		}
+		qs = append(qs, q)
+	}
+	if len(qs) == 0 {
+		return ScreenerQuery{}, fmt.Errorf("at least one filter is required")
+	}
+	q := And(qs...)
+	return q, q.Validate()
+}
+
+var screenerOperators = []string{">=", "<=", "==", "=", ">", "<", " between ", " in "}
+
+func parseScreenerCondition(s string) (ScreenerQuery, error) {
+	lower := strings.ToLower(s)
+	for _, op := range screenerOperators {
+		i := strings.Index(lower, op)
+		if i < 0 {
+			continue
+		}
+		f, err := LookupScreenerField(s[:i])
+		if err != nil {
+			return ScreenerQuery{}, err
+		}
+		value := strings.TrimSpace(s[i+len(op):])
+
+		switch strings.TrimSpace(op) {
+		case "between":
+			lo, hi, ok := strings.Cut(strings.ToLower(value), " and ")
+			if !ok {
+				return ScreenerQuery{}, fmt.Errorf("use \"between <low> and <high>\"")
+			}
+			a, err1 := parseScreenerNumber(lo)
+			b, err2 := parseScreenerNumber(hi)
+			if err1 != nil || err2 != nil {
+				return ScreenerQuery{}, fmt.Errorf("between needs two numbers")
+			}
+			return Btwn(f.Name, a, b), nil
+		case "in":
+			var values []any
+			for _, v := range strings.Split(value, "|") {
+				cv, err := screenerValue(f, v)
+				if err != nil {
+					return ScreenerQuery{}, err
+				}
+				values = append(values, cv)
+			}
+			return IsIn(f.Name, values...), nil
+		case "=", "==":
+			v, err := screenerValue(f, value)
+			if err != nil {
+				return ScreenerQuery{}, err
+			}
+			return Eq(f.Name, v), nil
+		}
+
+		if !f.Numeric() {
+			return ScreenerQuery{}, fmt.Errorf("field %q only supports = and in", f.Name)
+		}
+		n, err := parseScreenerNumber(value)
+		if err != nil {
+			return ScreenerQuery{}, err
+		}
+		switch op {
+		case ">":
+			return Gt(f.Name, n), nil
+		case ">=":
+			return Gte(f.Name, n), nil
+		case "<":
+			return Lt(f.Name, n), nil
+		default:
+			return Lte(f.Name, n), nil
+		}
+	}
+	return ScreenerQuery{}, fmt.Errorf("missing operator (use =, >, >=, <, <=, between or in)")
+}
+
+// screenerValue converts a filter value to the field's type, canonicalizing
+// the case of categorical values.
+func screenerValue(f ScreenerField, v string) (any, error) {
+	v = strings.TrimSpace(v)
+	if f.Numeric() {
+		return parseScreenerNumber(v)
+	}
+	for _, allowed := range f.Values {
+		if strings.EqualFold(allowed, v) {
+			return allowed, nil
+		}
+	}
+	return nil, fmt.Errorf("invalid %s %q", f.Name, v)
+}
+
+// parseScreenerNumber parses a number with an optional K, M, B or T suffix.
+func parseScreenerNumber(s string) (float64, error) {
+	s = strings.ToUpper(strings.TrimSpace(strings.ReplaceAll(s, "_", "")))
+	mult := 1.0
+	if n := len(s); n > 0 {
+		switch s[n-1] {
+		case 'K':
+			mult, s = 1e3, s[:n-1]
+		case 'M':
+			mult, s = 1e6, s[:n-1]
+		case 'B':
+			mult, s = 1e9, s[:n-1]
+		case 'T':
+			mult, s = 1e12, s[:n-1]
+		}
+	}
+	n, err := strconv.ParseFloat(strings.TrimPrefix(s, "$"), 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid number %q", s)
+	}
+	return n * mult, nil
+}
+
+// ScreenerRequest is a screener query with sorting and pagination.
+type ScreenerRequest struct {
+	Query     ScreenerQuery
+	SortField string // defaults to intradaymarketcap
+	Ascending bool
+	Offset    int
+	Size      int // 1-250, defaults to 25
+}
+
+// ScreenerResponse from v1/finance/screener.
+type ScreenerResponse struct {
+	Finance struct {
+		Result []ScreenerResult `json:"result"`
+		Error  *YahooError      `json:"error"`
+	} `json:"finance"`
+}
+
+// ScreenerResult is one page of screener matches.
+type ScreenerResult struct {
+	ID          string            `json:"id"`
+	Title       string            `json:"title"`
+	Description string            `json:"description"`
+	Start       int               `json:"start"`
+	Count       int               `json:"count"`
+	Total       int               `json:"total"`
+	Quotes      []BulkQuoteResult `json:"quotes"`
+}
+
+// Screen runs an equity screener query.
+func (c *Client) Screen(r ScreenerRequest) (*ScreenerResult, error) {
+	if err := r.Query.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid screener query: %w", err)
+	}
+
+	sortField := "intradaymarketcap"
+	if r.SortField != "" {
+		f, err := LookupScreenerField(r.SortField)
+		if err != nil {
+			return nil, err
+		}
+		sortField = f.Name
+	}
+	size := r.Size
+	if size <= 0 {
+		size = 25
+	}
+	if size > maxScreenerResults {
+		return nil, fmt.Errorf("too many results requested: %d (max %d)", size, maxScreenerResults)
+	}
+	sortType := "DESC"
+	if r.Ascending {
+		sortType = "ASC"
+	}
+
+	body := map[string]any{
+		"offset":     max(r.Offset, 0),
+		"size":       size,
+		"sortField":  sortField,
+		"sortType":   sortType,
+		"quoteType":  "EQUITY",
+		"query":      r.Query,
+		"userId":     "",
+		"userIdType": "guid",
+	}
+	params := url.Values{
+		"formatted": {"false"},
+		"lang":      {"en-US"},
+		"region":    {"US"},
+	}
+
+	var resp ScreenerResponse
+	if err := c.PostJSON("/v1/finance/screener", params, body, &resp); err != nil {
+		return nil, fmt.Errorf("screen: %w", err)
+	}
+
+	if resp.Finance.Error != nil {
+		return nil, fmt.Errorf("yahoo error: %s", resp.Finance.Error.Description)
+	}
+
+	if len(resp.Finance.Result) == 0 {
+		return &ScreenerResult{}, nil
+	}
+
+	return &resp.Finance.Result[0], nil
+}
@@ -0,0 +1,164 @@
+package yahoo
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestScreenerQuery_MarshalJSON(t *testing.T) {
+	q := And(Eq("region", "us"), Or(Gt("intradaymarketcap", 1e10), Btwn("peratio.lasttwelvemonths", 5, 20)))
+	data, err := json.Marshal(q)
+	if err != nil {
+		t.Fatalf("Marshal() error: %v", err)
+	}
+	want := `{"operator":"AND","operands":[{"operator":"EQ","operands":["region","us"]},` +
+		`{"operator":"OR","operands":[{"operator":"GT","operands":["intradaymarketcap",10000000000]},` +
+		`{"operator":"BTWN","operands":["peratio.lasttwelvemonths",5,20]}]}]}`
+	if string(data) != want {
+		t.Errorf("json = %s\nwant   %s", data, want)
+	}
+}
+
+func TestLookupScreenerField(t *testing.T) {
+	f, err := LookupScreenerField("PERatio")
+	if err != nil || f.Name != "peratio.lasttwelvemonths" {
+		t.Errorf("LookupScreenerField(PERatio) = %q, %v", f.Name, err)
+	}
+	if f, err := LookupScreenerField("sector"); err != nil || f.Numeric() {
+		t.Errorf("sector should be a categorical field, got %+v, %v", f, err)
+	}
+	if _, err := LookupScreenerField("favorite_color"); err == nil {
+		t.Error("expected error for unknown field")
+	}
+}
+
+func TestScreenerQuery_Validate(t *testing.T) {
+	valid := And(Eq("sector", "Technology"), Lt("peratio.lasttwelvemonths", 20))
+	if err := valid.Validate(); err != nil {
+		t.Errorf("Validate() error: %v", err)
+	}
+
+	invalid := map[string]ScreenerQuery{
+		"unknown field":     Gt("nonsense", 1),
+		"short field name":  Gt("peratio", 1),
+		"bad sector":        Eq("sector", "Crypto"),
+		"range on category": {Operator: "GT", Operands: []any{"region", 1.0}},
+		"string number":     Eq("beta", "high"),
+		"empty and":         And(),
+		"unknown operator":  {Operator: "LIKE", Operands: []any{"sector", "Tech"}},
+	}
+	for name, q := range invalid {
+		if err := q.Validate(); err == nil {
+			t.Errorf("%s: expected validation error", name)
+		}
+	}
+}
+
+func TestParseScreenerFilters(t *testing.T) {
+	q, err := ParseScreenerFilters("region = US, sector = technology; intradaymarketcap > 10B, peratio between 5 and 20, beta <= 1.5, exchange in NMS|nyq")
+	if err != nil {
+		t.Fatalf("ParseScreenerFilters() error: %v", err)
+	}
+	data, _ := json.Marshal(q)
+	for _, want := range []string{
+		`["region","us"]`,
+		`["sector","Technology"]`,
+		`{"operator":"GT","operands":["intradaymarketcap",10000000000]}`,
+		`{"operator":"BTWN","operands":["peratio.lasttwelvemonths",5,20]}`,
+		`{"operator":"LTE","operands":["beta",1.5]}`,
+		`{"operator":"OR","operands":[{"operator":"EQ","operands":["exchange","NMS"]},{"operator":"EQ","operands":["exchange","NYQ"]}]}`,
+	} {
+		if !strings.Contains(string(data), want) {
+			t.Errorf("query %s\nmissing %s", data, want)
+		}
+	}
+}
+
+func TestParseScreenerFilters_Invalid(t *testing.T) {
+	for _, s := range []string{
+		"",
+		"sector > 5",
+		"sector = Crypto",
+		"marketcap > 5",
+		"peratio ~ 5",
+		"peratio between 5",
+		"beta > high",
+	} {
+		if _, err := ParseScreenerFilters(s); err == nil {
+			t.Errorf("ParseScreenerFilters(%q) expected error", s)
+		}
+	}
+}
+
+func TestScreen_Success(t *testing.T) {
+	client := newTestClient(func(req *http.Request) (*http.Response, error) {
+		if req.Method != http.MethodPost || req.URL.Path != "/v1/finance/screener" {
+			t.Errorf("unexpected request: %s %s", req.Method, req.URL.Path)
+		}
+		var body map[string]any
+		raw, _ := io.ReadAll(req.Body)
+		if err := json.Unmarshal(raw, &body); err != nil {
+			t.Fatalf("request body is not JSON: %v", err)
+		}
+		if body["sortField"] != "peratio.lasttwelvemonths" || body["sortType"] != "ASC" {
+			t.Errorf("sort = %v %v", body["sortField"], body["sortType"])
+		}
+		if body["size"] != 10.0 || body["offset"] != 20.0 || body["quoteType"] != "EQUITY" {
+			t.Errorf("paging = size %v offset %v type %v", body["size"], body["offset"], body["quoteType"])
+		}
+		return jsonResponse(200, `{
+			"finance": {
+				"result": [{
+					"start": 20,
+					"count": 1,
+					"total": 42,
+					"quotes": [{"symbol": "INTC", "shortName": "Intel", "regularMarketPrice": 31.5, "marketCap": 134000000000, "trailingPE": 15.2}]
+				}],
+				"error": null
+			}
+		}`), nil
+	})
+
+	q, _ := ParseScreenerFilters("sector = Technology, peratio < 20")
+	result, err := client.Screen(ScreenerRequest{Query: q, SortField: "peratio", Ascending: true, Offset: 20, Size: 10})
+	if err != nil {
+		t.Fatalf("Screen() error: %v", err)
+	}
+	if result.Total != 42 || len(result.Quotes) != 1 {
+		t.Fatalf("result = total %d, %d quotes", result.Total, len(result.Quotes))
+	}
+	if result.Quotes[0].Symbol != "INTC" || result.Quotes[0].TrailingPE != 15.2 {
+		t.Errorf("quote = %+v", result.Quotes[0])
+	}
+}
+
+func TestScreen_Invalid(t *testing.T) {
+	client := newTestClient(func(req *http.Request) (*http.Response, error) {
+		t.Fatal("no request expected")
+		return nil, nil
+	})
+
+	if _, err := client.Screen(ScreenerRequest{Query: Gt("nonsense", 1)}); err == nil {
+		t.Error("expected error for invalid query")
+	}
+	if _, err := client.Screen(ScreenerRequest{Query: Gt("beta", 1), Size: 500}); err == nil {
+		t.Error("expected error for oversized page")
+	}
+	if _, err := client.Screen(ScreenerRequest{Query: Gt("beta", 1), SortField: "nonsense"}); err == nil {
+		t.Error("expected error for unknown sort field")
+	}
+}
+
+func TestScreen_YahooError(t *testing.T) {
+	client := newTestClient(func(req *http.Request) (*http.Response, error) {
+		return jsonResponse(200, `{"finance": {"result": null, "error": {"code": "Bad Request", "description": "Invalid query"}}}`), nil
+	})
+
+	_, err := client.Screen(ScreenerRequest{Query: Gt("beta", 1)})
+	if err == nil || !strings.Contains(err.Error(), "Invalid query") {
+		t.Errorf("error = %v, want yahoo error description", err)
+	}
+}
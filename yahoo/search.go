@@ -4,20 +4,60 @@ import (
 	"fmt"
 	"net/url"
 	"strconv"
+	"strings"
 )
 
+// SearchQuoteTypes are the quote types search results can be filtered to.
+var SearchQuoteTypes = []string{"EQUITY", "ETF", "MUTUALFUND", "CRYPTOCURRENCY", "FUTURE", "INDEX", "CURRENCY"}
+
+// maxSearchQuotes is the most quotes the search endpoint returns per request.
+const maxSearchQuotes = 50
+
+// SearchOptions controls a search. The zero value matches Search.
+type SearchOptions struct {
+	Limit int // quotes to return (default 10)
+	// QuoteTypes and Exchanges restrict quotes to the listed values. An
+	// exchange matches either its code (NMS) or display name (NASDAQ).
+	QuoteTypes []string
+	Exchanges  []string
+	Region     string // ranks results for a region, e.g. "GB"
+	Fuzzy      bool   // tolerate misspellings
+	News       int    // news articles to include
+	Lists      int    // Yahoo curated lists to include
+}
+
 // Search finds symbols and companies matching the query.
 func (c *Client) Search(query string, limit int) (*SearchResponse, error) {
+	return c.SearchWith(query, SearchOptions{Limit: limit})
+}
+
+// SearchWith finds symbols, and optionally news and curated lists, matching
+// the query. Type and exchange filters are applied to the returned quotes, so
+// more quotes are requested than Limit when filtering.
+func (c *Client) SearchWith(query string, opts SearchOptions) (*SearchResponse, error) {
+	limit := opts.Limit
 	if limit <= 0 {
 		limit = 10
 	}
+	filtered := len(opts.QuoteTypes) > 0 || len(opts.Exchanges) > 0
+	count := limit
+	if filtered {
+		count = maxSearchQuotes
+	}
 
 	params := url.Values{
-		"q":           {query},
-		"quotesCount": {strconv.Itoa(limit)},
-		"newsCount":   {"0"},
-		"enableFuzzyQuery": {"false"},
-		"quotesQueryId": {"tss_match_phrase_query"},
+		"q":                {query},
+		"quotesCount":      {strconv.Itoa(count)},
+		"newsCount":        {strconv.Itoa(opts.News)},
+		"enableFuzzyQuery": {strconv.FormatBool(opts.Fuzzy)},
+		"quotesQueryId":    {"tss_match_phrase_query"},
+	}
+	if opts.Lists > 0 {
+		params.Set("listsCount", strconv.Itoa(opts.Lists))
+	}
+	if opts.Region != "" {
+		params.Set("region", strings.ToUpper(opts.Region))
+		params.Set("lang", "en-US")
 	}
 
 	var resp SearchResponse
@@ -25,5 +65,32 @@ func (c *Client) Search(query string, limit int) (*SearchResponse, error) {
 		return nil, fmt.Errorf("search: %w", err)
 	}
 
+	if filtered {
+		var quotes []SearchQuote
+		for _, q := range resp.Quotes {
+			if matchesAny(opts.QuoteTypes, q.QuoteType) && (matchesAny(opts.Exchanges, q.Exchange) || matchesAny(opts.Exchanges, q.ExchDisp)) {
+				quotes = append(quotes, q)
+			}
+		}
+		resp.Quotes = quotes
+	}
+	if len(resp.Quotes) > limit {
+		resp.Quotes = resp.Quotes[:limit]
+	}
+
 	return &resp, nil
 }
+
+// matchesAny reports whether v case-insensitively equals one of values, or
+// values is empty.
+func matchesAny(values []string, v string) bool {
+	if len(values) == 0 {
+		return true
+	}
+	for _, s := range values {
+		if strings.EqualFold(s, v) {
+			return true
+		}
+	}
+	return false
+}
@@ -100,3 +100,75 @@ func TestSearch_NoCrumb(t *testing.T) {
 
 	client.Search("test", 5)
 }
+
+func TestSearchWith_Options(t *testing.T) {
+	client := newTestClient(func(req *http.Request) (*http.Response, error) {
+		q := req.URL.Query()
+		want := map[string]string{
+			"newsCount":        "3",
+			"listsCount":       "2",
+			"enableFuzzyQuery": "true",
+			"region":           "GB",
+			"quotesCount":      "5",
+		}
+		for k, v := range want {
+			if q.Get(k) != v {
+				t.Errorf("%s = %q, want %q", k, q.Get(k), v)
+			}
+		}
+		return jsonResponse(200, `{
+			"quotes": [{"symbol": "VOD.L", "exchange": "LSE", "quoteType": "EQUITY"}],
+			"news": [{"uuid": "n1", "title": "Vodafone results", "publisher": "Reuters"}],
+			"lists": [{"id": "l1", "name": "Telecom Stocks", "slug": "telecom-stocks"}],
+			"count": 3
+		}`), nil
+	})
+
+	resp, err := client.SearchWith("vodafone", SearchOptions{Limit: 5, Region: "gb", Fuzzy: true, News: 3, Lists: 2})
+	if err != nil {
+		t.Fatalf("SearchWith() error: %v", err)
+	}
+	if len(resp.News) != 1 || resp.News[0].Title != "Vodafone results" {
+		t.Errorf("news = %+v", resp.News)
+	}
+	if len(resp.Lists) != 1 || resp.Lists[0].Slug != "telecom-stocks" {
+		t.Errorf("lists = %+v", resp.Lists)
+	}
+}
+
+func TestSearchWith_Filters(t *testing.T) {
+	client := newTestClient(func(req *http.Request) (*http.Response, error) {
+		if got := req.URL.Query().Get("quotesCount"); got != "50" {
+			t.Errorf("quotesCount = %q, want 50 when filtering", got)
+		}
+		return jsonResponse(200, `{
+			"quotes": [
+				{"symbol": "VOD", "exchange": "NMS", "exchDisp": "NASDAQ", "quoteType": "EQUITY"},
+				{"symbol": "VOD.L", "exchange": "LSE", "exchDisp": "London", "quoteType": "EQUITY"},
+				{"symbol": "VODPF", "exchange": "PNK", "exchDisp": "OTC Markets", "quoteType": "EQUITY"},
+				{"symbol": "VODX", "exchange": "NMS", "exchDisp": "NASDAQ", "quoteType": "ETF"},
+				{"symbol": "VOD2", "exchange": "NMS", "exchDisp": "NASDAQ", "quoteType": "EQUITY"}
+			]
+		}`), nil
+	})
+
+	resp, err := client.SearchWith("vodafone", SearchOptions{
+		Limit:      1,
+		QuoteTypes: []string{"equity"},
+		Exchanges:  []string{"nasdaq", "LSE"},
+	})
+	if err != nil {
+		t.Fatalf("SearchWith() error: %v", err)
+	}
+	if len(resp.Quotes) != 1 || resp.Quotes[0].Symbol != "VOD" {
+		t.Errorf("quotes = %+v, want only VOD", resp.Quotes)
+	}
+
+	resp, err = client.SearchWith("vodafone", SearchOptions{Limit: 10, Exchanges: []string{"LSE", "PNK"}})
+	if err != nil {
+		t.Fatalf("SearchWith() error: %v", err)
+	}
+	if len(resp.Quotes) != 2 || resp.Quotes[0].Symbol != "VOD.L" || resp.Quotes[1].Symbol != "VODPF" {
+		t.Errorf("quotes = %+v, want VOD.L and VODPF", resp.Quotes)
+	}
+}
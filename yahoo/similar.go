@@ -0,0 +1,53 @@
+package yahoo
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+)
+
+// RecommendationsBySymbolResponse from v6/finance/recommendationsbysymbol.
+type RecommendationsBySymbolResponse struct {
+	Finance struct {
+		Result []struct {
+			Symbol             string          `json:"symbol"`
+			RecommendedSymbols []SimilarSymbol `json:"recommendedSymbols"`
+		} `json:"result"`
+		Error *YahooError `json:"error"`
+	} `json:"finance"`
+}
+
+// SimilarSymbol is a ticker Yahoo recommends alongside another. Higher
+// scores are more similar.
+type SimilarSymbol struct {
+	Symbol string  `json:"symbol"`
+	Score  float64 `json:"score"`
+}
+
+// GetSimilarSymbols fetches up to count tickers similar to symbol.
+func (c *Client) GetSimilarSymbols(symbol string, count int) ([]SimilarSymbol, error) {
+	params := url.Values{}
+	if count > 0 {
+		params.Set("count", strconv.Itoa(count))
+	}
+
+	var resp RecommendationsBySymbolResponse
+	path := fmt.Sprintf("/v6/finance/recommendationsbysymbol/%s", url.PathEscape(symbol))
+	if err := c.GetJSON(path, params, true, &resp); err != nil {
+		return nil, fmt.Errorf("get similar symbols: %w", err)
+	}
+
+	if resp.Finance.Error != nil {
+		return nil, fmt.Errorf("yahoo error: %s", resp.Finance.Error.Description)
+	}
+
+	if len(resp.Finance.Result) == 0 {
+		return nil, fmt.Errorf("no data found for symbol %q", symbol)
+	}
+
+	similar := resp.Finance.Result[0].RecommendedSymbols
+	if count > 0 && len(similar) > count {
+		similar = similar[:count]
+	}
+	return similar, nil
+}
@@ -0,0 +1,75 @@
+package yahoo
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestGetSimilarSymbols_Success(t *testing.T) {
+	client := newTestClient(func(req *http.Request) (*http.Response, error) {
+		if !strings.Contains(req.URL.Path, "/v6/finance/recommendationsbysymbol/AAPL") {
+			t.Errorf("unexpected path: %s", req.URL.Path)
+		}
+		if count := req.URL.Query().Get("count"); count != "2" {
+			t.Errorf("count = %q, want %q", count, "2")
+		}
+		return jsonResponse(200, `{
+			"finance": {
+				"result": [{
+					"symbol": "AAPL",
+					"recommendedSymbols": [
+						{"symbol": "AMZN", "score": 0.281},
+						{"symbol": "GOOG", "score": 0.265},
+						{"symbol": "MSFT", "score": 0.251}
+					]
+				}],
+				"error": null
+			}
+		}`), nil
+	})
+
+	result, err := client.GetSimilarSymbols("AAPL", 2)
+	if err != nil {
+		t.Fatalf("GetSimilarSymbols() error: %v", err)
+	}
+	if len(result) != 2 {
+		t.Fatalf("len = %d, want 2", len(result))
+	}
+	if result[0].Symbol != "AMZN" || result[0].Score != 0.281 {
+		t.Errorf("result[0] = %+v, want {AMZN 0.281}", result[0])
+	}
+	if result[1].Symbol != "GOOG" {
+		t.Errorf("result[1].Symbol = %q, want GOOG", result[1].Symbol)
+	}
+}
+
+func TestGetSimilarSymbols_YahooError(t *testing.T) {
+	client := newTestClient(func(req *http.Request) (*http.Response, error) {
+		return jsonResponse(200, `{
+			"finance": {
+				"result": null,
+				"error": {"code": "Not Found", "description": "No data found"}
+			}
+		}`), nil
+	})
+
+	_, err := client.GetSimilarSymbols("INVALID", 5)
+	if err == nil {
+		t.Fatal("expected error for Yahoo error response")
+	}
+	if !strings.Contains(err.Error(), "No data found") {
+		t.Errorf("error should contain Yahoo error description, got: %v", err)
+	}
+}
+
+func TestGetSimilarSymbols_EmptyResult(t *testing.T) {
+	client := newTestClient(func(req *http.Request) (*http.Response, error) {
+		return jsonResponse(200, `{"finance": {"result": [], "error": null}}`), nil
+	})
+
+	_, err := client.GetSimilarSymbols("AAPL", 5)
+	if err == nil {
+		t.Fatal("expected error for empty result")
+	}
+}
@@ -0,0 +1,11 @@
+package yahoo
+
+// SnapshotModules are the quoteSummary modules GetSnapshot requests: quote,
+// valuation, profile, analyst consensus and headline financial data.
+const SnapshotModules = "price,summaryDetail,assetProfile,quoteType,recommendationTrend,financialData,defaultKeyStatistics"
+
+// GetSnapshot fetches everything get_quote, get_profile and
+// get_recommendations show for a symbol in one quoteSummary call.
+func (c *Client) GetSnapshot(symbol string) (*QuoteSummaryResult, error) {
+	return c.quoteSummary("get snapshot", symbol, SnapshotModules)
+}
@@ -0,0 +1,68 @@
+package yahoo
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestGetSnapshot_Success(t *testing.T) {
+	client := newTestClient(func(req *http.Request) (*http.Response, error) {
+		if !strings.Contains(req.URL.Path, "/v10/finance/quoteSummary/AAPL") {
+			t.Errorf("unexpected path: %s", req.URL.Path)
+		}
+		if modules := req.URL.Query().Get("modules"); modules != SnapshotModules {
+			t.Errorf("modules = %q, want %q", modules, SnapshotModules)
+		}
+		return jsonResponse(200, `{
+			"quoteSummary": {
+				"result": [{
+					"price": {"symbol": "AAPL", "regularMarketPrice": {"raw": 190.5, "fmt": "190.50"}},
+					"assetProfile": {"sector": "Technology", "industry": "Consumer Electronics"},
+					"quoteType": {"symbol": "AAPL", "longName": "Apple Inc."},
+					"recommendationTrend": {"trend": [{"period": "0m", "strongBuy": 10, "buy": 20, "hold": 8}]},
+					"financialData": {"targetMeanPrice": {"raw": 210, "fmt": "210.00"}, "recommendationKey": "buy"}
+				}]
+			}
+		}`), nil
+	})
+
+	result, err := client.GetSnapshot("AAPL")
+	if err != nil {
+		t.Fatalf("GetSnapshot() error: %v", err)
+	}
+	if result.Price.RegularMarketPrice.Raw != 190.5 {
+		t.Errorf("RegularMarketPrice = %v, want 190.5", result.Price.RegularMarketPrice.Raw)
+	}
+	if result.AssetProfile.Sector != "Technology" {
+		t.Errorf("Sector = %q, want Technology", result.AssetProfile.Sector)
+	}
+	if result.QuoteType.LongName != "Apple Inc." {
+		t.Errorf("LongName = %q, want Apple Inc.", result.QuoteType.LongName)
+	}
+	if len(result.RecommendationTrend.Trend) != 1 || result.RecommendationTrend.Trend[0].Buy != 20 {
+		t.Errorf("RecommendationTrend = %+v", result.RecommendationTrend)
+	}
+	if result.FinancialData.TargetMeanPrice.Raw != 210 {
+		t.Errorf("TargetMeanPrice = %v, want 210", result.FinancialData.TargetMeanPrice.Raw)
+	}
+}
+
+func TestGetSnapshot_YahooError(t *testing.T) {
+	client := newTestClient(func(req *http.Request) (*http.Response, error) {
+		return jsonResponse(200, `{
+			"quoteSummary": {
+				"result": null,
+				"error": {"code": "Not Found", "description": "Quote not found for symbol: XXXX"}
+			}
+		}`), nil
+	})
+
+	_, err := client.GetSnapshot("XXXX")
+	if err == nil {
+		t.Fatal("expected error for Yahoo error response")
+	}
+	if !strings.Contains(err.Error(), "Quote not found") {
+		t.Errorf("error should contain Yahoo error description, got: %v", err)
+	}
+}
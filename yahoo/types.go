@@ -1,5 +1,7 @@
 package yahoo
 
+import "encoding/json"
+
 // QuoteSummaryResponse is the top-level response for quoteSummary endpoints.
 type QuoteSummaryResponse struct {
 	QuoteSummary struct {
@@ -8,12 +10,49 @@ type QuoteSummaryResponse struct {
 	} `json:"quoteSummary"`
 }
 
+// QuoteSummaryResult holds the modules of a quoteSummary response. Each typed
+// field is nil unless its module was requested and returned.
 type QuoteSummaryResult struct {
-	Price               *PriceData               `json:"price"`
-	SummaryDetail       *SummaryDetailData       `json:"summaryDetail"`
-	AssetProfile        *AssetProfileData        `json:"assetProfile"`
-	QuoteType           *QuoteTypeData           `json:"quoteType"`
-	RecommendationTrend *RecommendationTrendData `json:"recommendationTrend"`
+	Price                             *PriceData                    `json:"price"`
+	SummaryDetail                     *SummaryDetailData            `json:"summaryDetail"`
+	AssetProfile                      *AssetProfileData             `json:"assetProfile"`
+	SummaryProfile                    *AssetProfileData             `json:"summaryProfile"` // assetProfile without officers
+	QuoteType                         *QuoteTypeData                `json:"quoteType"`
+	RecommendationTrend               *RecommendationTrendData      `json:"recommendationTrend"`
+	UpgradeDowngradeHistory           *UpgradeDowngradeHistoryData  `json:"upgradeDowngradeHistory"`
+	FinancialData                     *FinancialDataModule          `json:"financialData"`
+	FundProfile                       *FundProfileData              `json:"fundProfile"`
+	TopHoldings                       *TopHoldingsData              `json:"topHoldings"`
+	FundPerformance                   *FundPerformanceData          `json:"fundPerformance"`
+	EarningsTrend                     *EarningsTrendData            `json:"earningsTrend"`
+	DefaultKeyStatistics              *DefaultKeyStatisticsData     `json:"defaultKeyStatistics"`
+	CalendarEvents                    *CalendarEventsData           `json:"calendarEvents"`
+	Earnings                          *EarningsData                 `json:"earnings"`
+	EarningsHistory                   *EarningsHistoryData          `json:"earningsHistory"`
+	IncomeStatementHistory            *StatementHistoryData         `json:"incomeStatementHistory"`
+	IncomeStatementHistoryQuarterly   *StatementHistoryData         `json:"incomeStatementHistoryQuarterly"`
+	BalanceSheetHistory               *StatementHistoryData         `json:"balanceSheetHistory"`
+	BalanceSheetHistoryQuarterly      *StatementHistoryData         `json:"balanceSheetHistoryQuarterly"`
+	CashflowStatementHistory          *StatementHistoryData         `json:"cashflowStatementHistory"`
+	CashflowStatementHistoryQuarterly *StatementHistoryData         `json:"cashflowStatementHistoryQuarterly"`
+	MajorHoldersBreakdown             *MajorHoldersBreakdownData    `json:"majorHoldersBreakdown"`
+	InstitutionOwnership              *OwnershipData                `json:"institutionOwnership"`
+	FundOwnership                     *OwnershipData                `json:"fundOwnership"`
+	MajorDirectHolders                *DirectHoldersData            `json:"majorDirectHolders"`
+	InsiderHolders                    *InsiderHoldersData           `json:"insiderHolders"`
+	InsiderTransactions               *InsiderTransactionsData      `json:"insiderTransactions"`
+	NetSharePurchaseActivity          *NetSharePurchaseActivityData `json:"netSharePurchaseActivity"`
+	SECFilings                        *SECFilingsData               `json:"secFilings"`
+	ESGScores                         *ESGScoresData                `json:"esgScores"`
+	IndexTrend                        *TrendData                    `json:"indexTrend"`
+	SectorTrend                       *TrendData                    `json:"sectorTrend"`
+	IndustryTrend                     *TrendData                    `json:"industryTrend"`
+	PageViews                         *PageViewsData                `json:"pageViews"`
+
+	// Raw holds the JSON of returned modules without a typed field.
+	Raw map[string]json.RawMessage `json:"-"`
+
+	modules map[string]json.RawMessage // every returned module, for Module
 }
 
 type YahooError struct {
@@ -73,6 +112,8 @@ type SummaryDetailData struct {
 	Beta             YahooValue `json:"beta"`
 	TrailingAnnualDividendYield YahooValue `json:"trailingAnnualDividendYield"`
 	PayoutRatio      YahooValue `json:"payoutRatio"`
+	TotalAssets      YahooValue `json:"totalAssets"`
+	Yield            YahooValue `json:"yield"`
 }
 
 // ChartResponse from v8 chart endpoint.
@@ -123,6 +164,7 @@ type ChartAdjClose struct {
 type SearchResponse struct {
 	Quotes []SearchQuote `json:"quotes"`
 	News   []SearchNews  `json:"news"`
+	Lists  []SearchList  `json:"lists"`
 	Count  int           `json:"count"`
 }
 
@@ -131,18 +173,55 @@ type SearchQuote struct {
 	ShortName string `json:"shortname"`
 	LongName  string `json:"longname"`
 	Exchange  string `json:"exchange"`
+	ExchDisp  string `json:"exchDisp"`
 	QuoteType string `json:"quoteType"`
+	TypeDisp  string `json:"typeDisp"`
 	Industry  string `json:"industry"`
 	Sector    string `json:"sector"`
 	Score     float64 `json:"score"`
 }
 
+// SearchList is a Yahoo curated list of symbols matching a search.
+type SearchList struct {
+	ID            string `json:"id"`
+	Name          string `json:"name"`
+	Slug          string `json:"slug"`
+	FollowerCount int64  `json:"followerCount"`
+}
+
 type SearchNews struct {
-	UUID          string `json:"uuid"`
-	Title         string `json:"title"`
-	Publisher     string `json:"publisher"`
-	Link          string `json:"link"`
-	ProviderPublishTime int64  `json:"providerPublishTime"`
+	UUID                string         `json:"uuid"`
+	Title               string         `json:"title"`
+	Publisher           string         `json:"publisher"`
+	Link                string         `json:"link"`
+	ProviderPublishTime int64          `json:"providerPublishTime"`
+	Type                string         `json:"type"`
+	Thumbnail           *NewsThumbnail `json:"thumbnail"`
+	RelatedTickers      []string       `json:"relatedTickers"`
+}
+
+// NewsThumbnail holds the image renditions attached to a news article.
+type NewsThumbnail struct {
+	Resolutions []struct {
+		URL    string `json:"url"`
+		Width  int    `json:"width"`
+		Height int    `json:"height"`
+		Tag    string `json:"tag"`
+	} `json:"resolutions"`
+}
+
+// URL returns the largest thumbnail rendition, or "" when there is none.
+func (t *NewsThumbnail) URL() string {
+	if t == nil {
+		return ""
+	}
+	best, area := "", -1
+	for _, r := range t.Resolutions {
+		if r.Width*r.Height > area {
+			best, area = r.URL, r.Width*r.Height
+		}
+	}
+	return best
 }
 
 // AssetProfileData from quoteSummary assetProfile module.
@@ -194,6 +273,411 @@ type RecommendationTrend struct {
 	StrongSell int    `json:"strongSell"`
 }
 
+// UpgradeDowngradeHistoryData from quoteSummary upgradeDowngradeHistory module.
+type UpgradeDowngradeHistoryData struct {
+	History []AnalystAction `json:"history"`
+}
+
+// AnalystAction is a single rating change published by a research firm.
+type AnalystAction struct {
+	EpochGradeDate     int64   `json:"epochGradeDate"`
+	Firm               string  `json:"firm"`
+	ToGrade            string  `json:"toGrade"`
+	FromGrade          string  `json:"fromGrade"`
+	Action             string  `json:"action"`
+	PriceTargetAction  string  `json:"priceTargetAction"`
+	CurrentPriceTarget float64 `json:"currentPriceTarget"`
+	PriorPriceTarget   float64 `json:"priorPriceTarget"`
+}
+
+// FinancialDataModule from quoteSummary financialData module.
+type FinancialDataModule struct {
+	CurrentPrice            YahooValue     `json:"currentPrice"`
+	TargetHighPrice         YahooValue     `json:"targetHighPrice"`
+	TargetLowPrice          YahooValue     `json:"targetLowPrice"`
+	TargetMeanPrice         YahooValue     `json:"targetMeanPrice"`
+	TargetMedianPrice       YahooValue     `json:"targetMedianPrice"`
+	RecommendationMean      YahooValue     `json:"recommendationMean"`
+	RecommendationKey       string         `json:"recommendationKey"`
+	NumberOfAnalystOpinions YahooLongValue `json:"numberOfAnalystOpinions"`
+	TotalCash               YahooLongValue `json:"totalCash"`
+	TotalDebt               YahooLongValue `json:"totalDebt"`
+	TotalRevenue            YahooLongValue `json:"totalRevenue"`
+	Ebitda                  YahooLongValue `json:"ebitda"`
+	FreeCashflow            YahooLongValue `json:"freeCashflow"`
+	OperatingCashflow       YahooLongValue `json:"operatingCashflow"`
+	RevenueGrowth           YahooValue     `json:"revenueGrowth"`
+	EarningsGrowth          YahooValue     `json:"earningsGrowth"`
+	GrossMargins            YahooValue     `json:"grossMargins"`
+	EbitdaMargins           YahooValue     `json:"ebitdaMargins"`
+	OperatingMargins        YahooValue     `json:"operatingMargins"`
+	ProfitMargins           YahooValue     `json:"profitMargins"`
+	ReturnOnAssets          YahooValue     `json:"returnOnAssets"`
+	ReturnOnEquity          YahooValue     `json:"returnOnEquity"`
+	DebtToEquity            YahooValue     `json:"debtToEquity"`
+	CurrentRatio            YahooValue     `json:"currentRatio"`
+	QuickRatio              YahooValue     `json:"quickRatio"`
+	FinancialCurrency       string         `json:"financialCurrency"`
+}
+
+// DefaultKeyStatisticsData from quoteSummary defaultKeyStatistics module.
+type DefaultKeyStatisticsData struct {
+	EnterpriseValue     YahooLongValue `json:"enterpriseValue"`
+	EnterpriseToRevenue YahooValue     `json:"enterpriseToRevenue"`
+	EnterpriseToEbitda  YahooValue     `json:"enterpriseToEbitda"`
+	PriceToBook         YahooValue     `json:"priceToBook"`
+	PegRatio            YahooValue     `json:"pegRatio"`
+	SharesOutstanding   YahooLongValue `json:"sharesOutstanding"`
+	FiftyTwoWeekChange  YahooValue     `json:"52WeekChange"`
+}
+
+// FundProfileData from quoteSummary fundProfile module.
+type FundProfileData struct {
+	Family                    string    `json:"family"`
+	CategoryName              string    `json:"categoryName"`
+	LegalType                 string    `json:"legalType"`
+	FeesExpensesInvestment    *FundFees `json:"feesExpensesInvestment"`
+	FeesExpensesInvestmentCat *FundFees `json:"feesExpensesInvestmentCat"`
+}
+
+// FundFees holds a fund's expense figures (or its category averages).
+type FundFees struct {
+	AnnualReportExpenseRatio YahooValue `json:"annualReportExpenseRatio"`
+	AnnualHoldingsTurnover   YahooValue `json:"annualHoldingsTurnover"`
+	TotalNetAssets           YahooValue `json:"totalNetAssets"`
+}
+
+// TopHoldingsData from quoteSummary topHoldings module.
+// SectorWeightings and BondRatings are lists of single-key objects, e.g. {"technology": {...}}.
+type TopHoldingsData struct {
+	CashPosition     YahooValue              `json:"cashPosition"`
+	StockPosition    YahooValue              `json:"stockPosition"`
+	BondPosition     YahooValue              `json:"bondPosition"`
+	OtherPosition    YahooValue              `json:"otherPosition"`
+	Holdings         []FundHolding           `json:"holdings"`
+	SectorWeightings []map[string]YahooValue `json:"sectorWeightings"`
+	BondRatings      []map[string]YahooValue `json:"bondRatings"`
+}
+
+type FundHolding struct {
+	Symbol         string     `json:"symbol"`
+	HoldingName    string     `json:"holdingName"`
+	HoldingPercent YahooValue `json:"holdingPercent"`
+}
+
+// FundPerformanceData from quoteSummary fundPerformance module.
+type FundPerformanceData struct {
+	TrailingReturns    FundReturns `json:"trailingReturns"`
+	TrailingReturnsCat FundReturns `json:"trailingReturnsCat"`
+}
+
+// FundReturns holds trailing total returns as fractions (0.1 = 10%).
+type FundReturns struct {
+	AsOfDate   YahooValue `json:"asOfDate"`
+	YTD        YahooValue `json:"ytd"`
+	OneMonth   YahooValue `json:"oneMonth"`
+	ThreeMonth YahooValue `json:"threeMonth"`
+	OneYear    YahooValue `json:"oneYear"`
+	ThreeYear  YahooValue `json:"threeYear"`
+	FiveYear   YahooValue `json:"fiveYear"`
+	TenYear    YahooValue `json:"tenYear"`
+}
+
+// EarningsTrendData from quoteSummary earningsTrend module.
+type EarningsTrendData struct {
+	Trend []EarningsTrend `json:"trend"`
+}
+
+// EarningsTrend holds consensus estimates for one period: "0q" and "+1q"
+// (current and next quarter), "0y" and "+1y" (current and next fiscal year),
+// "+5y" (next five years, per annum) and "-5y" (past five years, per annum).
+type EarningsTrend struct {
+	Period           string        `json:"period"`
+	EndDate          string        `json:"endDate"`
+	Growth           YahooValue    `json:"growth"`
+	EarningsEstimate TrendEstimate `json:"earningsEstimate"`
+	RevenueEstimate  TrendEstimate `json:"revenueEstimate"`
+}
+
+// TrendEstimate is a consensus estimate range for EPS or revenue. Only the
+// year-ago field matching the estimate is set.
+type TrendEstimate struct {
+	Avg              YahooValue     `json:"avg"`
+	Low              YahooValue     `json:"low"`
+	High             YahooValue     `json:"high"`
+	YearAgoEps       YahooValue     `json:"yearAgoEps"`
+	YearAgoRevenue   YahooValue     `json:"yearAgoRevenue"`
+	NumberOfAnalysts YahooLongValue `json:"numberOfAnalysts"`
+	Growth           YahooValue     `json:"growth"`
+}
+
+// CalendarEventsData from quoteSummary calendarEvents module.
+type CalendarEventsData struct {
+	Earnings       CalendarEarnings `json:"earnings"`
+	ExDividendDate YahooValue       `json:"exDividendDate"`
+	DividendDate   YahooValue       `json:"dividendDate"`
+}
+
+// CalendarEarnings holds the next earnings date (or date range) and its
+// consensus estimates.
+type CalendarEarnings struct {
+	EarningsDate           []YahooValue `json:"earningsDate"`
+	EarningsCallDate       []YahooValue `json:"earningsCallDate"`
+	IsEarningsDateEstimate bool         `json:"isEarningsDateEstimate"`
+	EarningsAverage        YahooValue   `json:"earningsAverage"`
+	EarningsLow            YahooValue   `json:"earningsLow"`
+	EarningsHigh           YahooValue   `json:"earningsHigh"`
+	RevenueAverage         YahooValue   `json:"revenueAverage"`
+	RevenueLow             YahooValue   `json:"revenueLow"`
+	RevenueHigh            YahooValue   `json:"revenueHigh"`
+}
+
+// EarningsData from quoteSummary earnings module.
+type EarningsData struct {
+	EarningsChart struct {
+		Quarterly                  []EarningsEstimatePoint `json:"quarterly"`
+		CurrentQuarterEstimate     YahooValue              `json:"currentQuarterEstimate"`
+		CurrentQuarterEstimateDate string                  `json:"currentQuarterEstimateDate"`
+		CurrentQuarterEstimateYear int                     `json:"currentQuarterEstimateYear"`
+		EarningsDate               []YahooValue            `json:"earningsDate"`
+	} `json:"earningsChart"`
+	FinancialsChart struct {
+		Yearly    []YearlyFinancials    `json:"yearly"`
+		Quarterly []QuarterlyFinancials `json:"quarterly"`
+	} `json:"financialsChart"`
+	FinancialCurrency string `json:"financialCurrency"`
+}
+
+// EarningsEstimatePoint compares reported and estimated EPS for a quarter
+// such as "3Q2024".
+type EarningsEstimatePoint struct {
+	Date     string     `json:"date"`
+	Actual   YahooValue `json:"actual"`
+	Estimate YahooValue `json:"estimate"`
+}
+
+type YearlyFinancials struct {
+	Date     int        `json:"date"`
+	Revenue  YahooValue `json:"revenue"`
+	Earnings YahooValue `json:"earnings"`
+}
+
+type QuarterlyFinancials struct {
+	Date     string     `json:"date"`
+	Revenue  YahooValue `json:"revenue"`
+	Earnings YahooValue `json:"earnings"`
+}
+
+// EarningsHistoryData from quoteSummary earningsHistory module.
+type EarningsHistoryData struct {
+	History []EarningsSurprise `json:"history"`
+}
+
+// EarningsSurprise compares reported and estimated EPS for a past quarter.
+type EarningsSurprise struct {
+	Quarter         YahooValue `json:"quarter"` // quarter end, Unix seconds
+	Period          string     `json:"period"`  // "-1q" is the latest
+	EpsActual       YahooValue `json:"epsActual"`
+	EpsEstimate     YahooValue `json:"epsEstimate"`
+	EpsDifference   YahooValue `json:"epsDifference"`
+	SurprisePercent YahooValue `json:"surprisePercent"`
+}
+
+// StatementHistoryData from the quoteSummary incomeStatementHistory,
+// balanceSheetHistory and cashflowStatementHistory modules and their
+// quarterly variants. Each module fills one of the lists.
+type StatementHistoryData struct {
+	IncomeStatements   []StatementEntry `json:"incomeStatementHistory"`
+	BalanceSheets      []StatementEntry `json:"balanceSheetStatements"`
+	CashflowStatements []StatementEntry `json:"cashflowStatements"`
+}
+
+// Statements returns the statements of whichever kind the module holds.
+func (d *StatementHistoryData) Statements() []StatementEntry {
+	switch {
+	case d == nil:
+		return nil
+	case len(d.IncomeStatements) > 0:
+		return d.IncomeStatements
+	case len(d.BalanceSheets) > 0:
+		return d.BalanceSheets
+	}
+	return d.CashflowStatements
+}
+
+// StatementEntry is one period of a statement history. Yahoo has dropped
+// most line items from these modules, so they are kept by name.
+type StatementEntry struct {
+	EndDate YahooValue
+	Items   map[string]YahooValue
+}
+
+// UnmarshalJSON reads endDate and every other {raw, fmt} line item.
+func (e *StatementEntry) UnmarshalJSON(data []byte) error {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return err
+	}
+	e.Items = make(map[string]YahooValue)
+	for name, raw := range fields {
+		var v YahooValue
+		if json.Unmarshal(raw, &v) != nil {
+			continue // maxAge and other plain values
+		}
+		if name == "endDate" {
+			e.EndDate = v
+		} else {
+			e.Items[name] = v
+		}
+	}
+	return nil
+}
+
+// MajorHoldersBreakdownData from quoteSummary majorHoldersBreakdown module.
+type MajorHoldersBreakdownData struct {
+	InsidersPercentHeld          YahooValue     `json:"insidersPercentHeld"`
+	InstitutionsPercentHeld      YahooValue     `json:"institutionsPercentHeld"`
+	InstitutionsFloatPercentHeld YahooValue     `json:"institutionsFloatPercentHeld"`
+	InstitutionsCount            YahooLongValue `json:"institutionsCount"`
+}
+
+// OwnershipData from the quoteSummary institutionOwnership and
+// fundOwnership modules.
+type OwnershipData struct {
+	OwnershipList []Holder `json:"ownershipList"`
+}
+
+// Holder is an institution's or fund's position as of its last filing.
+type Holder struct {
+	ReportDate   YahooValue     `json:"reportDate"`
+	Organization string         `json:"organization"`
+	PctHeld      YahooValue     `json:"pctHeld"`
+	Position     YahooLongValue `json:"position"`
+	Value        YahooValue     `json:"value"`
+	PctChange    YahooValue     `json:"pctChange"`
+}
+
+// DirectHoldersData from quoteSummary majorDirectHolders module.
+type DirectHoldersData struct {
+	Holders []DirectHolder `json:"holders"`
+}
+
+type DirectHolder struct {
+	Name               string         `json:"name"`
+	PositionDirect     YahooLongValue `json:"positionDirect"`
+	PositionDirectDate YahooValue     `json:"positionDirectDate"`
+	ValueDirect        YahooValue     `json:"valueDirect"`
+}
+
+// InsiderHoldersData from quoteSummary insiderHolders module.
+type InsiderHoldersData struct {
+	Holders []InsiderHolder `json:"holders"`
+}
+
+type InsiderHolder struct {
+	Name                   string         `json:"name"`
+	Relation               string         `json:"relation"`
+	URL                    string         `json:"url"`
+	TransactionDescription string         `json:"transactionDescription"`
+	LatestTransDate        YahooValue     `json:"latestTransDate"`
+	PositionDirect         YahooLongValue `json:"positionDirect"`
+	PositionDirectDate     YahooValue     `json:"positionDirectDate"`
+}
+
+// InsiderTransactionsData from quoteSummary insiderTransactions module.
+type InsiderTransactionsData struct {
+	Transactions []InsiderTransaction `json:"transactions"`
+}
+
+type InsiderTransaction struct {
+	FilerName       string         `json:"filerName"`
+	FilerRelation   string         `json:"filerRelation"`
+	FilerURL        string         `json:"filerUrl"`
+	TransactionText string         `json:"transactionText"`
+	MoneyText       string         `json:"moneyText"`
+	Ownership       string         `json:"ownership"` // D (direct) or I (indirect)
+	StartDate       YahooValue     `json:"startDate"`
+	Shares          YahooLongValue `json:"shares"`
+	Value           YahooValue     `json:"value"`
+}
+
+// NetSharePurchaseActivityData from quoteSummary netSharePurchaseActivity
+// module: insider buying and selling over Period (e.g. "6m").
+type NetSharePurchaseActivityData struct {
+	Period                   string         `json:"period"`
+	BuyInfoCount             YahooLongValue `json:"buyInfoCount"`
+	BuyInfoShares            YahooLongValue `json:"buyInfoShares"`
+	BuyPercentInsiderShares  YahooValue     `json:"buyPercentInsiderShares"`
+	SellInfoCount            YahooLongValue `json:"sellInfoCount"`
+	SellInfoShares           YahooLongValue `json:"sellInfoShares"`
+	SellPercentInsiderShares YahooValue     `json:"sellPercentInsiderShares"`
+	NetInfoCount             YahooLongValue `json:"netInfoCount"`
+	NetInfoShares            YahooLongValue `json:"netInfoShares"`
+	NetPercentInsiderShares  YahooValue     `json:"netPercentInsiderShares"`
+	TotalInsiderShares       YahooLongValue `json:"totalInsiderShares"`
+}
+
+// SECFilingsData from quoteSummary secFilings module.
+type SECFilingsData struct {
+	Filings []SECFiling `json:"filings"`
+}
+
+type SECFiling struct {
+	Date      string `json:"date"`
+	EpochDate int64  `json:"epochDate"`
+	Type      string `json:"type"`
+	Title     string `json:"title"`
+	EdgarURL  string `json:"edgarUrl"`
+	Exhibits  []struct {
+		Type string `json:"type"`
+		URL  string `json:"url"`
+	} `json:"exhibits"`
+}
+
+// ESGScoresData from quoteSummary esgScores module (Sustainalytics risk
+// scores; lower is better).
+type ESGScoresData struct {
+	TotalEsg           YahooValue `json:"totalEsg"`
+	EnvironmentScore   YahooValue `json:"environmentScore"`
+	SocialScore        YahooValue `json:"socialScore"`
+	GovernanceScore    YahooValue `json:"governanceScore"`
+	Percentile         YahooValue `json:"percentile"`
+	HighestControversy float64    `json:"highestControversy"`
+	PeerGroup          string     `json:"peerGroup"`
+	ESGPerformance     string     `json:"esgPerformance"`
+	RatingYear         int        `json:"ratingYear"`
+	RatingMonth        int        `json:"ratingMonth"`
+	Adult              bool       `json:"adult"`
+	Alcoholic          bool       `json:"alcoholic"`
+	Gambling           bool       `json:"gambling"`
+	Tobacco            bool       `json:"tobacco"`
+	SmallArms          bool       `json:"smallArms"`
+	MilitaryContract   bool       `json:"militaryContract"`
+	ControversialArms  bool       `json:"controversialWeapons"`
+	Nuclear            bool       `json:"nuclear"`
+	Coal               bool       `json:"coal"`
+}
+
+// TrendData from the quoteSummary indexTrend, sectorTrend and industryTrend
+// modules: valuation and growth estimates for a benchmark.
+type TrendData struct {
+	Symbol    string     `json:"symbol"`
+	PERatio   YahooValue `json:"peRatio"`
+	PEGRatio  YahooValue `json:"pegRatio"`
+	Estimates []struct {
+		Period string     `json:"period"`
+		Growth YahooValue `json:"growth"`
+	} `json:"estimates"`
+}
+
+// PageViewsData from quoteSummary pageViews module: UP, DOWN or NEUTRAL
+// interest trends on Yahoo Finance.
+type PageViewsData struct {
+	ShortTermTrend string `json:"shortTermTrend"`
+	MidTermTrend   string `json:"midTermTrend"`
+	LongTermTrend  string `json:"longTermTrend"`
+}
+
 // OptionsResponse from v7 finance/options.
 type OptionsResponse struct {
 	OptionChain struct {